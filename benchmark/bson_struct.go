@@ -31,6 +31,29 @@ func BSONFlatStructDecoding(ctx context.Context, tm TimerManager, iters int) err
 	return nil
 }
 
+// BSONDeepStructDecoding decodes deep_bson.json straight from its raw bytes into a matching struct,
+// for comparison with building a document in BSONDeepDocumentDecoding.
+func BSONDeepStructDecoding(ctx context.Context, tm TimerManager, iters int) error {
+	r, err := loadSourceRaw(getProjectRoot(), perfDataDir, bsonDataDir, deepBSONData)
+	if err != nil {
+		return err
+	}
+
+	tm.ResetTimer()
+
+	for i := 0; i < iters; i++ {
+		out := deepBSON{}
+		err := bson.UnmarshalInto(r, &out, nil)
+		if err != nil {
+			return err
+		}
+		if out.Left.Left.Left.Left.Left.Left == "" {
+			return errors.New("unmarshaling error")
+		}
+	}
+	return nil
+}
+
 func BSONFlatStructEncoding(ctx context.Context, tm TimerManager, iters int) error {
 	r, err := loadSourceRaw(getProjectRoot(), perfDataDir, bsonDataDir, flatBSONData)
 	if err != nil {
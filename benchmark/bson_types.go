@@ -304,3 +304,35 @@ type flatBSON struct {
 	ZswQbWEI  int64
 	PfZSRHnn  int
 }
+
+// deepBSON matches the shape of deep_bson.json, a complete binary tree of depth six whose leaves are
+// strings.
+type deepBSON struct {
+	Right deepBSON5 `bson:"right"`
+	Left  deepBSON5 `bson:"left"`
+}
+
+type deepBSON5 struct {
+	Right deepBSON4 `bson:"right"`
+	Left  deepBSON4 `bson:"left"`
+}
+
+type deepBSON4 struct {
+	Right deepBSON3 `bson:"right"`
+	Left  deepBSON3 `bson:"left"`
+}
+
+type deepBSON3 struct {
+	Right deepBSON2 `bson:"right"`
+	Left  deepBSON2 `bson:"left"`
+}
+
+type deepBSON2 struct {
+	Right deepBSONLeaves `bson:"right"`
+	Left  deepBSONLeaves `bson:"left"`
+}
+
+type deepBSONLeaves struct {
+	Right string `bson:"right"`
+	Left  string `bson:"left"`
+}
@@ -167,6 +167,12 @@ func getAllCases() []*CaseDefinition {
 			Size:    75310000,
 			Runtime: StandardRuntime,
 		},
+		{
+			Bench:   BSONDeepStructDecoding,
+			Count:   tenThousand,
+			Size:    19640000,
+			Runtime: StandardRuntime,
+		},
 		{
 			Bench:   BSONFlatStructEncoding,
 			Count:   tenThousand,
@@ -8,6 +8,7 @@ package bsoncodec
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
@@ -82,6 +83,10 @@ func (vde ValueDecoderError) Error() string {
 type EncodeContext struct {
 	*Registry
 	MinSize bool
+
+	// NilSliceAsEmpty causes nil slices to be encoded as empty BSON arrays instead of BSON null.
+	// Nil maps are always encoded as empty BSON documents.
+	NilSliceAsEmpty bool
 }
 
 // DecodeContext is the contextual information required for a Codec to decode a
@@ -89,6 +94,26 @@ type EncodeContext struct {
 type DecodeContext struct {
 	*Registry
 	Truncate bool
+
+	// DefaultDocumentInt causes BSON int32 and int64 values that are decoded into an empty interface
+	// to be decoded as Go ints. Int64 values that do not fit in an int are still decoded as int64.
+	DefaultDocumentInt bool
+
+	// DocumentType is the type that embedded documents are decoded into when they're decoded into an
+	// empty interface. When it's set, arrays decoded into an empty interface are decoded as
+	// []interface{}. When decoding into a map with interface{} values, it defaults to the type of the
+	// map so that nested documents are decoded into maps as well.
+	DocumentType reflect.Type
+
+	// DisallowUnknownFields causes decoding a document into a struct to return an error when the
+	// document contains a key that does not match a field of the struct and the struct does not have
+	// an inline map.
+	DisallowUnknownFields bool
+
+	// UseCaseInsensitiveFieldMatching causes decoding a document into a struct to match a key to a
+	// struct field case-insensitively when no field matches it exactly. Decoding returns an error if
+	// more than one field matches case-insensitively.
+	UseCaseInsensitiveFieldMatching bool
 }
 
 // ValueCodec is the interface that groups the methods to encode and decode
@@ -131,6 +156,16 @@ func (fn ValueDecoderFunc) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader,
 	return fn(dc, vr, val)
 }
 
+// EncodeHook is called with each value encoded using a Registry before the value's registered
+// encoder. If the hook writes the value to vw itself, it must return true so the registered encoder
+// is skipped. Pointers and the values they point to are passed to the hook separately.
+type EncodeHook func(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) (handled bool, err error)
+
+// DecodeHook is called with each value decoded using a Registry before the value's registered
+// decoder. val is the settable value being decoded into. If the hook reads the value from vr and
+// sets val itself, it must return true so the registered decoder is skipped.
+type DecodeHook func(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) (handled bool, err error)
+
 // CodecZeroer is the interface implemented by Codecs that can also determine if
 // a value of the type that would be encoded is zero.
 type CodecZeroer interface {
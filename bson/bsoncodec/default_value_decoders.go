@@ -47,6 +47,7 @@ func (dvd DefaultValueDecoders) RegisterDefaultDecoders(rb *RegistryBuilder) {
 		RegisterDecoder(reflect.PtrTo(tDecimal), ValueDecoderFunc(dvd.Decimal128DecodeValue)).
 		RegisterDecoder(reflect.PtrTo(tJSONNumber), ValueDecoderFunc(dvd.JSONNumberDecodeValue)).
 		RegisterDecoder(reflect.PtrTo(tURL), ValueDecoderFunc(dvd.URLDecodeValue)).
+		RegisterDecoder(reflect.PtrTo(tIP), NewIPCodec()).
 		RegisterDecoder(tValueUnmarshaler, ValueDecoderFunc(dvd.ValueUnmarshalerDecodeValue)).
 		RegisterDefaultDecoder(reflect.Bool, ValueDecoderFunc(dvd.BooleanDecodeValue)).
 		RegisterDefaultDecoder(reflect.Int, ValueDecoderFunc(dvd.IntDecodeValue)).
@@ -65,6 +66,7 @@ func (dvd DefaultValueDecoders) RegisterDefaultDecoders(rb *RegistryBuilder) {
 		RegisterDefaultDecoder(reflect.Map, ValueDecoderFunc(dvd.MapDecodeValue)).
 		RegisterDefaultDecoder(reflect.Slice, ValueDecoderFunc(dvd.SliceDecodeValue)).
 		RegisterDefaultDecoder(reflect.String, ValueDecoderFunc(dvd.StringDecodeValue)).
+		RegisterDefaultDecoder(reflect.Ptr, NewPointerCodec()).
 		RegisterDefaultDecoder(reflect.Struct, &StructCodec{cache: make(map[reflect.Type]*structDescription), parser: DefaultStructTagParser})
 }
 
@@ -395,12 +397,18 @@ func (dvd DefaultValueDecoders) StringDecodeValue(dctx DecodeContext, vr bsonrw.
 	var str string
 	var err error
 	switch vr.Type() {
-	// TODO(GODRIVER-577): Handle JavaScript and Symbol BSON types when allowed.
+	// TODO(GODRIVER-577): Handle JavaScript BSON types when allowed.
 	case bsontype.String:
 		str, err = vr.ReadString()
 		if err != nil {
 			return err
 		}
+	case bsontype.Symbol:
+		// Symbols are deprecated and only appear in legacy documents; they hold a plain string.
+		str, err = vr.ReadSymbol()
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("cannot decode %v into a string type", vr.Type())
 	}
@@ -506,7 +514,27 @@ func (dvd DefaultValueDecoders) JSONNumberDecodeValue(dc DecodeContext, vr bsonr
 }
 
 // URLDecodeValue is the ValueDecoderFunc for url.URL.
+//
+// A BSON null sets a **url.URL to nil and a *url.URL to the zero url.URL.
 func (dvd DefaultValueDecoders) URLDecodeValue(dc DecodeContext, vr bsonrw.ValueReader, i interface{}) error {
+	if vr.Type() == bsontype.Null {
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+		switch target := i.(type) {
+		case *url.URL:
+			if target != nil {
+				*target = url.URL{}
+				return nil
+			}
+		case **url.URL:
+			if target != nil {
+				*target = nil
+				return nil
+			}
+		}
+		return ValueDecoderError{Name: "URLDecodeValue", Types: []interface{}{(*url.URL)(nil), (**url.URL)(nil)}, Received: i}
+	}
 	if vr.Type() != bsontype.String {
 		return fmt.Errorf("cannot decode %v into a *url.URL", vr.Type())
 	}
@@ -597,7 +625,8 @@ func (dvd DefaultValueDecoders) ByteSliceDecodeValue(dc DecodeContext, vr bsonrw
 	return nil
 }
 
-// MapDecodeValue is the ValueDecoderFunc for map[string]* types.
+// MapDecodeValue is the ValueDecoderFunc for map[string]* types. Embedded documents decoded into
+// interface{} values of the map are decoded into the type of the map unless DocumentType is set.
 func (dvd DefaultValueDecoders) MapDecodeValue(dc DecodeContext, vr bsonrw.ValueReader, i interface{}) error {
 	val := reflect.ValueOf(i)
 	if !val.IsValid() || val.Kind() != reflect.Ptr || val.IsNil() {
@@ -624,6 +653,9 @@ func (dvd DefaultValueDecoders) MapDecodeValue(dc DecodeContext, vr bsonrw.Value
 	if err != nil {
 		return err
 	}
+	if eType == tEmpty && dc.DocumentType == nil {
+		dc.DocumentType = mVal.Type()
+	}
 
 	for {
 		key, vr, err := dr.ReadElement()
@@ -0,0 +1,104 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+)
+
+// IPCodec is the Codec used for net.IP values. By default an IP is encoded as a BSON binary
+// containing its bytes. To encode IPs as strings instead, register an IPCodec with EncodeString
+// set:
+//
+//	rb.RegisterEncoder(reflect.TypeOf(net.IP{}), &bsoncodec.IPCodec{EncodeString: true})
+//
+// Both forms can always be decoded. A nil IP is encoded as a BSON null.
+type IPCodec struct {
+	EncodeString bool
+}
+
+var _ ValueEncoder = &IPCodec{}
+var _ ValueDecoder = &IPCodec{}
+
+// NewIPCodec returns an IPCodec that encodes IPs as BSON binary.
+func NewIPCodec() *IPCodec {
+	return &IPCodec{}
+}
+
+// EncodeValue implements the ValueEncoder interface.
+func (ic *IPCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, i interface{}) error {
+	var ip net.IP
+	switch t := i.(type) {
+	case net.IP:
+		ip = t
+	case *net.IP:
+		if t == nil {
+			return vw.WriteNull()
+		}
+		ip = *t
+	default:
+		return ValueEncoderError{
+			Name:     "IPCodec.EncodeValue",
+			Types:    []interface{}{net.IP{}, (*net.IP)(nil)},
+			Received: i,
+		}
+	}
+
+	if ip == nil {
+		return vw.WriteNull()
+	}
+	if ic.EncodeString {
+		return vw.WriteString(ip.String())
+	}
+	return vw.WriteBinary(ip)
+}
+
+// DecodeValue implements the ValueDecoder interface. It decodes a BSON binary of 4 or 16 bytes, a
+// BSON string containing an IPv4 or IPv6 address, or a BSON null into a *net.IP.
+func (ic *IPCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, i interface{}) error {
+	target, ok := i.(*net.IP)
+	if !ok || target == nil {
+		return ValueDecoderError{Name: "IPCodec.DecodeValue", Types: []interface{}{(*net.IP)(nil)}, Received: i}
+	}
+
+	switch vr.Type() {
+	case bsontype.Binary:
+		data, subtype, err := vr.ReadBinary()
+		if err != nil {
+			return err
+		}
+		if subtype != 0x00 {
+			return fmt.Errorf("IPCodec.DecodeValue can only be used to decode subtype 0x00 for %s, got %v", bsontype.Binary, subtype)
+		}
+		if len(data) != net.IPv4len && len(data) != net.IPv6len {
+			return fmt.Errorf("cannot decode %d bytes into a net.IP", len(data))
+		}
+		*target = net.IP(data)
+	case bsontype.String:
+		str, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		ip := net.ParseIP(str)
+		if ip == nil {
+			return fmt.Errorf("cannot decode %q into a net.IP", str)
+		}
+		*target = ip
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+		*target = nil
+	default:
+		return fmt.Errorf("cannot decode %v into a net.IP", vr.Type())
+	}
+	return nil
+}
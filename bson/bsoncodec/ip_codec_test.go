@@ -0,0 +1,120 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw/bsonrwtest"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+)
+
+func TestIPCodec(t *testing.T) {
+	reg := buildDefaultRegistry()
+	ipv4 := net.ParseIP("10.0.0.1")
+
+	t.Run("lookup", func(t *testing.T) {
+		enc, err := reg.LookupEncoder(tIP)
+		noerr(t, err)
+		if _, ok := enc.(*IPCodec); !ok {
+			t.Errorf("expected an *IPCodec encoder for net.IP; got %T", enc)
+		}
+		dec, err := reg.LookupDecoder(reflect.PtrTo(tIP))
+		noerr(t, err)
+		if _, ok := dec.(*IPCodec); !ok {
+			t.Errorf("expected an *IPCodec decoder for *net.IP; got %T", dec)
+		}
+	})
+
+	t.Run("EncodeValue", func(t *testing.T) {
+		var nilip *net.IP
+		testCases := []struct {
+			name   string
+			codec  *IPCodec
+			val    interface{}
+			invoke bsonrwtest.Invoked
+		}{
+			{"binary", NewIPCodec(), ipv4, bsonrwtest.WriteBinary},
+			{"string", &IPCodec{EncodeString: true}, ipv4, bsonrwtest.WriteString},
+			{"pointer", NewIPCodec(), &ipv4, bsonrwtest.WriteBinary},
+			{"nil IP", NewIPCodec(), net.IP(nil), bsonrwtest.WriteNull},
+			{"nil pointer", &IPCodec{EncodeString: true}, nilip, bsonrwtest.WriteNull},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				llvrw := &bsonrwtest.ValueReaderWriter{T: t}
+				err := tc.codec.EncodeValue(EncodeContext{Registry: reg}, llvrw, tc.val)
+				noerr(t, err)
+				if llvrw.Invoked != tc.invoke {
+					t.Errorf("Incorrect method invoked. got %v; want %v", llvrw.Invoked, tc.invoke)
+				}
+			})
+		}
+
+		t.Run("wrong type", func(t *testing.T) {
+			llvrw := &bsonrwtest.ValueReaderWriter{T: t}
+			err := NewIPCodec().EncodeValue(EncodeContext{Registry: reg}, llvrw, "10.0.0.1")
+			if _, ok := err.(ValueEncoderError); !ok {
+				t.Errorf("Expected a ValueEncoderError. got %v", err)
+			}
+		})
+	})
+
+	t.Run("DecodeValue", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			llvrw *bsonrwtest.ValueReaderWriter
+			want  net.IP
+			err   bool
+		}{
+			{
+				"binary",
+				&bsonrwtest.ValueReaderWriter{
+					BSONType: bsontype.Binary,
+					Return:   bsoncore.Value{Type: bsontype.Binary, Data: bsoncore.AppendBinary(nil, 0x00, ipv4)},
+				},
+				ipv4,
+				false,
+			},
+			{
+				"binary of the wrong length",
+				&bsonrwtest.ValueReaderWriter{
+					BSONType: bsontype.Binary,
+					Return:   bsoncore.Value{Type: bsontype.Binary, Data: bsoncore.AppendBinary(nil, 0x00, []byte{0x01, 0x02})},
+				},
+				nil,
+				true,
+			},
+			{"string", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.String, Return: "::1"}, net.ParseIP("::1"), false},
+			{"invalid string", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.String, Return: "not-an-ip"}, nil, true},
+			{"null", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Null}, nil, false},
+			{"wrong type", &bsonrwtest.ValueReaderWriter{BSONType: bsontype.Int32}, nil, true},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.llvrw.T = t
+				got := net.IP{0x7f, 0x00, 0x00, 0x01}
+				err := NewIPCodec().DecodeValue(DecodeContext{Registry: reg}, tc.llvrw, &got)
+				if tc.err {
+					if err == nil {
+						t.Errorf("Expected an error decoding into a net.IP")
+					}
+					return
+				}
+				noerr(t, err)
+				if !got.Equal(tc.want) || (got == nil) != (tc.want == nil) {
+					t.Errorf("Decoded IPs do not match. got %v; want %v", got, tc.want)
+				}
+			})
+		}
+	})
+}
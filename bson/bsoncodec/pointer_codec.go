@@ -0,0 +1,89 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+)
+
+// PointerCodec is the Codec used for pointers to types that do not have a
+// registered encoder or decoder of their own.
+type PointerCodec struct{}
+
+var _ ValueEncoder = &PointerCodec{}
+var _ ValueDecoder = &PointerCodec{}
+
+// NewPointerCodec returns a PointerCodec.
+func NewPointerCodec() *PointerCodec {
+	return &PointerCodec{}
+}
+
+// EncodeValue dereferences i until it reaches a value that isn't a pointer and
+// encodes that value with the encoder registered for its type. If a nil
+// pointer is found at any level, a BSON null is written instead.
+func (pc *PointerCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, i interface{}) error {
+	val := reflect.ValueOf(i)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return vw.WriteNull()
+		}
+		val = val.Elem()
+	}
+
+	if !val.IsValid() {
+		return vw.WriteNull()
+	}
+
+	enc, err := ec.LookupEncoder(val.Type())
+	if err != nil {
+		return err
+	}
+	return enc.EncodeValue(ec, vw, val.Interface())
+}
+
+// DecodeValue decodes into the pointer pointed to by i. A BSON null sets the
+// pointer to nil, otherwise any nil pointers are allocated and the value is
+// decoded with the decoder registered for the element type.
+func (pc *PointerCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, i interface{}) error {
+	val := reflect.ValueOf(i)
+	if !val.IsValid() || val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("PointerCodec.DecodeValue can only be used to decode non-nil pointers, but got %T", i)
+	}
+
+	val = val.Elem()
+	if val.Kind() != reflect.Ptr {
+		// i points at the target value itself, so decode directly into it.
+		dec, err := dc.LookupDecoder(val.Type())
+		if err != nil {
+			return err
+		}
+		return dec.DecodeValue(dc, vr, i)
+	}
+
+	if !val.CanSet() {
+		return fmt.Errorf("PointerCodec.DecodeValue can only be used to decode settable values, but got %T", i)
+	}
+
+	if vr.Type() == bsontype.Null {
+		val.Set(reflect.Zero(val.Type()))
+		return vr.ReadNull()
+	}
+
+	if val.IsNil() {
+		val.Set(reflect.New(val.Type().Elem()))
+	}
+
+	dec, err := dc.LookupDecoder(val.Type().Elem())
+	if err != nil {
+		return err
+	}
+	return dec.DecodeValue(dc, vr, val.Interface())
+}
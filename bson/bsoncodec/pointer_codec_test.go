@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsoncodec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw/bsonrwtest"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+)
+
+func TestPointerCodec(t *testing.T) {
+	pc := NewPointerCodec()
+	reg := buildDefaultRegistry()
+
+	t.Run("lookup", func(t *testing.T) {
+		for _, i := range []interface{}{new(string), new(*string), new([]int), new(map[string]int)} {
+			enc, err := reg.LookupEncoder(reflect.TypeOf(i))
+			noerr(t, err)
+			if _, ok := enc.(*PointerCodec); !ok {
+				t.Errorf("expected a *PointerCodec encoder for %T; got %T", i, enc)
+			}
+			dec, err := reg.LookupDecoder(reflect.TypeOf(i))
+			noerr(t, err)
+			if _, ok := dec.(*PointerCodec); !ok {
+				t.Errorf("expected a *PointerCodec decoder for %T; got %T", i, dec)
+			}
+		}
+	})
+
+	t.Run("EncodeValue", func(t *testing.T) {
+		str := "foo"
+		strp := &str
+		var nilstr *string
+
+		testCases := []struct {
+			name   string
+			val    interface{}
+			invoke bsonrwtest.Invoked
+		}{
+			{"nil pointer", nilstr, bsonrwtest.WriteNull},
+			{"pointer", strp, bsonrwtest.WriteString},
+			{"pointer to pointer", &strp, bsonrwtest.WriteString},
+			{"pointer to nil pointer", &nilstr, bsonrwtest.WriteNull},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				llvrw := &bsonrwtest.ValueReaderWriter{T: t}
+				err := pc.EncodeValue(EncodeContext{Registry: reg}, llvrw, tc.val)
+				noerr(t, err)
+				if llvrw.Invoked != tc.invoke {
+					t.Errorf("Incorrect method invoked. got %v; want %v", llvrw.Invoked, tc.invoke)
+				}
+			})
+		}
+	})
+
+	t.Run("DecodeValue", func(t *testing.T) {
+		t.Run("allocates pointers", func(t *testing.T) {
+			var got **string
+			llvrw := &bsonrwtest.ValueReaderWriter{T: t, BSONType: bsontype.String, Return: "foo"}
+			err := pc.DecodeValue(DecodeContext{Registry: reg}, llvrw, &got)
+			noerr(t, err)
+			if got == nil || *got == nil || **got != "foo" {
+				t.Errorf("expected pointers to be allocated and the value decoded; got %v", got)
+			}
+		})
+		t.Run("null", func(t *testing.T) {
+			str := "foo"
+			got := &str
+			llvrw := &bsonrwtest.ValueReaderWriter{T: t, BSONType: bsontype.Null}
+			err := pc.DecodeValue(DecodeContext{Registry: reg}, llvrw, &got)
+			noerr(t, err)
+			if got != nil {
+				t.Errorf("expected pointer to be set to nil; got %v", got)
+			}
+			if llvrw.Invoked != bsonrwtest.ReadNull {
+				t.Errorf("Incorrect method invoked. got %v; want %v", llvrw.Invoked, bsonrwtest.ReadNull)
+			}
+		})
+		t.Run("non-pointer", func(t *testing.T) {
+			llvrw := &bsonrwtest.ValueReaderWriter{T: t, BSONType: bsontype.String, Return: "foo"}
+			err := pc.DecodeValue(DecodeContext{Registry: reg}, llvrw, "foo")
+			if err == nil {
+				t.Errorf("expected an error decoding into a non-pointer")
+			}
+		})
+	})
+}
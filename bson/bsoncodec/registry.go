@@ -10,6 +10,8 @@ import (
 	"errors"
 	"reflect"
 	"sync"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
 )
 
 // ErrNilType is returned when nil is passed to either LookupEncoder or LookupDecoder.
@@ -52,6 +54,9 @@ type RegistryBuilder struct {
 	typeDecoders      map[reflect.Type]ValueDecoder
 	interfaceDecoders []interfaceValueDecoder
 	kindDecoders      map[reflect.Kind]ValueDecoder
+
+	encodeHook EncodeHook
+	decodeHook DecodeHook
 }
 
 // A Registry is used to store and retrieve codecs for types and interfaces. This type is the main
@@ -66,6 +71,9 @@ type Registry struct {
 	kindEncoders map[reflect.Kind]ValueEncoder
 	kindDecoders map[reflect.Kind]ValueDecoder
 
+	encodeHook EncodeHook
+	decodeHook DecodeHook
+
 	mu sync.RWMutex
 }
 
@@ -153,6 +161,20 @@ func (rb *RegistryBuilder) RegisterDefaultDecoder(kind reflect.Kind, dec ValueDe
 	return rb
 }
 
+// SetEncodeHook sets a hook that is called with each value before it is encoded. Passing nil removes
+// the hook.
+func (rb *RegistryBuilder) SetEncodeHook(hook EncodeHook) *RegistryBuilder {
+	rb.encodeHook = hook
+	return rb
+}
+
+// SetDecodeHook sets a hook that is called with each value before it is decoded. Passing nil removes
+// the hook.
+func (rb *RegistryBuilder) SetDecodeHook(hook DecodeHook) *RegistryBuilder {
+	rb.decodeHook = hook
+	return rb
+}
+
 // Build creates a Registry from the current state of this RegistryBuilder.
 func (rb *RegistryBuilder) Build() *Registry {
 	registry := new(Registry)
@@ -183,6 +205,9 @@ func (rb *RegistryBuilder) Build() *Registry {
 		registry.kindDecoders[kind] = dec
 	}
 
+	registry.encodeHook = rb.encodeHook
+	registry.decodeHook = rb.decodeHook
+
 	return registry
 }
 
@@ -191,7 +216,17 @@ func (rb *RegistryBuilder) Build() *Registry {
 // precedence over an encoder registered for an interface the type satisfies,
 // which takes precedence over an encoder for the reflect.Kind of the value. If
 // no encoder can be found, an error is returned.
+//
+// If the registry has an encode hook, the returned encoder calls it first.
 func (r *Registry) LookupEncoder(t reflect.Type) (ValueEncoder, error) {
+	enc, err := r.lookupEncoder(t)
+	if err != nil || r.encodeHook == nil {
+		return enc, err
+	}
+	return newHookEncoder(r.encodeHook, enc), nil
+}
+
+func (r *Registry) lookupEncoder(t reflect.Type) (ValueEncoder, error) {
 	if t == nil {
 		return nil, ErrNilType
 	}
@@ -225,6 +260,11 @@ func (r *Registry) LookupEncoder(t reflect.Type) (ValueEncoder, error) {
 	}
 
 	if t.Kind() == reflect.Ptr {
+		// Pointers without a registered encoder of their own are handled by the
+		// encoder registered for reflect.Ptr, if there is one.
+		if enc, found := r.kindEncoders[reflect.Ptr]; found {
+			return enc, nil
+		}
 		t = t.Elem()
 	}
 
@@ -267,7 +307,17 @@ func (r *Registry) lookupInterfaceEncoder(t reflect.Type) (ValueEncoder, bool) {
 // precedence over a decoder registered for an interface the type satisfies,
 // which takes precedence over a decoder for the reflect.Kind of the value. If
 // no decoder can be found, an error is returned.
+//
+// If the registry has a decode hook, the returned decoder calls it first.
 func (r *Registry) LookupDecoder(t reflect.Type) (ValueDecoder, error) {
+	dec, err := r.lookupDecoder(t)
+	if err != nil || r.decodeHook == nil {
+		return dec, err
+	}
+	return hookDecoder{hook: r.decodeHook, vd: dec}, nil
+}
+
+func (r *Registry) lookupDecoder(t reflect.Type) (ValueDecoder, error) {
 	if t == nil {
 		return nil, ErrNilType
 	}
@@ -301,6 +351,11 @@ func (r *Registry) LookupDecoder(t reflect.Type) (ValueDecoder, error) {
 	}
 
 	if t.Kind() == reflect.Ptr {
+		// Pointers without a registered decoder of their own are handled by the
+		// decoder registered for reflect.Ptr, if there is one.
+		if dec, found := r.kindDecoders[reflect.Ptr]; found {
+			return dec, nil
+		}
 		t = t.Elem()
 	}
 
@@ -347,3 +402,49 @@ type interfaceValueDecoder struct {
 	i  reflect.Type
 	vd ValueDecoder
 }
+
+// hookEncoder calls an EncodeHook before a ValueEncoder.
+type hookEncoder struct {
+	hook EncodeHook
+	ve   ValueEncoder
+}
+
+// hookZeroerEncoder is a hookEncoder for a ValueEncoder that is also a CodecZeroer.
+type hookZeroerEncoder struct {
+	hookEncoder
+	CodecZeroer
+}
+
+func newHookEncoder(hook EncodeHook, ve ValueEncoder) ValueEncoder {
+	he := hookEncoder{hook: hook, ve: ve}
+	if cz, ok := ve.(CodecZeroer); ok {
+		return hookZeroerEncoder{hookEncoder: he, CodecZeroer: cz}
+	}
+	return he
+}
+
+func (he hookEncoder) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val interface{}) error {
+	handled, err := he.hook(ec, vw, reflect.ValueOf(val))
+	if err != nil || handled {
+		return err
+	}
+	return he.ve.EncodeValue(ec, vw, val)
+}
+
+// hookDecoder calls a DecodeHook before a ValueDecoder.
+type hookDecoder struct {
+	hook DecodeHook
+	vd   ValueDecoder
+}
+
+func (hd hookDecoder) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val interface{}) error {
+	rval := reflect.ValueOf(val)
+	if rval.Kind() == reflect.Ptr && !rval.IsNil() {
+		rval = rval.Elem()
+	}
+	handled, err := hd.hook(dc, vr, rval)
+	if err != nil || handled {
+		return err
+	}
+	return hd.vd.DecodeValue(dc, vr, val)
+}
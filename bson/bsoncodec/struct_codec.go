@@ -10,9 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
 )
 
 var defaultStructCodec = &StructCodec{
@@ -102,7 +104,7 @@ func (sc *StructCodec) EncodeValue(r EncodeContext, vw bsonrw.ValueWriter, i int
 			return err
 		}
 
-		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize}
+		ectx := EncodeContext{Registry: r.Registry, MinSize: desc.minSize, NilSliceAsEmpty: r.NilSliceAsEmpty}
 		err = encoder.EncodeValue(ectx, vw2, rv.Interface())
 		if err != nil {
 			return err
@@ -173,11 +175,28 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, i int
 			return err
 		}
 
+		// Undefined is deprecated and carries no value, so legacy documents that contain it are
+		// decoded as if the field were absent.
+		if vr.Type() == bsontype.Undefined {
+			err = vr.Skip()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		fd, exists := sd.fm[name]
+		if !exists && r.UseCaseInsensitiveFieldMatching {
+			fd, exists, err = sd.foldedField(name)
+			if err != nil {
+				return err
+			}
+		}
 		if !exists {
 			if sd.inlineMap < 0 {
-				// The encoding/json package requires a flag to return on error for non-existent fields.
-				// This functionality seems appropriate for the struct codec.
+				if r.DisallowUnknownFields {
+					return fmt.Errorf("cannot decode element '%s' into %v; the struct has no matching field", name, val.Type())
+				}
 				err = vr.Skip()
 				if err != nil {
 					return err
@@ -209,7 +228,8 @@ func (sc *StructCodec) DecodeValue(r DecodeContext, vr bsonrw.ValueReader, i int
 		}
 		field = field.Addr()
 
-		dctx := DecodeContext{Registry: r.Registry, Truncate: fd.truncate}
+		dctx := r
+		dctx.Truncate = fd.truncate
 		if fd.decoder == nil {
 			return ErrNoDecoder{Type: field.Elem().Type()}
 		}
@@ -259,6 +279,25 @@ type structDescription struct {
 	inlineMap int
 }
 
+// foldedField returns the field whose name matches name case-insensitively. It returns an error if
+// more than one field matches.
+func (sd *structDescription) foldedField(name string) (fieldDescription, bool, error) {
+	var match fieldDescription
+	var found bool
+	for _, fd := range sd.fl {
+		if !strings.EqualFold(fd.name, name) {
+			continue
+		}
+		if found {
+			return fieldDescription{}, false, fmt.Errorf(
+				"cannot decode element '%s'; it matches both the '%s' and '%s' fields case-insensitively", name, match.name, fd.name,
+			)
+		}
+		match, found = fd, true
+	}
+	return match, found, nil
+}
+
 type fieldDescription struct {
 	name      string
 	idx       int
@@ -8,6 +8,7 @@ package bsoncodec
 
 import (
 	"encoding/json"
+	"net"
 	"net/url"
 	"reflect"
 	"time"
@@ -54,6 +55,7 @@ var tByteSlice = reflect.TypeOf([]byte(nil))
 var tByte = reflect.TypeOf(byte(0x00))
 var tURL = reflect.TypeOf(url.URL{})
 var tJSONNumber = reflect.TypeOf(json.Number(""))
+var tIP = reflect.TypeOf(net.IP(nil))
 
 var tValueMarshaler = reflect.TypeOf((*ValueMarshaler)(nil)).Elem()
 var tValueUnmarshaler = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
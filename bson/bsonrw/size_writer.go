@@ -0,0 +1,176 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonrw
+
+import (
+	"strconv"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/bson/decimal"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+)
+
+var _ ValueWriter = (*SizeWriter)(nil)
+var _ BytesWriter = (*SizeWriter)(nil)
+
+// SizeWriter is a ValueWriter that counts the number of bytes the BSON encoding of a document
+// would take instead of writing it. As with the ValueWriter returned from NewBSONValueWriter, the
+// top-level value must be a document.
+type SizeWriter struct {
+	size    int
+	started bool
+}
+
+// NewBSONSizeWriter creates a SizeWriter.
+func NewBSONSizeWriter() *SizeWriter {
+	return new(SizeWriter)
+}
+
+// Size returns the number of bytes counted so far.
+func (sw *SizeWriter) Size() int { return sw.size }
+
+// Reset sets the count to zero so the SizeWriter can be reused for another document.
+func (sw *SizeWriter) Reset() {
+	sw.size = 0
+	sw.started = false
+}
+
+// add counts n bytes for a value. Values other than documents cannot be written at the top level.
+func (sw *SizeWriter) add(n int) error {
+	if !sw.started {
+		return TransitionError{current: mTopLevel}
+	}
+	sw.size += n
+	return nil
+}
+
+func stringSize(s string) int { return 4 + len(s) + 1 }
+
+// WriteValueBytes implements the BytesWriter interface.
+func (sw *SizeWriter) WriteValueBytes(t bsontype.Type, b []byte) error {
+	return sw.add(len(b))
+}
+
+// WriteArray implements the ValueWriter interface.
+func (sw *SizeWriter) WriteArray() (ArrayWriter, error) {
+	if err := sw.add(4); err != nil {
+		return nil, err
+	}
+	return &sizeArrayWriter{sw: sw}, nil
+}
+
+// WriteBinary implements the ValueWriter interface.
+func (sw *SizeWriter) WriteBinary(b []byte) error {
+	return sw.WriteBinaryWithSubtype(b, 0x00)
+}
+
+// WriteBinaryWithSubtype implements the ValueWriter interface.
+func (sw *SizeWriter) WriteBinaryWithSubtype(b []byte, btype byte) error {
+	n := 4 + 1 + len(b)
+	if btype == 0x02 {
+		n += 4 // the old binary subtype repeats the length
+	}
+	return sw.add(n)
+}
+
+// WriteBoolean implements the ValueWriter interface.
+func (sw *SizeWriter) WriteBoolean(bool) error { return sw.add(1) }
+
+// WriteCodeWithScope implements the ValueWriter interface.
+func (sw *SizeWriter) WriteCodeWithScope(code string) (DocumentWriter, error) {
+	// The total length, the code, and the scope document's length.
+	if err := sw.add(4 + stringSize(code) + 4); err != nil {
+		return nil, err
+	}
+	return sw, nil
+}
+
+// WriteDBPointer implements the ValueWriter interface.
+func (sw *SizeWriter) WriteDBPointer(ns string, oid objectid.ObjectID) error {
+	return sw.add(stringSize(ns) + len(oid))
+}
+
+// WriteDateTime implements the ValueWriter interface.
+func (sw *SizeWriter) WriteDateTime(dt int64) error { return sw.add(8) }
+
+// WriteDecimal128 implements the ValueWriter interface.
+func (sw *SizeWriter) WriteDecimal128(decimal.Decimal128) error { return sw.add(16) }
+
+// WriteDouble implements the ValueWriter interface.
+func (sw *SizeWriter) WriteDouble(float64) error { return sw.add(8) }
+
+// WriteInt32 implements the ValueWriter interface.
+func (sw *SizeWriter) WriteInt32(int32) error { return sw.add(4) }
+
+// WriteInt64 implements the ValueWriter interface.
+func (sw *SizeWriter) WriteInt64(int64) error { return sw.add(8) }
+
+// WriteJavascript implements the ValueWriter interface.
+func (sw *SizeWriter) WriteJavascript(code string) error { return sw.add(stringSize(code)) }
+
+// WriteMaxKey implements the ValueWriter interface.
+func (sw *SizeWriter) WriteMaxKey() error { return sw.add(0) }
+
+// WriteMinKey implements the ValueWriter interface.
+func (sw *SizeWriter) WriteMinKey() error { return sw.add(0) }
+
+// WriteNull implements the ValueWriter interface.
+func (sw *SizeWriter) WriteNull() error { return sw.add(0) }
+
+// WriteObjectID implements the ValueWriter interface.
+func (sw *SizeWriter) WriteObjectID(oid objectid.ObjectID) error { return sw.add(len(oid)) }
+
+// WriteRegex implements the ValueWriter interface.
+func (sw *SizeWriter) WriteRegex(pattern, options string) error {
+	return sw.add(len(pattern) + 1 + len(options) + 1)
+}
+
+// WriteString implements the ValueWriter interface.
+func (sw *SizeWriter) WriteString(s string) error { return sw.add(stringSize(s)) }
+
+// WriteDocument implements the ValueWriter interface.
+func (sw *SizeWriter) WriteDocument() (DocumentWriter, error) {
+	sw.started = true
+	sw.size += 4
+	return sw, nil
+}
+
+// WriteSymbol implements the ValueWriter interface.
+func (sw *SizeWriter) WriteSymbol(symbol string) error { return sw.add(stringSize(symbol)) }
+
+// WriteTimestamp implements the ValueWriter interface.
+func (sw *SizeWriter) WriteTimestamp(t, i uint32) error { return sw.add(8) }
+
+// WriteUndefined implements the ValueWriter interface.
+func (sw *SizeWriter) WriteUndefined() error { return sw.add(0) }
+
+// WriteDocumentElement implements the DocumentWriter interface.
+func (sw *SizeWriter) WriteDocumentElement(key string) (ValueWriter, error) {
+	// The type byte and the null terminated key.
+	if err := sw.add(1 + len(key) + 1); err != nil {
+		return nil, err
+	}
+	return sw, nil
+}
+
+// WriteDocumentEnd implements the DocumentWriter interface.
+func (sw *SizeWriter) WriteDocumentEnd() error { return sw.add(1) }
+
+type sizeArrayWriter struct {
+	sw     *SizeWriter
+	arrkey int
+}
+
+func (saw *sizeArrayWriter) WriteArrayElement() (ValueWriter, error) {
+	if err := saw.sw.add(1 + len(strconv.Itoa(saw.arrkey)) + 1); err != nil {
+		return nil, err
+	}
+	saw.arrkey++
+	return saw.sw, nil
+}
+
+func (saw *sizeArrayWriter) WriteArrayEnd() error { return saw.sw.add(1) }
@@ -1431,6 +1431,24 @@ func TestValueReader(t *testing.T) {
 		}
 	})
 
+	t.Run("Regex/non-zero offset", func(t *testing.T) {
+		vr := &valueReader{
+			d:      []byte{0x01, 0x02, 'f', 'o', 'o', 0x00, 'b', 'a', 'r', 0x00, 0x03},
+			offset: 2,
+			stack: []vrState{
+				{mode: mTopLevel},
+				{mode: mElement, vType: bsontype.Regex},
+			},
+			frame: 1,
+		}
+
+		err := vr.Skip()
+		noerr(t, err)
+		if vr.offset != 10 {
+			t.Errorf("Offset not set at correct position; got %d; want %d", vr.offset, 10)
+		}
+	})
+
 	t.Run("invalid transition", func(t *testing.T) {
 		t.Run("Skip", func(t *testing.T) {
 			vr := &valueReader{stack: []vrState{{mode: mTopLevel}}}
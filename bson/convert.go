@@ -0,0 +1,215 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/bson/decimal"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// DocumentFromD converts d into a bsonx.Doc using the default registry. See DocumentFromDWithRegistry.
+func DocumentFromD(d D) (bsonx.Doc, error) {
+	return DocumentFromDWithRegistry(DefaultRegistry, d)
+}
+
+// defaultRegistry is the registry DefaultRegistry is initialized with. It has no custom encoders or
+// encode hook, so values converted with it do not need to go through the registry.
+var defaultRegistry = DefaultRegistry
+
+// DocumentFromDWithRegistry converts d into a bsonx.Doc. Nested D, A, and M values and nils are
+// converted directly without marshaling d. If r is the default registry, so are the bsonx types, the
+// primitive types, and Go strings, booleans, signed integers, and floats. Any other value is
+// marshaled on its own using r, so that the encoders and encode hook of a custom registry are applied.
+func DocumentFromDWithRegistry(r *bsoncodec.Registry, d D) (bsonx.Doc, error) {
+	doc := make(bsonx.Doc, 0, len(d))
+	for _, e := range d {
+		val, err := valueFromInterface(r, e.Value)
+		if err != nil {
+			return nil, err
+		}
+		doc = append(doc, bsonx.Elem{Key: e.Key, Value: val})
+	}
+	return doc, nil
+}
+
+func arrayFromA(r *bsoncodec.Registry, a []interface{}) (bsonx.Arr, error) {
+	arr := make(bsonx.Arr, 0, len(a))
+	for _, i := range a {
+		val, err := valueFromInterface(r, i)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, val)
+	}
+	return arr, nil
+}
+
+func valueFromInterface(r *bsoncodec.Registry, i interface{}) (bsonx.Val, error) {
+	switch tt := i.(type) {
+	case nil:
+		return bsonx.Null(), nil
+	case D:
+		doc, err := DocumentFromDWithRegistry(r, tt)
+		if err != nil {
+			return bsonx.Val{}, err
+		}
+		return bsonx.Document(doc), nil
+	case M:
+		mdoc := make(bsonx.MDoc, len(tt))
+		for k, v := range tt {
+			val, err := valueFromInterface(r, v)
+			if err != nil {
+				return bsonx.Val{}, err
+			}
+			mdoc[k] = val
+		}
+		return bsonx.Document(mdoc), nil
+	case A:
+		arr, err := arrayFromA(r, tt)
+		if err != nil {
+			return bsonx.Val{}, err
+		}
+		return bsonx.Array(arr), nil
+	case []interface{}:
+		arr, err := arrayFromA(r, tt)
+		if err != nil {
+			return bsonx.Val{}, err
+		}
+		return bsonx.Array(arr), nil
+	}
+
+	if r != defaultRegistry {
+		return marshalValue(r, i)
+	}
+
+	switch tt := i.(type) {
+	case bsonx.Val:
+		return tt, nil
+	case bsonx.Doc:
+		return bsonx.Document(tt), nil
+	case bsonx.MDoc:
+		return bsonx.Document(tt), nil
+	case bsonx.Arr:
+		return bsonx.Array(tt), nil
+	case Raw:
+		doc, err := bsonx.ReadDoc(tt)
+		if err != nil {
+			return bsonx.Val{}, err
+		}
+		return bsonx.Document(doc), nil
+	case string:
+		return bsonx.String(tt), nil
+	case bool:
+		return bsonx.Boolean(tt), nil
+	case int8:
+		return bsonx.Int32(int32(tt)), nil
+	case int16:
+		return bsonx.Int32(int32(tt)), nil
+	case int32:
+		return bsonx.Int32(tt), nil
+	case int64:
+		return bsonx.Int64(tt), nil
+	case int:
+		return bsonx.Int64(int64(tt)), nil
+	case float32:
+		return bsonx.Double(float64(tt)), nil
+	case float64:
+		return bsonx.Double(tt), nil
+	case time.Time:
+		return bsonx.Time(tt), nil
+	case objectid.ObjectID:
+		return bsonx.ObjectID(tt), nil
+	case decimal.Decimal128:
+		return bsonx.Decimal128(tt), nil
+	case primitive.Binary:
+		return bsonx.Binary(tt.Subtype, tt.Data), nil
+	case primitive.Undefined:
+		return bsonx.Undefined(), nil
+	case primitive.Null:
+		return bsonx.Null(), nil
+	case primitive.DateTime:
+		return bsonx.DateTime(int64(tt)), nil
+	case primitive.Regex:
+		return bsonx.Regex(tt.Pattern, tt.Options), nil
+	case primitive.DBPointer:
+		return bsonx.DBPointer(tt.DB, tt.Pointer), nil
+	case primitive.JavaScript:
+		return bsonx.JavaScript(string(tt)), nil
+	case primitive.Symbol:
+		return bsonx.Symbol(string(tt)), nil
+	case primitive.Timestamp:
+		return bsonx.Timestamp(tt.T, tt.I), nil
+	case primitive.MinKey:
+		return bsonx.MinKey(), nil
+	case primitive.MaxKey:
+		return bsonx.MaxKey(), nil
+	}
+
+	return marshalValue(r, i)
+}
+
+// marshalValue converts i into a bsonx.Val by marshaling it with r.
+func marshalValue(r *bsoncodec.Registry, i interface{}) (bsonx.Val, error) {
+	b, err := MarshalWithRegistry(r, D{{Key: "v", Value: i}})
+	if err != nil {
+		return bsonx.Val{}, err
+	}
+	doc, err := bsonx.ReadDoc(b)
+	if err != nil {
+		return bsonx.Val{}, err
+	}
+	return doc[0].Value, nil
+}
+
+// DocumentToD converts doc into a D. Embedded documents are converted into D, arrays into A, and nulls
+// into nil. DateTime, JavaScript, Symbol, and CodeWithScope values are converted into their primitive
+// types and all other values into the Go type returned by bsonx.Val.Interface.
+func DocumentToD(doc bsonx.Doc) D {
+	if doc == nil {
+		return nil
+	}
+
+	d := make(D, 0, len(doc))
+	for _, elem := range doc {
+		d = append(d, E{Key: elem.Key, Value: interfaceFromValue(elem.Value)})
+	}
+	return d
+}
+
+func interfaceFromValue(val bsonx.Val) interface{} {
+	if val.IsZero() {
+		return nil
+	}
+
+	switch val.Type() {
+	case TypeEmbeddedDocument:
+		return DocumentToD(val.Document())
+	case TypeArray:
+		arr := val.Array()
+		a := make(A, 0, len(arr))
+		for _, v := range arr {
+			a = append(a, interfaceFromValue(v))
+		}
+		return a
+	case TypeDateTime:
+		return primitive.DateTime(val.DateTime())
+	case TypeJavaScript:
+		return primitive.JavaScript(val.JavaScript())
+	case TypeSymbol:
+		return primitive.Symbol(val.Symbol())
+	case TypeCodeWithScope:
+		code, scope := val.CodeWithScope()
+		return primitive.CodeWithScope{Code: primitive.JavaScript(code), Scope: DocumentToD(scope)}
+	default:
+		return val.Interface()
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bson
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/bson/decimal"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+func TestDocumentFromD(t *testing.T) {
+	oid := objectid.ObjectID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C}
+	now := time.Now().Truncate(time.Millisecond)
+	type custom struct {
+		Foo string
+	}
+	raw, err := bsonx.Doc{{"r", bsonx.Int32(1)}}.MarshalBSON()
+	noerr(t, err)
+
+	testCases := []struct {
+		name string
+		d    D
+	}{
+		{"empty", D{}},
+		{"nil", nil},
+		{"flat", D{
+			{"string", "foo"},
+			{"bool", true},
+			{"int8", int8(1)},
+			{"int16", int16(2)},
+			{"int32", int32(3)},
+			{"int64", int64(4)},
+			{"int", 5},
+			{"float32", float32(1.5)},
+			{"float64", 2.5},
+		}},
+		{"primitives", D{
+			{"time", now},
+			{"oid", oid},
+			{"decimal", decimal.NewDecimal128(1, 2)},
+			{"binary", primitive.Binary{Subtype: 0x80, Data: []byte{0x01}}},
+			{"undefined", primitive.Undefined{}},
+			{"null", primitive.Null{}},
+			{"datetime", primitive.DateTime(1234)},
+			{"regex", primitive.Regex{Pattern: "^foo", Options: "i"}},
+			{"dbpointer", primitive.DBPointer{DB: "foo.bar", Pointer: oid}},
+			{"timestamp", primitive.Timestamp{T: 1, I: 2}},
+			{"minkey", primitive.MinKey{}},
+			{"maxkey", primitive.MaxKey{}},
+		}},
+		{"nested", D{
+			{"a", D{{"b", D{{"c", int32(1)}}}}},
+			{"empty", D{}},
+			{"emptyNil", D(nil)},
+			{"m", M{"x": "y"}},
+			{"raw", Raw(raw)},
+			{"bsonx", bsonx.Doc{{"x", bsonx.String("y")}}},
+		}},
+		{"arrays", D{
+			{"a", A{int32(1), "two", D{{"three", 3.0}}, A{int64(4), A{}}}},
+			{"slice", []interface{}{"foo", A{}}},
+			{"empty", A{}},
+		}},
+		{"marshaled", D{
+			{"struct", custom{Foo: "bar"}},
+			{"pointer", &custom{Foo: "baz"}},
+			{"uint", uint16(7)},
+			{"nested", D{{"strings", []string{"a", "b"}}}},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := Marshal(tc.d)
+			noerr(t, err)
+			want, err := bsonx.ReadDoc(b)
+			noerr(t, err)
+
+			got, err := DocumentFromD(tc.d)
+			noerr(t, err)
+			if !got.Equal(want) {
+				t.Errorf("Documents do not match. got %v; want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("direct", func(t *testing.T) {
+		// these values cannot be marshaled as their own BSON types with the default registry
+		got, err := DocumentFromD(D{
+			{"nil", nil},
+			{"javascript", primitive.JavaScript("var a = 1;")},
+			{"symbol", primitive.Symbol("sym")},
+		})
+		noerr(t, err)
+		want := bsonx.Doc{
+			{"nil", bsonx.Null()},
+			{"javascript", bsonx.JavaScript("var a = 1;")},
+			{"symbol", bsonx.Symbol("sym")},
+		}
+		if !got.Equal(want) {
+			t.Errorf("Documents do not match. got %v; want %v", got, want)
+		}
+	})
+	t.Run("marshal error", func(t *testing.T) {
+		_, err := DocumentFromD(D{{"nested", D{{"chan", make(chan int)}}}})
+		if err == nil {
+			t.Error("Expected an error for a value that cannot be marshaled")
+		}
+	})
+	t.Run("custom registry", func(t *testing.T) {
+		reg := NewRegistryBuilder().SetEncodeHook(func(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) (bool, error) {
+			if val.Kind() != reflect.String {
+				return false, nil
+			}
+			return true, vw.WriteString(strings.ToUpper(val.String()))
+		}).Build()
+
+		got, err := DocumentFromDWithRegistry(reg, D{{"a", "foo"}, {"nested", D{{"b", "bar"}}}, {"n", int32(1)}})
+		noerr(t, err)
+		want := bsonx.Doc{
+			{"a", bsonx.String("FOO")},
+			{"nested", bsonx.Document(bsonx.Doc{{"b", bsonx.String("BAR")}})},
+			{"n", bsonx.Int32(1)},
+		}
+		if !got.Equal(want) {
+			t.Errorf("Documents do not match. got %v; want %v", got, want)
+		}
+	})
+}
+
+func TestDocumentToD(t *testing.T) {
+	oid := objectid.ObjectID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C}
+	doc := bsonx.Doc{
+		{"string", bsonx.String("foo")},
+		{"int32", bsonx.Int32(1)},
+		{"int64", bsonx.Int64(2)},
+		{"double", bsonx.Double(3.5)},
+		{"bool", bsonx.Boolean(true)},
+		{"null", bsonx.Null()},
+		{"oid", bsonx.ObjectID(oid)},
+		{"datetime", bsonx.DateTime(1234)},
+		{"binary", bsonx.Binary(0x80, []byte{0x01})},
+		{"timestamp", bsonx.Timestamp(1, 2)},
+		{"nested", bsonx.Document(bsonx.Doc{
+			{"a", bsonx.Document(bsonx.Doc{{"b", bsonx.Int32(1)}})},
+			{"arr", bsonx.Array(bsonx.Arr{
+				bsonx.Int32(1),
+				bsonx.Document(bsonx.Doc{{"c", bsonx.String("d")}}),
+				bsonx.Array(bsonx.Arr{bsonx.Null()}),
+			})},
+		})},
+		{"empty", bsonx.Document(bsonx.Doc{})},
+		{"emptyArr", bsonx.Array(bsonx.Arr{})},
+	}
+
+	want := D{
+		{"string", "foo"},
+		{"int32", int32(1)},
+		{"int64", int64(2)},
+		{"double", 3.5},
+		{"bool", true},
+		{"null", nil},
+		{"oid", oid},
+		{"datetime", primitive.DateTime(1234)},
+		{"binary", primitive.Binary{Subtype: 0x80, Data: []byte{0x01}}},
+		{"timestamp", primitive.Timestamp{T: 1, I: 2}},
+		{"nested", D{
+			{"a", D{{"b", int32(1)}}},
+			{"arr", A{int32(1), D{{"c", "d"}}, A{nil}}},
+		}},
+		{"empty", D{}},
+		{"emptyArr", A{}},
+	}
+
+	got := DocumentToD(doc)
+	if !cmp.Equal(got, want) {
+		t.Errorf("Ds do not match. got %v; want %v", got, want)
+	}
+
+	// converting back must produce the original document
+	roundTrip, err := DocumentFromD(got)
+	noerr(t, err)
+	if !roundTrip.Equal(doc) {
+		t.Errorf("Round tripped document does not match. got %v; want %v", roundTrip, doc)
+	}
+
+	t.Run("javascript and symbol", func(t *testing.T) {
+		got := DocumentToD(bsonx.Doc{
+			{"javascript", bsonx.JavaScript("var a = 1;")},
+			{"symbol", bsonx.Symbol("sym")},
+		})
+		want := D{
+			{"javascript", primitive.JavaScript("var a = 1;")},
+			{"symbol", primitive.Symbol("sym")},
+		}
+		if !cmp.Equal(got, want) {
+			t.Errorf("Ds do not match. got %v; want %v", got, want)
+		}
+	})
+}
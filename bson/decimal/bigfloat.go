@@ -0,0 +1,159 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package decimal
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// These are the errors returned when converting between Decimal128 and big.Float values.
+var (
+	// ErrNaN is returned by BigFloat when the Decimal128 is NaN, which a big.Float cannot represent.
+	ErrNaN = errors.New("decimal128 NaN cannot be converted to a big.Float")
+	// ErrOverflow is returned by FromBigFloat when the magnitude of the value is larger than the
+	// largest finite decimal128 value.
+	ErrOverflow = errors.New("value overflows decimal128")
+	// ErrUnderflow is returned by FromBigFloat when the value is non-zero but its magnitude is
+	// smaller than the smallest non-zero decimal128 value.
+	ErrUnderflow = errors.New("value underflows decimal128")
+)
+
+const (
+	// maxDigits is the number of decimal digits in a decimal128 significand.
+	maxDigits = 34
+	// minExponent and maxExponent are the exponents of the least significant digit of the smallest
+	// and largest finite decimal128 values.
+	minExponent = -6176
+	maxExponent = 6111
+
+	// bigFloatPrec is the precision used for values that cannot be represented exactly in binary.
+	// It's enough for FromBigFloat to recover every digit of the significand.
+	bigFloatPrec = 128
+)
+
+// BigFloat converts d into a big.Float. Infinities are converted into the corresponding big.Float
+// infinity and NaN returns ErrNaN.
+//
+// Integral values are converted exactly. Other values are rounded to 128 bits of precision, which
+// is enough for FromBigFloat to convert the result back into a Decimal128 with the same value.
+func (d Decimal128) BigFloat() (*big.Float, error) {
+	if d.IsNaN() {
+		return nil, ErrNaN
+	}
+	if inf := d.IsInf(); inf != 0 {
+		return new(big.Float).SetInf(inf < 0), nil
+	}
+
+	neg := d.h>>63&1 == 1
+
+	var e int
+	var h, l uint64
+	if d.h>>61&3 == 3 {
+		// The significand is out of range, which the spec treats as zero.
+		e = int(d.h>>47&(1<<14-1)) - 6176
+	} else {
+		e = int(d.h>>49&(1<<14-1)) - 6176
+		h, l = d.h&(1<<49-1), d.l
+	}
+
+	sig := new(big.Int).SetUint64(h)
+	sig.Lsh(sig, 64).Or(sig, new(big.Int).SetUint64(l))
+	if neg {
+		sig.Neg(sig)
+	}
+
+	var f *big.Float
+	if e >= 0 {
+		sig.Mul(sig, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(e)), nil))
+		f = new(big.Float).SetInt(sig)
+	} else {
+		div := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-e)), nil)
+		f = new(big.Float).SetPrec(bigFloatPrec).SetInt(sig)
+		f.Quo(f, new(big.Float).SetPrec(bigFloatPrec).SetInt(div))
+	}
+
+	if sig.Sign() == 0 && neg {
+		f.Neg(f)
+	}
+	return f, nil
+}
+
+// FromBigFloat converts f into a Decimal128. Values with more than 34 significant digits are rounded
+// to the nearest representable value, with ties rounded to even. ErrOverflow is returned if the
+// rounded value is too large for a decimal128 and ErrUnderflow if it's too small.
+//
+// Trailing zeros are removed from the significand, so converting the result of BigFloat back into a
+// Decimal128 preserves the value but not necessarily its representation; for example, 1.50 becomes
+// 1.5.
+func FromBigFloat(f *big.Float) (Decimal128, error) {
+	if f == nil {
+		return dNaN, errors.New("cannot convert a nil *big.Float into a decimal128")
+	}
+	if f.IsInf() {
+		if f.Signbit() {
+			return dNegInf, nil
+		}
+		return dPosInf, nil
+	}
+	if f.Sign() == 0 {
+		if f.Signbit() {
+			return ParseDecimal128("-0")
+		}
+		return ParseDecimal128("0")
+	}
+
+	digits, exp := bigFloatDigits(f, maxDigits)
+	// exp is the exponent of the most significant digit. If the least significant digit would be
+	// smaller than the minimum exponent, round to fewer digits instead.
+	if exp-(maxDigits-1) < minExponent {
+		n := exp - minExponent + 1
+		if n < 1 {
+			// The value is smaller than the smallest non-zero decimal128 value, so it rounds either
+			// up to that value or down to zero.
+			if !moreThanHalfMinValue(f) {
+				return dNaN, ErrUnderflow
+			}
+			digits, exp = "1", minExponent
+		} else {
+			digits, exp = bigFloatDigits(f, n)
+		}
+	}
+	if exp > maxExponent+maxDigits-1 {
+		return dNaN, ErrOverflow
+	}
+
+	trimmed := strings.TrimRight(digits, "0")
+	e := exp - (len(trimmed) - 1)
+
+	s := trimmed + "E" + strconv.Itoa(e)
+	if f.Signbit() {
+		s = "-" + s
+	}
+	return ParseDecimal128(s)
+}
+
+// moreThanHalfMinValue returns whether the magnitude of f is larger than half of the smallest
+// non-zero decimal128 value, 1E-6176. A big.Float can't be exactly equal to it, so there are no ties.
+func moreThanHalfMinValue(f *big.Float) bool {
+	r, _ := f.Rat(nil)
+	half := new(big.Rat).SetFrac(big.NewInt(5), new(big.Int).Exp(big.NewInt(10), big.NewInt(-minExponent+1), nil))
+	return r.Abs(r).Cmp(half) > 0
+}
+
+// bigFloatDigits returns the n most significant decimal digits of f, correctly rounded, and the
+// exponent of the first digit.
+func bigFloatDigits(f *big.Float, n int) (string, int) {
+	// Text returns a string of the form [-]d.ddde±dd.
+	s := f.Text('e', n-1)
+	s = strings.TrimPrefix(s, "-")
+	idx := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[idx+1:])
+	return strings.Replace(s[:idx], ".", "", 1), exp
+}
@@ -0,0 +1,160 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigFloatRoundTrip(t *testing.T) {
+	testCases := []string{
+		"0",
+		"-0",
+		"1",
+		"-1",
+		"0.1",
+		"1.5",
+		"-123.456",
+		"1234567890123456789012345678901234",
+		"1.234567890123456789012345678901234E+100",
+		"9.999999999999999999999999999999999E+6144",
+		"-9.999999999999999999999999999999999E+6144",
+		"1E+6144",
+		"1E-6143",
+		"1E-6176",
+		"-1E-6176",
+		"1.234567890123456789012345678901234E-6143",
+		"1.23456789012345678901234567890123E-6144",
+		"Infinity",
+		"-Infinity",
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			d, err := ParseDecimal128(tc)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %s: %v", tc, err)
+			}
+
+			f, err := d.BigFloat()
+			if err != nil {
+				t.Fatalf("unexpected error converting to big.Float: %v", err)
+			}
+
+			got, err := FromBigFloat(f)
+			if err != nil {
+				t.Fatalf("unexpected error converting from big.Float: %v", err)
+			}
+			if got != d {
+				t.Errorf("round trip did not preserve value; got %s; want %s", got, d)
+			}
+		})
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	t.Run("NaN", func(t *testing.T) {
+		_, err := dNaN.BigFloat()
+		if err != ErrNaN {
+			t.Errorf("expected ErrNaN; got %v", err)
+		}
+	})
+	t.Run("integral values are exact", func(t *testing.T) {
+		d, err := ParseDecimal128("1.5E+6000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := d.BigFloat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := new(big.Int).Exp(big.NewInt(10), big.NewInt(5999), nil)
+		want.Mul(want, big.NewInt(15))
+		got, acc := f.Int(nil)
+		if acc != big.Exact || got.Cmp(want) != 0 {
+			t.Errorf("expected an exact conversion; got %v (%v)", f, acc)
+		}
+	})
+	t.Run("trailing zeros are removed", func(t *testing.T) {
+		d, err := ParseDecimal128("1.50")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := d.BigFloat()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := FromBigFloat(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != "1.5" {
+			t.Errorf("expected 1.5; got %s", got)
+		}
+	})
+}
+
+func TestFromBigFloat(t *testing.T) {
+	parse := func(s string) *big.Float {
+		f, _, err := big.ParseFloat(s, 10, 1024, big.ToNearestEven)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", s, err)
+		}
+		return f
+	}
+
+	testCases := []struct {
+		name string
+		f    *big.Float
+		want string
+		err  error
+	}{
+		{"rounds to 34 digits", parse("1.23456789012345678901234567890123456"), "1.234567890123456789012345678901235", nil},
+		{"rounds half to even (down)", parse("12345678901234567890123456789012345"), "1.234567890123456789012345678901234E+34", nil},
+		{"rounds half to even (up)", parse("12345678901234567890123456789012355"), "1.234567890123456789012345678901236E+34", nil},
+		{"largest finite value", parse("9.999999999999999999999999999999999e6144"), "9.999999999999999999999999999999999E+6144", nil},
+		{"overflow", parse("1e6145"), "", ErrOverflow},
+		{"overflow after rounding", parse("9.9999999999999999999999999999999999e6144"), "", ErrOverflow},
+		{"negative overflow", parse("-1e6145"), "", ErrOverflow},
+		{"smallest subnormal", parse("1e-6176"), "1E-6176", nil},
+		{"subnormal rounding", parse("1.5e-6175"), "1.5E-6175", nil},
+		{"subnormal loses digits", parse("1.26e-6175"), "1.3E-6175", nil},
+		{"rounds up to smallest subnormal", parse("5.0000001e-6177"), "1E-6176", nil},
+		{"rounds up to smallest negative subnormal", parse("-9.9e-6177"), "-1E-6176", nil},
+		{"underflow", parse("1e-6177"), "", ErrUnderflow},
+		{"underflow below half of smallest subnormal", parse("4.9999999e-6177"), "", ErrUnderflow},
+		{"positive infinity", new(big.Float).SetInf(false), "Infinity", nil},
+		{"negative infinity", new(big.Float).SetInf(true), "-Infinity", nil},
+		{"negative zero", new(big.Float).Neg(new(big.Float)), "-0", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FromBigFloat(tc.f)
+			if err != tc.err {
+				t.Fatalf("unexpected error; got %v; want %v", err, tc.err)
+			}
+			if tc.err != nil {
+				return
+			}
+			want, err := ParseDecimal128(tc.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("unexpected value; got %s; want %s", got, want)
+			}
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		if _, err := FromBigFloat(nil); err == nil {
+			t.Error("expected an error converting a nil *big.Float")
+		}
+	})
+}
@@ -32,6 +32,23 @@ func (d Decimal128) GetBytes() (uint64, uint64) {
 	return d.h, d.l
 }
 
+// IsNaN returns whether d is NaN. Signaling and negative NaNs are also NaN.
+func (d Decimal128) IsNaN() bool {
+	return d.h>>58&(1<<5-1) == 0x1F
+}
+
+// IsInf returns +1 if d is Infinity, -1 if d is -Infinity, and 0 otherwise.
+func (d Decimal128) IsInf() int {
+	if d.h>>58&(1<<5-1) != 0x1E {
+		return 0
+	}
+
+	if d.h>>63&1 == 0 {
+		return 1
+	}
+	return -1
+}
+
 // String returns a string representation of the decimal value.
 func (d Decimal128) String() string {
 	var pos int     // positive sign
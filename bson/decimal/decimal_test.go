@@ -0,0 +1,133 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package decimal
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const dataDir = "../../data"
+
+type corpusTestCase struct {
+	Valid []struct {
+		Description      string  `json:"description"`
+		CanonicalBson    string  `json:"canonical_bson"`
+		CanonicalExtJSON string  `json:"canonical_extjson"`
+		DegenerateBSON   *string `json:"degenerate_bson"`
+	} `json:"valid"`
+}
+
+// decimalFromCorpusBSON returns the decimal128 value of the "d" element of a corpus document.
+func decimalFromCorpusBSON(t *testing.T, s string) Decimal128 {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("could not decode hex: %v", err)
+	}
+	// length (4 bytes), type (1 byte), and key "d\x00" (2 bytes), followed by the 16 byte value
+	if len(b) < 23 {
+		t.Fatalf("document is too short to contain a decimal128: %s", s)
+	}
+	return NewDecimal128(binary.LittleEndian.Uint64(b[15:23]), binary.LittleEndian.Uint64(b[7:15]))
+}
+
+func TestDecimal128Corpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(dataDir, "decimal128-*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no decimal128 corpus files found in %s", dataDir)
+	}
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tc corpusTestCase
+		if err = json.Unmarshal(content, &tc); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, v := range tc.Valid {
+			v := v
+			t.Run(filepath.Base(file)+"/"+v.Description, func(t *testing.T) {
+				var ej struct {
+					D struct {
+						NumberDecimal string `json:"$numberDecimal"`
+					} `json:"d"`
+				}
+				if err := json.Unmarshal([]byte(v.CanonicalExtJSON), &ej); err != nil {
+					t.Fatal(err)
+				}
+				want := ej.D.NumberDecimal
+
+				bsons := []string{v.CanonicalBson}
+				if v.DegenerateBSON != nil {
+					bsons = append(bsons, *v.DegenerateBSON)
+				}
+				for _, b := range bsons {
+					d := decimalFromCorpusBSON(t, b)
+
+					if got := d.String(); got != want {
+						t.Errorf("String mismatch; got %s; want %s", got, want)
+					}
+					if got := d.IsNaN(); got != (want == "NaN") {
+						t.Errorf("IsNaN mismatch for %s; got %t", want, got)
+					}
+
+					wantInf := 0
+					switch want {
+					case "Infinity":
+						wantInf = 1
+					case "-Infinity":
+						wantInf = -1
+					}
+					if got := d.IsInf(); got != wantInf {
+						t.Errorf("IsInf mismatch for %s; got %d; want %d", want, got, wantInf)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDecimal128SpecialValues(t *testing.T) {
+	testCases := []struct {
+		name  string
+		d     Decimal128
+		str   string
+		isNaN bool
+		isInf int
+	}{
+		{"NaN", dNaN, "NaN", true, 0},
+		{"Infinity", dPosInf, "Infinity", false, 1},
+		{"-Infinity", dNegInf, "-Infinity", false, -1},
+		{"0", NewDecimal128(0x3040000000000000, 0), "0", false, 0},
+		{"-0", NewDecimal128(0xB040000000000000, 0), "-0", false, 0},
+		{"1", NewDecimal128(0x3040000000000000, 1), "1", false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.d.String(); got != tc.str {
+				t.Errorf("String mismatch; got %s; want %s", got, tc.str)
+			}
+			if got := tc.d.IsNaN(); got != tc.isNaN {
+				t.Errorf("IsNaN mismatch; got %t; want %t", got, tc.isNaN)
+			}
+			if got := tc.d.IsInf(); got != tc.isInf {
+				t.Errorf("IsInf mismatch; got %d; want %d", got, tc.isInf)
+			}
+		})
+	}
+}
@@ -30,6 +30,11 @@ var decPool = sync.Pool{
 type Decoder struct {
 	r  *bsoncodec.Registry
 	vr bsonrw.ValueReader
+
+	defaultDocumentInt              bool
+	documentType                    reflect.Type
+	disallowUnknownFields           bool
+	useCaseInsensitiveFieldMatching bool
 }
 
 // NewDecoder returns a new decoder that uses Registry reg to read from r.
@@ -70,7 +75,14 @@ func (d *Decoder) Decode(val interface{}) error {
 	if err != nil {
 		return err
 	}
-	return decoder.DecodeValue(bsoncodec.DecodeContext{Registry: d.r}, d.vr, val)
+	dc := bsoncodec.DecodeContext{
+		Registry:                        d.r,
+		DefaultDocumentInt:              d.defaultDocumentInt,
+		DocumentType:                    d.documentType,
+		DisallowUnknownFields:           d.disallowUnknownFields,
+		UseCaseInsensitiveFieldMatching: d.useCaseInsensitiveFieldMatching,
+	}
+	return decoder.DecodeValue(dc, d.vr, val)
 }
 
 // Reset will reset the state of the decoder, using the same *Registry used in
@@ -85,3 +97,40 @@ func (d *Decoder) SetRegistry(r *bsoncodec.Registry) error {
 	d.r = r
 	return nil
 }
+
+// DefaultDocumentInt causes the Decoder to decode BSON int32 and int64 values into Go ints when
+// decoding into an empty interface, such as the values of an M or D. Int64 values that do not fit
+// in an int are still decoded as int64. By default, int32 and int64 values are decoded as int32 and
+// int64 respectively.
+func (d *Decoder) DefaultDocumentInt() {
+	d.defaultDocumentInt = true
+}
+
+// DefaultDocumentM causes the Decoder to decode embedded documents into M and arrays into
+// []interface{} when decoding into an empty interface, such as the values of an M or D. By default,
+// they're decoded into bsonx.Doc and bsonx.Arr, except within maps with interface{} values, where
+// embedded documents are decoded into the type of the map.
+func (d *Decoder) DefaultDocumentM() {
+	d.documentType = tM
+}
+
+// DefaultDocumentD causes the Decoder to decode embedded documents into D and arrays into
+// []interface{} when decoding into an empty interface. See DefaultDocumentM for the default
+// behavior.
+func (d *Decoder) DefaultDocumentD() {
+	d.documentType = tD
+}
+
+// DisallowUnknownFields causes the Decoder to return an error when decoding a document into a struct
+// and the document contains a key that does not match any field of the struct. Keys are never
+// unknown for structs with an inline map. By default, such keys are ignored.
+func (d *Decoder) DisallowUnknownFields() {
+	d.disallowUnknownFields = true
+}
+
+// UseCaseInsensitiveFieldMatching causes the Decoder to match a document key to a struct field
+// case-insensitively when no field matches the key exactly. Exact matches always take precedence, and
+// Decode returns an error if a key matches more than one field case-insensitively.
+func (d *Decoder) UseCaseInsensitiveFieldMatching() {
+	d.useCaseInsensitiveFieldMatching = true
+}
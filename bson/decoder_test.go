@@ -9,6 +9,7 @@ package bson
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -16,6 +17,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
 	"github.com/mongodb/mongo-go-driver/bson/bsonrw/bsonrwtest"
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
 func TestBasicDecode(t *testing.T) {
@@ -164,6 +166,283 @@ func TestDecoderv2(t *testing.T) {
 			t.Errorf("Decoder should use the Registry provided. got %v; want %v", dec.r, reg2)
 		}
 	})
+	t.Run("DisallowUnknownFields", func(t *testing.T) {
+		doc, err := bsonx.Doc{
+			{"a", bsonx.Int32(1)},
+			{"nested", bsonx.Document(bsonx.Doc{{"b", bsonx.String("b")}, {"extra", bsonx.String("x")}})},
+			{"unknown", bsonx.String("foo")},
+		}.MarshalBSON()
+		noerr(t, err)
+
+		type nested struct {
+			B     string
+			Extra interface{}
+		}
+		type known struct {
+			A      int32
+			Nested nested
+		}
+		type withInline struct {
+			A      int32
+			Nested nested
+			Rest   map[string]interface{} `bson:",inline"`
+		}
+		type nestedUnknown struct {
+			A       int32
+			Nested  struct{ B string }
+			Unknown string
+		}
+
+		decode := func(val interface{}, disallow bool) error {
+			dec, err := NewDecoder(DefaultRegistry, bsonrw.NewBSONDocumentReader(doc))
+			noerr(t, err)
+			if disallow {
+				dec.DisallowUnknownFields()
+			}
+			return dec.Decode(val)
+		}
+
+		t.Run("allowed by default", func(t *testing.T) {
+			var got known
+			noerr(t, decode(&got, false))
+			want := known{A: 1, Nested: nested{B: "b", Extra: "x"}}
+			if !cmp.Equal(got, want) {
+				t.Errorf("Results do not match. got %+v; want %+v", got, want)
+			}
+		})
+		t.Run("disallowed", func(t *testing.T) {
+			var got known
+			err := decode(&got, true)
+			want := "cannot decode element 'unknown' into bson.known; the struct has no matching field"
+			if err == nil || err.Error() != want {
+				t.Errorf("Did not receive expected error. got %v; want %v", err, want)
+			}
+		})
+		t.Run("disallowed in nested struct", func(t *testing.T) {
+			var got nestedUnknown
+			err := decode(&got, true)
+			if err == nil || !strings.Contains(err.Error(), "'extra'") {
+				t.Errorf("Expected an error naming the key 'extra'. got %v", err)
+			}
+		})
+		t.Run("inline map absorbs unknown fields", func(t *testing.T) {
+			var got withInline
+			noerr(t, decode(&got, true))
+			if got.Rest["unknown"] != "foo" {
+				t.Errorf("Expected the inline map to contain the unknown field. got %v", got.Rest)
+			}
+		})
+	})
+	t.Run("UseCaseInsensitiveFieldMatching", func(t *testing.T) {
+		decode := func(doc bsonx.Doc, val interface{}, caseInsensitive bool) error {
+			b, err := doc.MarshalBSON()
+			noerr(t, err)
+			dec, err := NewDecoder(DefaultRegistry, bsonrw.NewBSONDocumentReader(b))
+			noerr(t, err)
+			if caseInsensitive {
+				dec.UseCaseInsensitiveFieldMatching()
+			}
+			return dec.Decode(val)
+		}
+
+		type person struct {
+			FirstName string
+			LastName  string `bson:"last_name"`
+		}
+		doc := bsonx.Doc{{"FIRSTNAME", bsonx.String("Ada")}, {"Last_Name", bsonx.String("Lovelace")}}
+
+		t.Run("disabled by default", func(t *testing.T) {
+			var got person
+			noerr(t, decode(doc, &got, false))
+			if !cmp.Equal(got, person{}) {
+				t.Errorf("Expected no fields to be decoded. got %+v", got)
+			}
+		})
+		t.Run("matches names and tags", func(t *testing.T) {
+			var got person
+			noerr(t, decode(doc, &got, true))
+			want := person{FirstName: "Ada", LastName: "Lovelace"}
+			if !cmp.Equal(got, want) {
+				t.Errorf("Results do not match. got %+v; want %+v", got, want)
+			}
+		})
+		t.Run("exact match wins", func(t *testing.T) {
+			type cased struct {
+				Lower string `bson:"name"`
+				Upper string `bson:"NAME"`
+			}
+			var got cased
+			noerr(t, decode(bsonx.Doc{{"NAME", bsonx.String("upper")}, {"name", bsonx.String("lower")}}, &got, true))
+			want := cased{Lower: "lower", Upper: "upper"}
+			if !cmp.Equal(got, want) {
+				t.Errorf("Results do not match. got %+v; want %+v", got, want)
+			}
+		})
+		t.Run("ambiguous match", func(t *testing.T) {
+			type cased struct {
+				Lower string `bson:"name"`
+				Upper string `bson:"NAME"`
+			}
+			var got cased
+			err := decode(bsonx.Doc{{"Name", bsonx.String("mixed")}}, &got, true)
+			want := "cannot decode element 'Name'; it matches both the 'name' and 'NAME' fields case-insensitively"
+			if err == nil || err.Error() != want {
+				t.Errorf("Did not receive expected error. got %v; want %v", err, want)
+			}
+		})
+	})
+	t.Run("DefaultDocumentInt", func(t *testing.T) {
+		doc, err := bsonx.Doc{
+			{"int32", bsonx.Int32(42)},
+			{"int64", bsonx.Int64(1 << 40)},
+			{"double", bsonx.Double(1.5)},
+		}.MarshalBSON()
+		noerr(t, err)
+
+		type withInterfaces struct {
+			Int32  interface{}
+			Int64  interface{}
+			Double interface{}
+		}
+
+		testCases := []struct {
+			name         string
+			defaultInt   bool
+			int32, int64 interface{}
+		}{
+			{"disabled", false, int32(42), int64(1 << 40)},
+			{"enabled", true, int(42), int(1 << 40)},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				decode := func(val interface{}) {
+					t.Helper()
+					dec, err := NewDecoder(DefaultRegistry, bsonrw.NewBSONDocumentReader(doc))
+					noerr(t, err)
+					if tc.defaultInt {
+						dec.DefaultDocumentInt()
+					}
+					noerr(t, dec.Decode(val))
+				}
+
+				var m M
+				decode(&m)
+				want := M{"int32": tc.int32, "int64": tc.int64, "double": 1.5}
+				if !cmp.Equal(m, want) {
+					t.Errorf("M does not match. got %v; want %v", m, want)
+				}
+
+				var d D
+				decode(&d)
+				wantD := D{{"int32", tc.int32}, {"int64", tc.int64}, {"double", 1.5}}
+				if !cmp.Equal(d, wantD) {
+					t.Errorf("D does not match. got %v; want %v", d, wantD)
+				}
+
+				var s withInterfaces
+				decode(&s)
+				wantS := withInterfaces{Int32: tc.int32, Int64: tc.int64, Double: 1.5}
+				if !cmp.Equal(s, wantS) {
+					t.Errorf("struct does not match. got %v; want %v", s, wantS)
+				}
+			})
+		}
+	})
+	t.Run("embedded document type", func(t *testing.T) {
+		doc, err := bsonx.Doc{
+			{"int32", bsonx.Int32(1)},
+			{"string", bsonx.String("foo")},
+			{"doc", bsonx.Document(bsonx.Doc{
+				{"bool", bsonx.Boolean(true)},
+				{"nested", bsonx.Document(bsonx.Doc{{"double", bsonx.Double(1.5)}})},
+			})},
+			{"array", bsonx.Array(bsonx.Arr{
+				bsonx.Int64(2),
+				bsonx.Document(bsonx.Doc{{"a", bsonx.String("b")}}),
+				bsonx.Array(bsonx.Arr{bsonx.Int32(3)}),
+			})},
+		}.MarshalBSON()
+		noerr(t, err)
+
+		decode := func(val interface{}, configure func(*Decoder)) {
+			t.Helper()
+			dec, err := NewDecoder(DefaultRegistry, bsonrw.NewBSONDocumentReader(doc))
+			noerr(t, err)
+			if configure != nil {
+				configure(dec)
+			}
+			noerr(t, dec.Decode(val))
+		}
+
+		t.Run("map[string]interface{}", func(t *testing.T) {
+			var got map[string]interface{}
+			decode(&got, nil)
+			want := map[string]interface{}{
+				"int32":  int32(1),
+				"string": "foo",
+				"doc": map[string]interface{}{
+					"bool":   true,
+					"nested": map[string]interface{}{"double": 1.5},
+				},
+				"array": []interface{}{
+					int64(2),
+					map[string]interface{}{"a": "b"},
+					[]interface{}{int32(3)},
+				},
+			}
+			if !cmp.Equal(got, want) {
+				t.Errorf("map does not match. got %v; want %v", got, want)
+			}
+
+			// the decoded map encodes back to the original document
+			b, err := Marshal(got)
+			noerr(t, err)
+			var roundTrip map[string]interface{}
+			noerr(t, Unmarshal(b, &roundTrip))
+			if !cmp.Equal(roundTrip, want) {
+				t.Errorf("round trip does not match. got %v; want %v", roundTrip, want)
+			}
+		})
+		t.Run("M", func(t *testing.T) {
+			var got M
+			decode(&got, nil)
+			want := M{
+				"int32":  int32(1),
+				"string": "foo",
+				"doc":    M{"bool": true, "nested": M{"double": 1.5}},
+				"array":  []interface{}{int64(2), M{"a": "b"}, []interface{}{int32(3)}},
+			}
+			if !cmp.Equal(got, want) {
+				t.Errorf("M does not match. got %v; want %v", got, want)
+			}
+		})
+		t.Run("DefaultDocumentM", func(t *testing.T) {
+			var got D
+			decode(&got, (*Decoder).DefaultDocumentM)
+			want := D{
+				{"int32", int32(1)},
+				{"string", "foo"},
+				{"doc", M{"bool": true, "nested": M{"double": 1.5}}},
+				{"array", []interface{}{int64(2), M{"a": "b"}, []interface{}{int32(3)}}},
+			}
+			if !cmp.Equal(got, want) {
+				t.Errorf("D does not match. got %v; want %v", got, want)
+			}
+		})
+		t.Run("DefaultDocumentD", func(t *testing.T) {
+			var got map[string]interface{}
+			decode(&got, (*Decoder).DefaultDocumentD)
+			want := map[string]interface{}{
+				"int32":  int32(1),
+				"string": "foo",
+				"doc":    D{{"bool", true}, {"nested", D{{"double", 1.5}}}},
+				"array":  []interface{}{int64(2), D{{"a", "b"}}, []interface{}{int32(3)}},
+			}
+			if !cmp.Equal(got, want) {
+				t.Errorf("map does not match. got %v; want %v", got, want)
+			}
+		})
+	})
 }
 
 type testDecoderCodec struct {
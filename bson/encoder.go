@@ -29,6 +29,8 @@ var encPool = sync.Pool{
 type Encoder struct {
 	r  *bsoncodec.Registry
 	vw bsonrw.ValueWriter
+
+	nilSliceAsEmpty bool
 }
 
 // NewEncoder returns a new encoder that uses Registry r to write to w.
@@ -64,7 +66,7 @@ func (e *Encoder) Encode(val interface{}) error {
 	if err != nil {
 		return err
 	}
-	return encoder.EncodeValue(bsoncodec.EncodeContext{Registry: e.r}, e.vw, val)
+	return encoder.EncodeValue(bsoncodec.EncodeContext{Registry: e.r, NilSliceAsEmpty: e.nilSliceAsEmpty}, e.vw, val)
 }
 
 // Reset will reset the state of the encoder, using the same *Registry used in
@@ -79,3 +81,9 @@ func (e *Encoder) SetRegistry(r *bsoncodec.Registry) error {
 	e.r = r
 	return nil
 }
+
+// SetNilSliceAsEmpty sets whether nil slices are encoded as empty BSON arrays instead of BSON null.
+// It is off by default. Nil maps are always encoded as empty BSON documents.
+func (e *Encoder) SetNilSliceAsEmpty(b bool) {
+	e.nilSliceAsEmpty = b
+}
@@ -158,3 +158,78 @@ type _impl struct {
 }
 
 func (_impl) method() {}
+
+func TestEncoderNilSliceAsEmpty(t *testing.T) {
+	type collections struct {
+		S      []string
+		Nested struct{ I []int32 }
+		M      map[string]int32
+	}
+
+	testCases := []struct {
+		name  string
+		empty bool
+		val   collections
+		want  bsonx.Doc
+	}{
+		{
+			"nil/default",
+			false,
+			collections{},
+			bsonx.Doc{
+				{"s", bsonx.Null()},
+				{"nested", bsonx.Document(bsonx.Doc{{"i", bsonx.Null()}})},
+				{"m", bsonx.Document(bsonx.Doc{})},
+			},
+		},
+		{
+			"nil/empty",
+			true,
+			collections{},
+			bsonx.Doc{
+				{"s", bsonx.Array(bsonx.Arr{})},
+				{"nested", bsonx.Document(bsonx.Doc{{"i", bsonx.Array(bsonx.Arr{})}})},
+				{"m", bsonx.Document(bsonx.Doc{})},
+			},
+		},
+		{
+			"non-nil/default",
+			false,
+			collections{S: []string{"a"}, Nested: struct{ I []int32 }{I: []int32{}}, M: map[string]int32{"b": 1}},
+			bsonx.Doc{
+				{"s", bsonx.Array(bsonx.Arr{bsonx.String("a")})},
+				{"nested", bsonx.Document(bsonx.Doc{{"i", bsonx.Array(bsonx.Arr{})}})},
+				{"m", bsonx.Document(bsonx.Doc{{"b", bsonx.Int32(1)}})},
+			},
+		},
+		{
+			"non-nil/empty",
+			true,
+			collections{S: []string{"a"}, Nested: struct{ I []int32 }{I: []int32{}}, M: map[string]int32{"b": 1}},
+			bsonx.Doc{
+				{"s", bsonx.Array(bsonx.Arr{bsonx.String("a")})},
+				{"nested", bsonx.Document(bsonx.Doc{{"i", bsonx.Array(bsonx.Arr{})}})},
+				{"m", bsonx.Document(bsonx.Doc{{"b", bsonx.Int32(1)}})},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := make(bsonrw.SliceWriter, 0, 1024)
+			vw, err := bsonrw.NewBSONValueWriter(&got)
+			noerr(t, err)
+			enc, err := NewEncoder(DefaultRegistry, vw)
+			noerr(t, err)
+			enc.SetNilSliceAsEmpty(tc.empty)
+			err = enc.Encode(tc.val)
+			noerr(t, err)
+
+			want, err := tc.want.MarshalBSON()
+			noerr(t, err)
+			if !bytes.Equal(got, want) {
+				t.Errorf("Documents are not equal. got %v; want %v", Raw(got), Raw(want))
+			}
+		})
+	}
+}
@@ -82,6 +82,36 @@ func MarshalAppendWithRegistry(r *bsoncodec.Registry, dst []byte, val interface{
 	return *sw, nil
 }
 
+// SizeOf returns the length of the BSON encoding of val using Registry r, or DefaultRegistry if r is
+// nil. The encoding is counted rather than built, so SizeOf can be used to check a document against
+// the server's maximum document size without allocating it. Values that implement Marshaler are the
+// exception: their MarshalBSON method builds the encoding, which is then counted.
+func SizeOf(val interface{}, r *bsoncodec.Registry) (int, error) {
+	if r == nil {
+		r = DefaultRegistry
+	}
+
+	sw := bsonrw.NewBSONSizeWriter()
+	enc := encPool.Get().(*Encoder)
+	defer encPool.Put(enc)
+
+	err := enc.Reset(sw)
+	if err != nil {
+		return 0, err
+	}
+	err = enc.SetRegistry(r)
+	if err != nil {
+		return 0, err
+	}
+
+	err = enc.Encode(val)
+	if err != nil {
+		return 0, err
+	}
+
+	return sw.Size(), nil
+}
+
 // MarshalExtJSON returns the extended JSON encoding of val.
 func MarshalExtJSON(val interface{}, canonical, escapeHTML bool) ([]byte, error) {
 	return MarshalExtJSONWithRegistry(DefaultRegistry, val, canonical, escapeHTML)
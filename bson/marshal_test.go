@@ -8,9 +8,20 @@ package bson
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/stretchr/testify/require"
 )
@@ -74,6 +85,73 @@ func TestMarshalAppend(t *testing.T) {
 	}
 }
 
+func TestSizeOf(t *testing.T) {
+	for _, tc := range marshalingTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SizeOf(tc.val, tc.reg)
+			noerr(t, err)
+
+			if got != len(tc.want) {
+				t.Errorf("Sizes are not equal. got %d; want %d", got, len(tc.want))
+			}
+		})
+	}
+
+	scope := bsonx.Doc{{"x", bsonx.Int32(1)}}
+	raw, err := bsonx.Doc{{"r", bsonx.String("raw")}}.MarshalBSON()
+	noerr(t, err)
+	arr := make([]interface{}, 12)
+	for i := range arr {
+		arr[i] = int64(i)
+	}
+	testCases := []struct {
+		name string
+		val  interface{}
+	}{
+		{"empty", D{}},
+		{"nested", D{{"a", D{{"b", D{{"c", "deep"}}}}}, {"d", M{"e": 3.14}}}},
+		{"array", D{{"arr", arr}, {"nested", A{A{"x"}, D{{"y", true}}}}}},
+		{"struct", struct {
+			Name  string
+			Tags  []string
+			Inner struct{ N int32 }
+			Skip  string `bson:",omitempty"`
+		}{Name: "foo", Tags: []string{"a", "bc"}}},
+		{"special types", D{
+			{"oid", objectid.New()},
+			{"bin", primitive.Binary{Subtype: 0x00, Data: []byte{1, 2, 3}}},
+			{"oldbin", primitive.Binary{Subtype: 0x02, Data: []byte{1, 2, 3}}},
+			{"cws", primitive.CodeWithScope{Code: "function() { return x; }", Scope: scope}},
+			{"dbp", primitive.DBPointer{DB: "db.coll", Pointer: objectid.New()}},
+			{"regex", primitive.Regex{Pattern: "^foo", Options: "i"}},
+			{"ts", primitive.Timestamp{T: 1, I: 2}},
+			{"null", primitive.Null{}},
+			{"minkey", primitive.MinKey{}},
+			{"raw", Raw(raw)},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := Marshal(tc.val)
+			noerr(t, err)
+			got, err := SizeOf(tc.val, nil)
+			noerr(t, err)
+
+			if got != len(b) {
+				t.Errorf("Sizes are not equal. got %d; want %d", got, len(b))
+			}
+		})
+	}
+
+	t.Run("top-level value must be a document", func(t *testing.T) {
+		_, err := SizeOf(int32(1), nil)
+		if err == nil {
+			t.Errorf("Expected an error sizing a non-document value")
+		}
+	})
+}
+
 func TestMarshal_roundtripFromBytes(t *testing.T) {
 	before := []byte{
 		// length
@@ -130,3 +208,432 @@ func TestMarshal_roundtripFromDoc(t *testing.T) {
 
 	require.True(t, before.Equal(after))
 }
+
+func TestMarshal_roundtripCodeWithScope(t *testing.T) {
+	type withCode struct {
+		Code primitive.CodeWithScope
+		Any  interface{}
+	}
+
+	scope := bsonx.Doc{
+		{"x", bsonx.Int32(1)},
+		{"nested", bsonx.Document(bsonx.Doc{
+			{"y", bsonx.String("z")},
+			{"arr", bsonx.Array(bsonx.Arr{bsonx.Int64(2), bsonx.Document(bsonx.Doc{{"deep", bsonx.Boolean(true)}})})},
+		})},
+	}
+	cws := primitive.CodeWithScope{Code: "function() { return x; }", Scope: scope}
+
+	b, err := Marshal(withCode{Code: cws, Any: cws})
+	require.NoError(t, err)
+
+	code, rawScope := Raw(b).Lookup("code").CodeWithScope()
+	require.Equal(t, "function() { return x; }", code)
+	require.Equal(t, "z", rawScope.Lookup("nested", "y").StringValue())
+	str := Raw(b).Lookup("code").String()
+	require.True(t, strings.HasPrefix(str, `{"$code":"function() { return x; }","$scope":`), "unexpected string %s", str)
+
+	var after withCode
+	require.NoError(t, Unmarshal(b, &after))
+
+	for _, got := range []interface{}{after.Code, after.Any} {
+		gotCWS, ok := got.(primitive.CodeWithScope)
+		require.True(t, ok, "expected a primitive.CodeWithScope but got %T", got)
+		require.Equal(t, cws.Code, gotCWS.Code)
+		gotScope, ok := gotCWS.Scope.(bsonx.Doc)
+		require.True(t, ok, "expected the scope to be a bsonx.Doc but got %T", gotCWS.Scope)
+		require.True(t, scope.Equal(gotScope))
+	}
+
+	again, err := Marshal(after)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(b, again))
+}
+
+func TestMarshal_roundtripDBPointer(t *testing.T) {
+	type withPointer struct {
+		Pointer primitive.DBPointer
+		Any     interface{}
+	}
+
+	oid, err := objectid.FromHex("5a934e000102030405000000")
+	require.NoError(t, err)
+	dbp := primitive.DBPointer{DB: "db.coll", Pointer: oid}
+	before := withPointer{Pointer: dbp, Any: dbp}
+
+	b, err := Marshal(before)
+	require.NoError(t, err)
+
+	require.Equal(t, bsontype.DBPointer, Raw(b).Lookup("pointer").Type)
+	ns, ptr := Raw(b).Lookup("pointer").DBPointer()
+	require.Equal(t, "db.coll", ns)
+	require.Equal(t, oid, ptr)
+
+	var after withPointer
+	require.NoError(t, Unmarshal(b, &after))
+	require.Equal(t, before, after)
+
+	for _, canonical := range []bool{true, false} {
+		ej, err := MarshalExtJSON(before, canonical, false)
+		require.NoError(t, err)
+		require.Equal(t,
+			`{"pointer":{"$dbPointer":{"$ref":"db.coll","$id":{"$oid":"5a934e000102030405000000"}}},`+
+				`"any":{"$dbPointer":{"$ref":"db.coll","$id":{"$oid":"5a934e000102030405000000"}}}}`,
+			string(ej))
+
+		var fromJSON withPointer
+		require.NoError(t, UnmarshalExtJSON(ej, canonical, &fromJSON))
+		require.Equal(t, before, fromJSON)
+	}
+}
+
+func TestMarshal_roundtripSymbolAndUndefined(t *testing.T) {
+	b, err := bsonx.Doc{
+		{"symbol", bsonx.Symbol("foo")},
+		{"str", bsonx.Symbol("bar")},
+		{"undefined", bsonx.Undefined()},
+		{"any", bsonx.Undefined()},
+	}.MarshalBSON()
+	require.NoError(t, err)
+
+	t.Run("struct", func(t *testing.T) {
+		var s struct {
+			Symbol    primitive.Symbol
+			Str       string
+			Undefined string
+			Any       interface{}
+		}
+		require.NoError(t, Unmarshal(b, &s))
+		require.Equal(t, primitive.Symbol("foo"), s.Symbol)
+		require.Equal(t, "bar", s.Str)
+		require.Equal(t, "", s.Undefined)
+		require.Nil(t, s.Any)
+
+		out, err := Marshal(struct{ Symbol primitive.Symbol }{s.Symbol})
+		require.NoError(t, err)
+		require.Equal(t, bsontype.Symbol, Raw(out).Lookup("symbol").Type)
+	})
+
+	t.Run("D", func(t *testing.T) {
+		var d D
+		require.NoError(t, Unmarshal(b, &d))
+		require.Equal(t, D{
+			{"symbol", primitive.Symbol("foo")},
+			{"str", primitive.Symbol("bar")},
+			{"undefined", primitive.Undefined{}},
+			{"any", primitive.Undefined{}},
+		}, d)
+
+		out, err := Marshal(d)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(b, out))
+	})
+
+	t.Run("extended JSON", func(t *testing.T) {
+		for _, canonical := range []bool{true, false} {
+			ej, err := MarshalExtJSON(Raw(b), canonical, false)
+			require.NoError(t, err)
+			require.Equal(t,
+				`{"symbol":{"$symbol":"foo"},"str":{"$symbol":"bar"},"undefined":{"$undefined":true},"any":{"$undefined":true}}`,
+				string(ej))
+
+			var d D
+			require.NoError(t, UnmarshalExtJSON(ej, canonical, &d))
+			out, err := Marshal(d)
+			require.NoError(t, err)
+			require.True(t, bytes.Equal(b, out))
+		}
+	})
+}
+
+func TestMarshal_roundtripStdlibTypes(t *testing.T) {
+	type stdlib struct {
+		IP       net.IP
+		NilIP    net.IP
+		URL      *url.URL
+		NilURL   *url.URL
+		Duration time.Duration
+		IntNum   json.Number
+		FloatNum json.Number
+	}
+
+	u, err := url.Parse("https://example.com/path?q=1")
+	require.NoError(t, err)
+	in := stdlib{
+		IP:       net.ParseIP("2001:db8::1"),
+		URL:      u,
+		Duration: 90 * time.Second,
+		IntNum:   json.Number("42"),
+		FloatNum: json.Number("1.5"),
+	}
+
+	b, err := Marshal(in)
+	require.NoError(t, err)
+
+	raw := Raw(b)
+	require.Equal(t, bsontype.Binary, raw.Lookup("ip").Type)
+	require.Equal(t, bsontype.Null, raw.Lookup("nilip").Type)
+	require.Equal(t, "https://example.com/path?q=1", raw.Lookup("url").StringValue())
+	require.Equal(t, bsontype.Null, raw.Lookup("nilurl").Type)
+	require.Equal(t, int64(90*time.Second), raw.Lookup("duration").Int64())
+	require.Equal(t, int64(42), raw.Lookup("intnum").Int64())
+	require.Equal(t, 1.5, raw.Lookup("floatnum").Double())
+
+	out := stdlib{NilIP: net.IP{0x01, 0x02, 0x03, 0x04}, NilURL: u}
+	require.NoError(t, Unmarshal(b, &out))
+	require.Equal(t, in, out)
+
+	t.Run("IP as string", func(t *testing.T) {
+		rb := NewRegistryBuilder()
+		rb.RegisterEncoder(reflect.TypeOf(net.IP{}), &bsoncodec.IPCodec{EncodeString: true})
+		reg := rb.Build()
+
+		b, err := MarshalWithRegistry(reg, in)
+		require.NoError(t, err)
+		require.Equal(t, "2001:db8::1", Raw(b).Lookup("ip").StringValue())
+		require.Equal(t, bsontype.Null, Raw(b).Lookup("nilip").Type)
+
+		var out stdlib
+		require.NoError(t, UnmarshalWithRegistry(reg, b, &out))
+		require.Equal(t, in, out)
+	})
+}
+
+type hookSecret string
+
+func TestMarshal_hooks(t *testing.T) {
+	tSecret := reflect.TypeOf(hookSecret(""))
+	const prefix = "sealed:"
+
+	rb := NewRegistryBuilder()
+	rb.SetEncodeHook(func(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) (bool, error) {
+		if val.Type() != tSecret {
+			return false, nil
+		}
+		return true, vw.WriteString(prefix + strings.ToUpper(val.String()))
+	})
+	rb.SetDecodeHook(func(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) (bool, error) {
+		if val.Type() != tSecret {
+			return false, nil
+		}
+		str, err := vr.ReadString()
+		if err != nil {
+			return true, err
+		}
+		if !strings.HasPrefix(str, prefix) {
+			return true, fmt.Errorf("secret %q is not sealed", str)
+		}
+		val.SetString(strings.ToLower(strings.TrimPrefix(str, prefix)))
+		return true, nil
+	})
+	reg := rb.Build()
+
+	type withSecrets struct {
+		Secret  hookSecret
+		Plain   string
+		Secrets []hookSecret
+		Ptr     *hookSecret
+	}
+	ptr := hookSecret("pointer")
+	in := withSecrets{Secret: "foo", Plain: "bar", Secrets: []hookSecret{"baz"}, Ptr: &ptr}
+
+	b, err := MarshalWithRegistry(reg, in)
+	require.NoError(t, err)
+	raw := Raw(b)
+	require.Equal(t, "sealed:FOO", raw.Lookup("secret").StringValue())
+	require.Equal(t, "bar", raw.Lookup("plain").StringValue())
+	require.Equal(t, "sealed:BAZ", raw.Lookup("secrets", "0").StringValue())
+	require.Equal(t, "sealed:POINTER", raw.Lookup("ptr").StringValue())
+
+	var out withSecrets
+	require.NoError(t, UnmarshalWithRegistry(reg, b, &out))
+	require.Equal(t, in, out)
+
+	t.Run("decode error", func(t *testing.T) {
+		b, err := Marshal(D{{"secret", "foo"}})
+		require.NoError(t, err)
+		var out withSecrets
+		require.Error(t, UnmarshalWithRegistry(reg, b, &out))
+	})
+
+	t.Run("default registry is unaffected", func(t *testing.T) {
+		b, err := Marshal(in)
+		require.NoError(t, err)
+		require.Equal(t, "foo", Raw(b).Lookup("secret").StringValue())
+	})
+}
+
+func TestMarshal_roundtripMinKeyMaxKey(t *testing.T) {
+	type bounds struct {
+		Min primitive.MinKey
+		Max primitive.MaxKey
+		Any interface{}
+	}
+
+	b, err := Marshal(bounds{Any: primitive.MinKey{}})
+	require.NoError(t, err)
+
+	want := bsonx.Doc{{"min", bsonx.MinKey()}, {"max", bsonx.MaxKey()}, {"any", bsonx.MinKey()}}
+	var doc bsonx.Doc
+	require.NoError(t, Unmarshal(b, &doc))
+	require.True(t, want.Equal(doc))
+
+	var after bounds
+	require.NoError(t, Unmarshal(b, &after))
+	require.Equal(t, bounds{Any: primitive.MinKey{}}, after)
+
+	for _, canonical := range []bool{true, false} {
+		ej, err := MarshalExtJSON(Raw(b), canonical, false)
+		require.NoError(t, err)
+		require.Equal(t, `{"min":{"$minKey":1},"max":{"$maxKey":1},"any":{"$minKey":1}}`, string(ej))
+
+		var fromJSON bsonx.Doc
+		require.NoError(t, UnmarshalExtJSON(ej, canonical, &fromJSON))
+		require.True(t, want.Equal(fromJSON))
+	}
+}
+
+func TestMarshal_roundtripRegex(t *testing.T) {
+	type withRegex struct {
+		Regex primitive.Regex
+		Any   interface{}
+	}
+
+	before := withRegex{
+		Regex: primitive.Regex{Pattern: "^foo", Options: "xmi"},
+		Any:   primitive.Regex{Pattern: "bar$", Options: "si"},
+	}
+	b, err := Marshal(before)
+	require.NoError(t, err)
+
+	pattern, options := Raw(b).Lookup("regex").Regex()
+	require.Equal(t, "^foo", pattern)
+	require.Equal(t, "imx", options)
+
+	fromDoc, err := bsonx.Doc{{"regex", bsonx.Regex("^foo", "xmi")}, {"any", bsonx.Regex("bar$", "si")}}.MarshalBSON()
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(b, fromDoc))
+
+	var after withRegex
+	require.NoError(t, Unmarshal(b, &after))
+	require.Equal(t, withRegex{
+		Regex: primitive.Regex{Pattern: "^foo", Options: "imx"},
+		Any:   primitive.Regex{Pattern: "bar$", Options: "is"},
+	}, after)
+
+	ej, err := MarshalExtJSON(before, true, false)
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"regex":{"$regularExpression":{"pattern":"^foo","options":"imx"}},"any":{"$regularExpression":{"pattern":"bar$","options":"is"}}}`,
+		string(ej))
+
+	t.Run("custom type", func(t *testing.T) {
+		type slashRegex string
+		type withSlashRegex struct {
+			Regex slashRegex
+		}
+
+		decodeSlashRegex := func(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, i interface{}) error {
+			pattern, options, err := vr.ReadRegex()
+			if err != nil {
+				return err
+			}
+			*i.(*slashRegex) = slashRegex("/" + pattern + "/" + options)
+			return nil
+		}
+		reg := NewRegistryBuilder().
+			RegisterDecoder(reflect.TypeOf(slashRegex("")), bsoncodec.ValueDecoderFunc(decodeSlashRegex)).
+			Build()
+
+		var got withSlashRegex
+		require.NoError(t, UnmarshalWithRegistry(reg, b, &got))
+		require.Equal(t, slashRegex("/^foo/imx"), got.Regex)
+	})
+}
+
+func TestMarshal_roundtripPointers(t *testing.T) {
+	type pointers struct {
+		S  **string
+		A  *[]int
+		M  *map[string]int
+		I  *int32
+		IS []*int32
+	}
+
+	str := "hello"
+	strp := &str
+	var nilStr *string
+	i32 := int32(5)
+
+	testCases := []struct {
+		name string
+		val  pointers
+		want bsonx.Doc
+	}{
+		{
+			"non-nil",
+			pointers{
+				S:  &strp,
+				A:  &[]int{1, 2},
+				M:  &map[string]int{"a": 1},
+				I:  &i32,
+				IS: []*int32{&i32, nil},
+			},
+			bsonx.Doc{
+				{"s", bsonx.String("hello")},
+				{"a", bsonx.Array(bsonx.Arr{bsonx.Int64(1), bsonx.Int64(2)})},
+				{"m", bsonx.Document(bsonx.Doc{{"a", bsonx.Int64(1)}})},
+				{"i", bsonx.Int32(5)},
+				{"is", bsonx.Array(bsonx.Arr{bsonx.Int32(5), bsonx.Null()})},
+			},
+		},
+		{
+			"nil",
+			pointers{},
+			bsonx.Doc{
+				{"s", bsonx.Null()},
+				{"a", bsonx.Null()},
+				{"m", bsonx.Null()},
+				{"i", bsonx.Null()},
+				{"is", bsonx.Null()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := Marshal(tc.val)
+			require.NoError(t, err)
+
+			var doc bsonx.Doc
+			require.NoError(t, Unmarshal(b, &doc))
+			require.True(t, tc.want.Equal(doc), "got %v; want %v", doc, tc.want)
+
+			var after pointers
+			require.NoError(t, Unmarshal(b, &after))
+			require.Equal(t, tc.val, after)
+		})
+	}
+
+	t.Run("nested nil", func(t *testing.T) {
+		b, err := Marshal(pointers{S: &nilStr})
+		require.NoError(t, err)
+		require.Equal(t, bsontype.Null, Raw(b).Lookup("s").Type)
+
+		// the null is decoded into the outer pointer, so the nil inner pointer isn't preserved
+		after := pointers{S: &strp}
+		require.NoError(t, Unmarshal(b, &after))
+		require.Nil(t, after.S)
+	})
+
+	t.Run("decoding allocates intermediate pointers", func(t *testing.T) {
+		b, err := Marshal(bsonx.Doc{{"s", bsonx.String("world")}})
+		require.NoError(t, err)
+
+		var after pointers
+		require.NoError(t, Unmarshal(b, &after))
+		require.NotNil(t, after.S)
+		require.NotNil(t, *after.S)
+		require.Equal(t, "world", **after.S)
+	})
+}
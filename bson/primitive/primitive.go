@@ -50,7 +50,7 @@ func (rp Regex) String() string {
 
 // Equal compaes rp to rp2 and returns true is the are equal.
 func (rp Regex) Equal(rp2 Regex) bool {
-	return rp.Pattern == rp2.Pattern && rp.Options == rp.Options
+	return rp.Pattern == rp2.Pattern && rp.Options == rp2.Options
 }
 
 // DBPointer represents a BSON dbpointer value.
@@ -95,6 +95,23 @@ func (tp Timestamp) Equal(tp2 Timestamp) bool {
 	return tp.T == tp2.T && tp.I == tp2.I
 }
 
+// CompareTimestamp returns an integer comparing two timestamps, first by their seconds (T) and then by
+// their increments (I). The result is 0 if tp == tp2, -1 if tp < tp2, and +1 if tp > tp2.
+func CompareTimestamp(tp, tp2 Timestamp) int {
+	switch {
+	case tp.T < tp2.T:
+		return -1
+	case tp.T > tp2.T:
+		return 1
+	case tp.I < tp2.I:
+		return -1
+	case tp.I > tp2.I:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // MinKey represents the BSON minkey value.
 type MinKey struct{}
 
@@ -0,0 +1,37 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package primitive
+
+import "testing"
+
+func TestCompareTimestamp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tp       Timestamp
+		tp2      Timestamp
+		expected int
+	}{
+		{"equal", Timestamp{T: 12345, I: 67890}, Timestamp{T: 12345, I: 67890}, 0},
+		{"T equal, I greater", Timestamp{T: 12345, I: 67890}, Timestamp{T: 12345, I: 67889}, 1},
+		{"T equal, I less", Timestamp{T: 12345, I: 67890}, Timestamp{T: 12345, I: 67891}, -1},
+		{"T greater, I less", Timestamp{T: 12346, I: 1}, Timestamp{T: 12345, I: 67890}, 1},
+		{"T less, I greater", Timestamp{T: 12344, I: 99999}, Timestamp{T: 12345, I: 0}, -1},
+		{"zero", Timestamp{}, Timestamp{}, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := CompareTimestamp(tc.tp, tc.tp2)
+			if result != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, result)
+			}
+			if reverse := CompareTimestamp(tc.tp2, tc.tp); reverse != -tc.expected {
+				t.Errorf("expected reversed comparison to be %d, got %d", -tc.expected, reverse)
+			}
+		})
+	}
+}
@@ -47,6 +47,8 @@ func (pc PrimitiveCodecs) RegisterPrimitiveCodecs(rb *bsoncodec.RegistryBuilder)
 		RegisterEncoder(reflect.PtrTo(tNull), bsoncodec.ValueEncoderFunc(pc.NullEncodeValue)).
 		RegisterEncoder(reflect.PtrTo(tRegex), bsoncodec.ValueEncoderFunc(pc.RegexEncodeValue)).
 		RegisterEncoder(reflect.PtrTo(tDBPointer), bsoncodec.ValueEncoderFunc(pc.DBPointerEncodeValue)).
+		RegisterEncoder(reflect.PtrTo(tJavaScript), bsoncodec.ValueEncoderFunc(pc.JavaScriptEncodeValue)).
+		RegisterEncoder(reflect.PtrTo(tSymbol), bsoncodec.ValueEncoderFunc(pc.SymbolEncodeValue)).
 		RegisterEncoder(reflect.PtrTo(tCodeWithScope), bsoncodec.ValueEncoderFunc(pc.CodeWithScopeEncodeValue)).
 		RegisterEncoder(reflect.PtrTo(tTimestamp), bsoncodec.ValueEncoderFunc(pc.TimestampEncodeValue)).
 		RegisterEncoder(reflect.PtrTo(tMinKey), bsoncodec.ValueEncoderFunc(pc.MinKeyEncodeValue)).
@@ -64,6 +66,8 @@ func (pc PrimitiveCodecs) RegisterPrimitiveCodecs(rb *bsoncodec.RegistryBuilder)
 		RegisterDecoder(reflect.PtrTo(tNull), bsoncodec.ValueDecoderFunc(pc.NullDecodeValue)).
 		RegisterDecoder(reflect.PtrTo(tRegex), bsoncodec.ValueDecoderFunc(pc.RegexDecodeValue)).
 		RegisterDecoder(reflect.PtrTo(tDBPointer), bsoncodec.ValueDecoderFunc(pc.DBPointerDecodeValue)).
+		RegisterDecoder(reflect.PtrTo(tJavaScript), bsoncodec.ValueDecoderFunc(pc.JavaScriptDecodeValue)).
+		RegisterDecoder(reflect.PtrTo(tSymbol), bsoncodec.ValueDecoderFunc(pc.SymbolDecodeValue)).
 		RegisterDecoder(reflect.PtrTo(tCodeWithScope), bsoncodec.ValueDecoderFunc(pc.CodeWithScopeDecodeValue)).
 		RegisterDecoder(reflect.PtrTo(tTimestamp), bsoncodec.ValueDecoderFunc(pc.TimestampDecodeValue)).
 		RegisterDecoder(reflect.PtrTo(tMinKey), bsoncodec.ValueDecoderFunc(pc.MinKeyDecodeValue)).
@@ -114,7 +118,7 @@ func (PrimitiveCodecs) SymbolEncodeValue(ectx bsoncodec.EncodeContext, vw bsonrw
 		}
 	}
 
-	return vw.WriteJavascript(string(symbol))
+	return vw.WriteSymbol(string(symbol))
 }
 
 // JavaScriptDecodeValue is the ValueDecoderFunc for the primitive.JavaScript type.
@@ -698,6 +702,52 @@ func (PrimitiveCodecs) EmptyInterfaceDecodeValue(dc bsoncodec.DecodeContext, vr
 	// we can keep down on the repeated code in this method. In all of the
 	// implementations this is a closure, so we don't need to provide the
 	// target as a parameter.
+	if dc.DefaultDocumentInt {
+		switch vr.Type() {
+		case bsontype.Int32:
+			i32, err := vr.ReadInt32()
+			if err != nil {
+				return err
+			}
+			*target = int(i32)
+			return nil
+		case bsontype.Int64:
+			i64, err := vr.ReadInt64()
+			if err != nil {
+				return err
+			}
+			if int64(int(i64)) == i64 {
+				*target = int(i64)
+			} else {
+				*target = i64
+			}
+			return nil
+		}
+	}
+
+	if dc.DocumentType != nil {
+		var rtype reflect.Type
+		switch vr.Type() {
+		case bsontype.EmbeddedDocument:
+			rtype = dc.DocumentType
+		case bsontype.Array:
+			rtype = tEmptySlice
+		}
+		if rtype != nil {
+			decoder, err := dc.LookupDecoder(rtype)
+			if err != nil {
+				return err
+			}
+			val := reflect.New(rtype)
+			err = decoder.DecodeValue(dc, vr, val.Interface())
+			if err != nil {
+				return err
+			}
+			*target = val.Elem().Interface()
+			return nil
+		}
+	}
+
 	var fn func()
 	var val interface{}
 	var rtype reflect.Type
@@ -151,8 +151,8 @@ func TestDefaultValueEncoders(t *testing.T) {
 						Received: wrong,
 					},
 				},
-				{"Symbol", primitive.Symbol("foobar"), nil, nil, bsonrwtest.WriteJavascript, nil},
-				{"*Symbol", psymbol, nil, nil, bsonrwtest.WriteJavascript, nil},
+				{"Symbol", primitive.Symbol("foobar"), nil, nil, bsonrwtest.WriteSymbol, nil},
+				{"*Symbol", psymbol, nil, nil, bsonrwtest.WriteSymbol, nil},
 				{"*Symbol/nil", psymbolNil, nil, nil, bsonrwtest.WriteNull, nil},
 			},
 		},
@@ -60,6 +60,11 @@ func (r Raw) Elements() ([]RawElement, error) {
 	return relems, err
 }
 
+// Keys returns the keys of the top-level elements of this document, in order, without decoding
+// their values. If the document is not valid, the keys up to the invalid point will be returned
+// along with an error.
+func (r Raw) Keys() ([]string, error) { return bsoncore.Document(r).Keys() }
+
 // Values returns this document as a slice of values. The returned slice will contain valid values.
 // If the document is not valid, the values up to the invalid point will be returned along with an
 // error.
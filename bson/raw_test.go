@@ -345,6 +345,24 @@ func TestRaw(t *testing.T) {
 			})
 		}
 	})
+	t.Run("Keys", func(t *testing.T) {
+		rdr := Raw(bsoncore.BuildDocument(nil,
+			bsoncore.AppendDocumentElement(
+				bsoncore.AppendInt32Element(nil, "a", 1),
+				"b", bsoncore.BuildDocument(nil, bsoncore.AppendStringElement(nil, "c", "d")),
+			),
+		))
+
+		keys, err := rdr.Keys()
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, keys)
+
+		elems, err := rdr.Elements()
+		require.NoError(t, err)
+		require.Len(t, elems, 2)
+		require.Equal(t, "b", elems[1].Key())
+		require.Equal(t, "d", elems[1].Value().Document().Lookup("c").StringValue())
+	})
 }
 
 func readerElementEqual(e1, e2 bsonx.Elem) bool { return e1.Equal(e2) }
@@ -25,7 +25,9 @@ var ErrNilRegistry = errors.New("Registry cannot be nil")
 
 // RawValue represents a BSON value in byte form. It can be used to hold unprocessed BSON or to
 // defer processing of BSON. Type is the BSON type of the value and Value are the raw bytes that
-// represent the element.
+// represent the element. When used as a struct field or map value, a RawValue captures the bytes of
+// the element without decoding them, similar to json.RawMessage; they can be decoded later with
+// Unmarshal.
 //
 // This type wraps bsoncore.Value for most of it's functionality.
 type RawValue struct {
@@ -7,10 +7,12 @@
 package bson
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
@@ -81,4 +83,76 @@ func TestRawValue(t *testing.T) {
 			}
 		})
 	})
+	t.Run("Deferred decoding", func(t *testing.T) {
+		type point struct {
+			X, Y int32
+		}
+		type envelope struct {
+			Kind    string
+			Payload RawValue
+		}
+
+		docs := []bsonx.Doc{
+			{{"kind", bsonx.String("point")}, {"payload", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}, {"y", bsonx.Int32(2)}})}},
+			{{"kind", bsonx.String("count")}, {"payload", bsonx.Int32(42)}},
+			{{"kind", bsonx.String("tags")}, {"payload", bsonx.Array(bsonx.Arr{bsonx.String("a"), bsonx.String("b")})}},
+		}
+
+		for _, doc := range docs {
+			b, err := doc.MarshalBSON()
+			noerr(t, err)
+
+			var env envelope
+			noerr(t, Unmarshal(b, &env))
+			want := Raw(b).Lookup("payload")
+			if env.Payload.Type != want.Type || !bytes.Equal(env.Payload.Value, want.Value) {
+				t.Errorf("Expected the raw payload to be captured. got %v; want %v", env.Payload, want)
+			}
+
+			var got, wantVal interface{}
+			switch env.Kind {
+			case "point":
+				var p point
+				noerr(t, env.Payload.Unmarshal(&p))
+				got, wantVal = p, point{X: 1, Y: 2}
+			case "count":
+				var n int
+				noerr(t, env.Payload.Unmarshal(&n))
+				got, wantVal = n, 42
+			case "tags":
+				var tags []string
+				noerr(t, env.Payload.Unmarshal(&tags))
+				got, wantVal = tags, []string{"a", "b"}
+			}
+			if !cmp.Equal(got, wantVal) {
+				t.Errorf("Expected decoded payloads to match. got %v; want %v", got, wantVal)
+			}
+
+			// the captured value encodes back to the original bytes
+			out, err := Marshal(env)
+			noerr(t, err)
+			if !bytes.Equal(out, b) {
+				t.Errorf("Expected round trip to preserve bytes. got %v; want %v", Raw(out), Raw(b))
+			}
+		}
+
+		t.Run("map values", func(t *testing.T) {
+			b, err := bsonx.Doc{{"a", bsonx.Int64(1)}, {"b", bsonx.String("two")}}.MarshalBSON()
+			noerr(t, err)
+
+			var m map[string]RawValue
+			noerr(t, Unmarshal(b, &m))
+			if m["a"].Type != bsontype.Int64 || m["b"].Type != bsontype.String {
+				t.Fatalf("Expected raw values of the original types. got %v", m)
+			}
+
+			var n int64
+			noerr(t, m["a"].Unmarshal(&n))
+			var s string
+			noerr(t, m["b"].Unmarshal(&s))
+			if n != 1 || s != "two" {
+				t.Errorf("Expected decoded values to match. got %d and %q", n, s)
+			}
+		})
+	})
 }
@@ -50,6 +50,7 @@ var tDecimal = reflect.TypeOf(decimal.Decimal128{})
 var tDocument = reflect.TypeOf((bsonx.Doc)(nil))
 var tMDoc = reflect.TypeOf((bsonx.MDoc)(nil))
 var tD = reflect.TypeOf(D{})
+var tM = reflect.TypeOf(M{})
 var tElementSlice = reflect.TypeOf(([]bsonx.Elem)(nil))
 var tDateTime = reflect.TypeOf(primitive.DateTime(0))
 var tUndefined = reflect.TypeOf(primitive.Undefined{})
@@ -45,6 +45,17 @@ func UnmarshalWithRegistry(r *bsoncodec.Registry, data []byte, val interface{})
 	return unmarshalFromReader(r, vr, val)
 }
 
+// UnmarshalInto decodes raw directly into the value pointed to by val using Registry r, or
+// DefaultRegistry if r is nil. The value is read from the raw bytes with a bsonrw.ValueReader, so no
+// intermediate document is constructed. If val is nil or not a pointer, UnmarshalInto returns
+// InvalidUnmarshalError.
+func UnmarshalInto(raw Raw, val interface{}, r *bsoncodec.Registry) error {
+	if r == nil {
+		r = DefaultRegistry
+	}
+	return UnmarshalWithRegistry(r, raw, val)
+}
+
 // UnmarshalExtJSON parses the extended JSON-encoded data and stores the result
 // in the value pointed to by val. If val is nil or not a pointer, Unmarshal
 // returns InvalidUnmarshalError.
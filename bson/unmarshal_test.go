@@ -48,3 +48,16 @@ func TestUnmarshalWithRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalInto(t *testing.T) {
+	for _, tc := range unmarshalingTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := reflect.New(tc.sType).Interface()
+			err := UnmarshalInto(Raw(tc.data), got, tc.reg)
+			noerr(t, err)
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Did not unmarshal as expected. got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
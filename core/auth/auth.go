@@ -99,6 +99,7 @@ type HandshakeOptions struct {
 	Authenticator Authenticator
 	Compressors   []string
 	DBUser        string
+	UseHello      bool // send hello over OP_MSG in place of isMaster
 }
 
 // Handshaker creates a connection handshaker for the given authenticator.
@@ -108,6 +109,7 @@ func Handshaker(h connection.Handshaker, options *HandshakeOptions) connection.H
 			Client:             command.ClientDoc(options.AppName),
 			Compressors:        options.Compressors,
 			SaslSupportedMechs: options.DBUser,
+			UseHello:           options.UseHello,
 		}).Handshake(ctx, addr, rw)
 
 		if err != nil {
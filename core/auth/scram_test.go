@@ -0,0 +1,155 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+func TestScramAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	// The SCRAM-SHA-1 vector comes from the MongoDB authentication specification and the
+	// SCRAM-SHA-256 vector from RFC 7677.
+	testCases := []struct {
+		name        string
+		newAuth     func(*Cred) (Authenticator, error)
+		mechanism   string
+		clientNonce string
+		clientFirst string
+		serverFirst string
+		clientFinal string
+		serverFinal string
+	}{
+		{
+			name:        "SCRAM-SHA-1",
+			newAuth:     newScramSHA1Authenticator,
+			mechanism:   SCRAMSHA1,
+			clientNonce: "fyko+d2lbbFgONRv9qkxdawL",
+			clientFirst: "n,,n=user,r=fyko+d2lbbFgONRv9qkxdawL",
+			serverFirst: "r=fyko+d2lbbFgONRv9qkxdawLHo+Vgk7qvUOKUwuWLIWg4l/9SraGMHEE,s=rQ9ZY3MntBeuP3E1TDVC4w==,i=10000",
+			clientFinal: "c=biws,r=fyko+d2lbbFgONRv9qkxdawLHo+Vgk7qvUOKUwuWLIWg4l/9SraGMHEE,p=MC2T8BvbmWRckDw8oWl5IVghwCY=",
+			serverFinal: "v=UMWeI25JD1yNYZRMpZ4VHvhZ9e0=",
+		},
+		{
+			name:        "SCRAM-SHA-256",
+			newAuth:     newScramSHA256Authenticator,
+			mechanism:   SCRAMSHA256,
+			clientNonce: "rOprNGfwEbeRWgbNEkqO",
+			clientFirst: "n,,n=user,r=rOprNGfwEbeRWgbNEkqO",
+			serverFirst: "r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096",
+			clientFinal: "c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=",
+			serverFinal: "v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			authenticator, err := tc.newAuth(&Cred{Username: "user", Password: "pencil", Source: "admin"})
+			if err != nil {
+				t.Fatalf("expected no error but got \"%s\"", err)
+			}
+			a := authenticator.(*ScramAuthenticator)
+			a.client.WithNonceGenerator(func() string { return tc.clientNonce })
+
+			resps := make(chan wiremessage.WireMessage, 3)
+			resps <- internal.MakeReply(t, bsonx.Doc{
+				{"ok", bsonx.Int32(1)},
+				{"conversationId", bsonx.Int32(1)},
+				{"payload", bsonx.Binary(0x00, []byte(tc.serverFirst))},
+				{"done", bsonx.Boolean(false)}},
+			)
+			resps <- internal.MakeReply(t, bsonx.Doc{
+				{"ok", bsonx.Int32(1)},
+				{"conversationId", bsonx.Int32(1)},
+				{"payload", bsonx.Binary(0x00, []byte(tc.serverFinal))},
+				{"done", bsonx.Boolean(false)}},
+			)
+			resps <- internal.MakeReply(t, bsonx.Doc{
+				{"ok", bsonx.Int32(1)},
+				{"conversationId", bsonx.Int32(1)},
+				{"payload", bsonx.Binary(0x00, []byte{})},
+				{"done", bsonx.Boolean(true)}},
+			)
+
+			c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 3), ReadResp: resps}
+
+			err = a.Auth(context.Background(), description.Server{
+				WireVersion: &description.VersionRange{
+					Max: 6,
+				},
+			}, c)
+			if err != nil {
+				t.Fatalf("expected no error but got \"%s\"", err)
+			}
+
+			if len(c.Written) != 3 {
+				t.Fatalf("expected 3 messages to be sent but had %d", len(c.Written))
+			}
+
+			start := writtenCommand(t, <-c.Written)
+			if mech := start.Lookup("mechanism").StringValue(); mech != tc.mechanism {
+				t.Errorf("unexpected mechanism. got %s; want %s", mech, tc.mechanism)
+			}
+			if payload := writtenPayload(t, start); payload != tc.clientFirst {
+				t.Errorf("unexpected client-first message. got %s; want %s", payload, tc.clientFirst)
+			}
+			if payload := writtenPayload(t, writtenCommand(t, <-c.Written)); payload != tc.clientFinal {
+				t.Errorf("unexpected client-final message. got %s; want %s", payload, tc.clientFinal)
+			}
+		})
+	}
+}
+
+func writtenCommand(t *testing.T, wm wiremessage.WireMessage) bson.Raw {
+	msg, ok := wm.(wiremessage.Msg)
+	if !ok {
+		t.Fatalf("expected an OP_MSG but got %T", wm)
+	}
+	return bson.Raw(msg.Sections[0].(wiremessage.SectionBody).Document)
+}
+
+func writtenPayload(t *testing.T, cmd bson.Raw) string {
+	_, payload, ok := cmd.Lookup("payload").BinaryOK()
+	if !ok {
+		t.Fatalf("expected a binary payload in %s", cmd)
+	}
+	return string(payload)
+}
+
+func TestChooseAuthMechanism(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		desc description.Server
+		mech string
+	}{
+		{"SCRAM-SHA-256 advertised", description.Server{SaslSupportedMechs: []string{SCRAMSHA1, SCRAMSHA256}}, SCRAMSHA256},
+		{"only SCRAM-SHA-1 advertised", description.Server{SaslSupportedMechs: []string{SCRAMSHA1}}, SCRAMSHA1},
+		{"nothing advertised", description.Server{WireVersion: &description.VersionRange{Max: 6}}, SCRAMSHA1},
+		{"legacy server", description.Server{WireVersion: &description.VersionRange{Max: 2}}, MONGODBCR},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if mech := chooseAuthMechanism(tc.desc); mech != tc.mech {
+				t.Errorf("unexpected mechanism. got %s; want %s", mech, tc.mech)
+			}
+		})
+	}
+}
@@ -34,7 +34,11 @@ func (a *MongoDBX509Authenticator) Auth(ctx context.Context, desc description.Se
 		{"mechanism", bsonx.String(MongoDBX509)},
 	}
 
-	if desc.WireVersion.Max < 5 {
+	// Servers before 3.4 cannot infer the user name from the client certificate.
+	if desc.WireVersion == nil || desc.WireVersion.Max < 5 {
+		if a.User == "" {
+			return newAuthError("a user name is required for MONGODB-X509 on servers before 3.4", nil)
+		}
 		authRequestDoc = append(authRequestDoc, bsonx.Elem{"user", bsonx.String(a.User)})
 	}
 
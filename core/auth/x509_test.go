@@ -0,0 +1,102 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/mongodb/mongo-go-driver/core/auth"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+const x509Subject = "CN=client,OU=drivers,O=MongoDB,L=New York City,ST=New York,C=US"
+
+func TestMongoDBX509Authenticator_Legacy(t *testing.T) {
+	t.Parallel()
+
+	authenticator := MongoDBX509Authenticator{User: x509Subject}
+
+	resps := make(chan wiremessage.WireMessage, 1)
+	resps <- internal.MakeReply(t, bsonx.Doc{{"ok", bsonx.Int32(1)}})
+
+	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1), ReadResp: resps}
+
+	err := authenticator.Auth(context.Background(), description.Server{
+		WireVersion: &description.VersionRange{
+			Max: 4,
+		},
+	}, c)
+	if err != nil {
+		t.Fatalf("expected no error but got \"%s\"", err)
+	}
+
+	if len(c.Written) != 1 {
+		t.Fatalf("expected 1 messages to be sent but had %d", len(c.Written))
+	}
+
+	expectedCmd := bsonx.Doc{
+		{"authenticate", bsonx.Int32(1)},
+		{"mechanism", bsonx.String("MONGODB-X509")},
+		{"user", bsonx.String(x509Subject)},
+	}
+	compareResponses(t, <-c.Written, expectedCmd, "$external")
+}
+
+func TestMongoDBX509Authenticator_InferredUser(t *testing.T) {
+	t.Parallel()
+
+	authenticator := MongoDBX509Authenticator{User: x509Subject}
+
+	resps := make(chan wiremessage.WireMessage, 1)
+	resps <- internal.MakeReply(t, bsonx.Doc{{"ok", bsonx.Int32(1)}})
+
+	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1), ReadResp: resps}
+
+	err := authenticator.Auth(context.Background(), description.Server{
+		WireVersion: &description.VersionRange{
+			Max: 6,
+		},
+	}, c)
+	if err != nil {
+		t.Fatalf("expected no error but got \"%s\"", err)
+	}
+
+	if len(c.Written) != 1 {
+		t.Fatalf("expected 1 messages to be sent but had %d", len(c.Written))
+	}
+
+	expectedCmd := bsonx.Doc{
+		{"authenticate", bsonx.Int32(1)},
+		{"mechanism", bsonx.String("MONGODB-X509")},
+	}
+	compareResponses(t, <-c.Written, expectedCmd, "$external")
+}
+
+func TestMongoDBX509Authenticator_MissingUser(t *testing.T) {
+	t.Parallel()
+
+	authenticator := MongoDBX509Authenticator{}
+
+	c := &internal.ChannelConn{Written: make(chan wiremessage.WireMessage, 1)}
+
+	err := authenticator.Auth(context.Background(), description.Server{
+		WireVersion: &description.VersionRange{
+			Max: 4,
+		},
+	}, c)
+	if err == nil {
+		t.Fatalf("expected an error but got none")
+	}
+
+	if len(c.Written) != 0 {
+		t.Fatalf("expected no messages to be sent but had %d", len(c.Written))
+	}
+}
@@ -110,6 +110,20 @@ func (a *Aggregate) HasDollarOut() bool {
 	return doc[0].Key == "$out"
 }
 
+// HasSearchStage returns true if the Pipeline field starts with an Atlas Search $search or
+// $searchMeta stage.
+func (a *Aggregate) HasSearchStage() bool {
+	if len(a.Pipeline) == 0 {
+		return false
+	}
+
+	doc, ok := a.Pipeline[0].DocumentOK()
+	if !ok || len(doc) != 1 {
+		return false
+	}
+	return doc[0].Key == "$search" || doc[0].Key == "$searchMeta"
+}
+
 // Decode will decode the wire message using the provided server description. Errors during decoding
 // are deferred until either the Result or Err methods are called.
 func (a *Aggregate) Decode(desc description.SelectedServer, cb CursorBuilder, wm wiremessage.WireMessage) *Aggregate {
@@ -214,7 +214,7 @@ func addSessionFields(cmd bsonx.Doc, desc description.SelectedServer, client *se
 		cmd = addTransaction(cmd, client)
 	}
 
-	client.ApplyCommand() // advance the state machine based on a command executing
+	client.ApplyCommand(desc.Server) // advance the state machine based on a command executing
 
 	return cmd, nil
 }
@@ -272,13 +272,19 @@ func addReadConcern(cmd bsonx.Doc, desc description.SelectedServer, rc *readconc
 		return cmd, nil
 	}
 
+	if rc.GetLevel() == "snapshot" && rc.GetAtClusterTime() == nil && (sess == nil || !sess.TransactionRunning()) {
+		return cmd, ErrSnapshotReadConcern
+	}
+
 	element, err := rc.MarshalBSONElement()
 	if err != nil {
 		return cmd, err
 	}
 
 	rcDoc := element.Value.Document()
-	if description.SessionsSupported(desc.WireVersion) && sess != nil && sess.Consistent && sess.OperationTime != nil {
+	// afterClusterTime cannot be combined with an explicit atClusterTime
+	if description.SessionsSupported(desc.WireVersion) && sess != nil && sess.Consistent && sess.OperationTime != nil &&
+		rc.GetAtClusterTime() == nil {
 		rcDoc = append(rcDoc, bsonx.Elem{"afterClusterTime", bsonx.Timestamp(sess.OperationTime.T, sess.OperationTime.I)})
 	}
 
@@ -479,7 +485,7 @@ func roundTripBatches(
 	cmdKind WriteCommandKind,
 ) (interface{}, []*WriteBatch, error) {
 	var res interface{}
-	var upsertIndex int64 // the operation index for the upserted IDs map
+	var opIndex int64 // the operation index of the first document in the current batch
 
 	// hold onto txnNumber, reset it when loop exits to ensure reuse of same
 	// transaction number if retry is needed
@@ -510,7 +516,7 @@ func roundTripBatches(
 				return res, batches, err
 			}
 
-			conv.WriteErrors = append(conv.WriteErrors, r.WriteErrors...)
+			conv.WriteErrors = appendWriteErrors(conv.WriteErrors, r.WriteErrors, opIndex)
 
 			if r.WriteConcernError != nil {
 				conv.WriteConcernError = r.WriteConcernError
@@ -539,7 +545,7 @@ func roundTripBatches(
 				return conv, batches, err
 			}
 
-			conv.WriteErrors = append(conv.WriteErrors, r.WriteErrors...)
+			conv.WriteErrors = appendWriteErrors(conv.WriteErrors, r.WriteErrors, opIndex)
 
 			if r.WriteConcernError != nil {
 				conv.WriteConcernError = r.WriteConcernError
@@ -553,7 +559,7 @@ func roundTripBatches(
 			conv.ModifiedCount += r.ModifiedCount
 			for _, upsert := range r.Upserted {
 				conv.Upserted = append(conv.Upserted, result.Upsert{
-					Index: upsert.Index + upsertIndex,
+					Index: upsert.Index + opIndex,
 					ID:    upsert.ID,
 				})
 			}
@@ -563,7 +569,6 @@ func roundTripBatches(
 			}
 
 			res = conv
-			upsertIndex += int64(cmd.numDocs)
 		case DeleteCommand:
 			if res == nil {
 				res = result.Delete{}
@@ -576,7 +581,7 @@ func roundTripBatches(
 				return conv, batches, err
 			}
 
-			conv.WriteErrors = append(conv.WriteErrors, r.WriteErrors...)
+			conv.WriteErrors = appendWriteErrors(conv.WriteErrors, r.WriteErrors, opIndex)
 
 			if r.WriteConcernError != nil {
 				conv.WriteConcernError = r.WriteConcernError
@@ -594,6 +599,7 @@ func roundTripBatches(
 
 			res = conv
 		}
+		opIndex += int64(cmd.numDocs)
 
 		// Increment txnNumber for each batch
 		if sess != nil && sess.RetryWrite {
@@ -611,6 +617,16 @@ func roundTripBatches(
 	return res, batches, nil
 }
 
+// appendWriteErrors appends the write errors of a batch to errs, adjusting their indexes so they
+// refer to the position of the document in the original command instead of in the batch.
+func appendWriteErrors(errs []result.WriteError, batchErrs []result.WriteError, opIndex int64) []result.WriteError {
+	for _, we := range batchErrs {
+		we.Index += int(opIndex)
+		errs = append(errs, we)
+	}
+	return errs
+}
+
 // ErrUnacknowledgedWrite is returned from functions that have an unacknowledged
 // write concern.
 var ErrUnacknowledgedWrite = errors.New("unacknowledged write")
@@ -9,9 +9,15 @@ package command
 import (
 	"testing"
 
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
 func noerr(t *testing.T, err error) {
@@ -65,3 +71,297 @@ func TestCommandEncode(t *testing.T) {
 		}
 	})
 }
+
+func TestReadConcernCausalConsistency(t *testing.T) {
+	t.Run("majority read concern merges with afterClusterTime", func(t *testing.T) {
+		id, err := uuid.New()
+		noerr(t, err)
+		sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+		noerr(t, err)
+		noerr(t, sess.AdvanceOperationTime(&primitive.Timestamp{T: 5, I: 2}))
+
+		cmd := &Find{
+			NS:          Namespace{DB: "foo", Collection: "bar"},
+			ReadConcern: readconcern.Majority(),
+			Session:     sess,
+		}
+		desc := description.SelectedServer{
+			Server: description.Server{WireVersion: &description.VersionRange{Max: 6}},
+		}
+		wm, err := cmd.Encode(desc)
+		noerr(t, err)
+		msg, ok := wm.(wiremessage.Msg)
+		if !ok {
+			t.Fatalf("Returned wiremessage is not a msg. got %T; want %T", wm, wiremessage.Msg{})
+		}
+		body, ok := msg.Sections[0].(wiremessage.SectionBody)
+		if !ok {
+			t.Fatalf("First section is not a body. got %T; want %T", msg.Sections[0], wiremessage.SectionBody{})
+		}
+
+		elems, err := bson.Raw(body.Document).Elements()
+		noerr(t, err)
+		var count int
+		for _, elem := range elems {
+			if elem.Key() == "readConcern" {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Fatalf("Expected exactly one readConcern document, but found %d", count)
+		}
+
+		rc := bson.Raw(body.Document).Lookup("readConcern").Document()
+		if level := rc.Lookup("level").StringValue(); level != "majority" {
+			t.Errorf("Unexpected read concern level. got %s; want %s", level, "majority")
+		}
+		ts, i := rc.Lookup("afterClusterTime").Timestamp()
+		if ts != 5 || i != 2 {
+			t.Errorf("Unexpected afterClusterTime. got (%d, %d); want (%d, %d)", ts, i, 5, 2)
+		}
+	})
+}
+
+func TestReadConcernLevels(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{WireVersion: &description.VersionRange{Max: 6}},
+	}
+	ns := Namespace{DB: "foo", Collection: "bar"}
+	encoders := []struct {
+		name   string
+		encode func(*readconcern.ReadConcern, *session.Client) (wiremessage.WireMessage, error)
+	}{
+		{"find", func(rc *readconcern.ReadConcern, sess *session.Client) (wiremessage.WireMessage, error) {
+			return (&Find{NS: ns, ReadConcern: rc, Session: sess}).Encode(desc)
+		}},
+		{"count", func(rc *readconcern.ReadConcern, sess *session.Client) (wiremessage.WireMessage, error) {
+			return (&Count{NS: ns, ReadConcern: rc, Session: sess}).Encode(desc)
+		}},
+		{"aggregate", func(rc *readconcern.ReadConcern, sess *session.Client) (wiremessage.WireMessage, error) {
+			return (&Aggregate{NS: ns, ReadConcern: rc, Session: sess}).Encode(desc)
+		}},
+	}
+	levels := []struct {
+		rc    *readconcern.ReadConcern
+		level string
+	}{
+		{readconcern.Local(), "local"},
+		{readconcern.Majority(), "majority"},
+		{readconcern.Linearizable(), "linearizable"},
+		{readconcern.Available(), "available"},
+	}
+
+	readConcernLevel := func(t *testing.T, wm wiremessage.WireMessage) string {
+		t.Helper()
+		msg, ok := wm.(wiremessage.Msg)
+		if !ok {
+			t.Fatalf("Returned wiremessage is not a msg. got %T; want %T", wm, wiremessage.Msg{})
+		}
+		body, ok := msg.Sections[0].(wiremessage.SectionBody)
+		if !ok {
+			t.Fatalf("First section is not a body. got %T; want %T", msg.Sections[0], wiremessage.SectionBody{})
+		}
+		return bson.Raw(body.Document).Lookup("readConcern", "level").StringValue()
+	}
+
+	for _, enc := range encoders {
+		t.Run(enc.name, func(t *testing.T) {
+			for _, l := range levels {
+				t.Run(l.level, func(t *testing.T) {
+					wm, err := enc.encode(l.rc, nil)
+					noerr(t, err)
+					if level := readConcernLevel(t, wm); level != l.level {
+						t.Errorf("Unexpected read concern level. got %s; want %s", level, l.level)
+					}
+				})
+			}
+			t.Run("snapshot outside transaction", func(t *testing.T) {
+				_, err := enc.encode(readconcern.Snapshot(), nil)
+				if err != ErrSnapshotReadConcern {
+					t.Errorf("Unexpected error. got %v; want %v", err, ErrSnapshotReadConcern)
+				}
+			})
+			t.Run("snapshot in transaction", func(t *testing.T) {
+				id, err := uuid.New()
+				noerr(t, err)
+				sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+				noerr(t, err)
+				noerr(t, sess.StartTransaction(&session.TransactionOptions{ReadConcern: readconcern.Snapshot()}))
+
+				wm, err := enc.encode(nil, sess)
+				noerr(t, err)
+				if level := readConcernLevel(t, wm); level != "snapshot" {
+					t.Errorf("Unexpected read concern level. got %s; want %s", level, "snapshot")
+				}
+			})
+		})
+	}
+}
+
+func TestOperationTimeFromErrorResponse(t *testing.T) {
+	id, err := uuid.New()
+	noerr(t, err)
+	sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+	noerr(t, err)
+
+	desc := description.SelectedServer{
+		Server: description.Server{WireVersion: &description.VersionRange{Max: 6}},
+	}
+	reply, err := bsonx.Doc{
+		{"ok", bsonx.Int32(0)},
+		{"errmsg", bsonx.String("command failed")},
+		{"code", bsonx.Int32(2)},
+		{"operationTime", bsonx.Timestamp(7, 3)},
+	}.MarshalBSON()
+	noerr(t, err)
+
+	read := &Read{DB: "foo", Session: sess}
+	_, err = read.Decode(desc, wiremessage.Msg{Sections: []wiremessage.Section{wiremessage.SectionBody{Document: reply}}}).Result()
+	if _, ok := err.(Error); !ok {
+		t.Fatalf("Expected a command error. got %v (%T)", err, err)
+	}
+	if sess.OperationTime == nil {
+		t.Fatal("Expected operationTime to be tracked from the error response")
+	}
+	if sess.OperationTime.T != 7 || sess.OperationTime.I != 3 {
+		t.Errorf("Unexpected operationTime. got (%d, %d); want (%d, %d)", sess.OperationTime.T, sess.OperationTime.I, 7, 3)
+	}
+
+	// the next causally consistent read must wait for the failed operation
+	wm, err := (&Find{NS: Namespace{DB: "foo", Collection: "bar"}, Session: sess, ReadConcern: readconcern.Local()}).Encode(desc)
+	noerr(t, err)
+	body := wm.(wiremessage.Msg).Sections[0].(wiremessage.SectionBody)
+	ts, i := bson.Raw(body.Document).Lookup("readConcern", "afterClusterTime").Timestamp()
+	if ts != 7 || i != 3 {
+		t.Errorf("Unexpected afterClusterTime. got (%d, %d); want (%d, %d)", ts, i, 7, 3)
+	}
+}
+
+func TestReadConcernAtClusterTime(t *testing.T) {
+	desc := description.SelectedServer{
+		Server: description.Server{WireVersion: &description.VersionRange{Max: 6}},
+	}
+	ns := Namespace{DB: "foo", Collection: "bar"}
+	snapshotAt := readconcern.New(readconcern.Level("snapshot"), readconcern.AtClusterTime(primitive.Timestamp{T: 12, I: 4}))
+	majorityAt := readconcern.New(readconcern.Level("majority"), readconcern.AtClusterTime(primitive.Timestamp{T: 12, I: 4}))
+
+	commands := []struct {
+		name   string
+		encode func(*readconcern.ReadConcern, *session.Client) (wiremessage.WireMessage, error)
+	}{
+		{"find", func(rc *readconcern.ReadConcern, sess *session.Client) (wiremessage.WireMessage, error) {
+			return (&Find{NS: ns, ReadConcern: rc, Session: sess}).Encode(desc)
+		}},
+		{"aggregate", func(rc *readconcern.ReadConcern, sess *session.Client) (wiremessage.WireMessage, error) {
+			return (&Aggregate{NS: ns, ReadConcern: rc, Session: sess}).Encode(desc)
+		}},
+	}
+
+	for _, c := range commands {
+		t.Run(c.name, func(t *testing.T) {
+			id, err := uuid.New()
+			noerr(t, err)
+			// a causally consistent session must not add afterClusterTime next to atClusterTime
+			sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+			noerr(t, err)
+			noerr(t, sess.AdvanceOperationTime(&primitive.Timestamp{T: 5, I: 2}))
+
+			wm, err := c.encode(snapshotAt, sess)
+			noerr(t, err)
+			body := wm.(wiremessage.Msg).Sections[0].(wiremessage.SectionBody)
+			rc := bson.Raw(body.Document).Lookup("readConcern").Document()
+
+			if level := rc.Lookup("level").StringValue(); level != "snapshot" {
+				t.Errorf("Unexpected read concern level. got %s; want %s", level, "snapshot")
+			}
+			ts, i := rc.Lookup("atClusterTime").Timestamp()
+			if ts != 12 || i != 4 {
+				t.Errorf("Unexpected atClusterTime. got (%d, %d); want (%d, %d)", ts, i, 12, 4)
+			}
+			if _, err := rc.LookupErr("afterClusterTime"); err == nil {
+				t.Errorf("Expected no afterClusterTime, but found one in %v", rc)
+			}
+
+			_, err = c.encode(majorityAt, nil)
+			if err != readconcern.ErrAtClusterTimeWithoutSnapshot {
+				t.Errorf("Unexpected error. got %v; want %v", err, readconcern.ErrAtClusterTimeWithoutSnapshot)
+			}
+		})
+	}
+}
+
+func TestRenameCollection(t *testing.T) {
+	rc := &RenameCollection{
+		From: NewNamespace("db", "foo"),
+		To:   NewNamespace("db", "bar"),
+		Opts: []bsonx.Elem{{"dropTarget", bsonx.Boolean(true)}},
+	}
+	write, err := rc.encode(description.SelectedServer{})
+	noerr(t, err)
+	if write.DB != "admin" {
+		t.Errorf("Expected the command to run against the admin database. got %s", write.DB)
+	}
+	want := bsonx.Doc{
+		{"renameCollection", bsonx.String("db.foo")},
+		{"to", bsonx.String("db.bar")},
+		{"dropTarget", bsonx.Boolean(true)},
+	}
+	if !write.Command.Equal(want) {
+		t.Errorf("Commands do not match. got %v; want %v", write.Command, want)
+	}
+
+	rc.To = NewNamespace("db", "")
+	if _, err = rc.encode(description.SelectedServer{}); err == nil {
+		t.Error("Expected an error for an invalid target namespace")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	find := &Find{
+		NS:          NewNamespace("db", "foo"),
+		Filter:      bsonx.Doc{{"x", bsonx.Int32(1)}},
+		Opts:        []bsonx.Elem{{"limit", bsonx.Int64(2)}},
+		ReadConcern: readconcern.Majority(),
+	}
+	inner, err := find.encode(description.SelectedServer{})
+	noerr(t, err)
+
+	read, err := (&Explain{Command: find, Verbosity: "executionStats"}).encode(description.SelectedServer{})
+	noerr(t, err)
+	if read.DB != "db" {
+		t.Errorf("Expected the command to run against the collection's database. got %s", read.DB)
+	}
+	if read.ReadConcern != nil {
+		t.Errorf("Expected the read concern to be omitted. got %v", read.ReadConcern)
+	}
+	want := bsonx.Doc{
+		{"explain", bsonx.Document(inner.Command)},
+		{"verbosity", bsonx.String("executionStats")},
+	}
+	if !read.Command.Equal(want) {
+		t.Errorf("Commands do not match. got %v; want %v", read.Command, want)
+	}
+
+	find.NS = NewNamespace("db", "")
+	if _, err = (&Explain{Command: find}).encode(description.SelectedServer{}); err == nil {
+		t.Error("Expected an error for an invalid namespace")
+	}
+}
+
+func TestFindRawFilter(t *testing.T) {
+	raw, err := bsonx.Doc{{"a", bsonx.Int64(1)}, {"b", bsonx.Regex("^foo", "i")}}.MarshalBSON()
+	noerr(t, err)
+
+	cmd := &Find{NS: Namespace{DB: "db", Collection: "coll"}, RawFilter: raw}
+	wm, err := cmd.Encode(description.SelectedServer{})
+	noerr(t, err)
+	query, ok := wm.(wiremessage.Query)
+	if !ok {
+		t.Fatalf("Returned wiremessage is not a query. got %T; want %T", wm, wiremessage.Query{})
+	}
+	filter, err := query.Query.LookupErr("filter")
+	noerr(t, err)
+	if got := bson.Raw(filter.Value); string(got) != string(raw) {
+		t.Errorf("Raw filter was not spliced into the command. got %v; want %v", got, bson.Raw(raw))
+	}
+}
@@ -44,6 +44,11 @@ type Cursor interface {
 	// Returns true if there were no errors and there is a next result.
 	Next(context.Context) bool
 
+	// Get the next result from the cursor without blocking for more than a single getMore.
+	// Returns true if a document is available. Unlike Next, a false result does not mean the
+	// cursor is exhausted; check Err and ID to tell the cases apart.
+	TryNext(context.Context) bool
+
 	// Decode the next document into the provided object according to the
 	// rules of the bson package.
 	Decode(interface{}) error
@@ -59,6 +64,21 @@ type Cursor interface {
 	Close(context.Context) error
 }
 
+// CurrentCursor is implemented by Cursors that can return the raw bytes of their current document.
+type CurrentCursor interface {
+	// Returns the raw bytes of the document Next or TryNext most recently positioned the cursor
+	// on, or nil if there is no such document. The bytes are only valid until the next call to
+	// Next or TryNext; the user must copy them to retain them.
+	Current() bson.Raw
+}
+
+// ResumeTokenCursor is implemented by Cursors that record the postBatchResumeToken of their batches.
+type ResumeTokenCursor interface {
+	// Returns the postBatchResumeToken of the most recent batch, or nil if the server did not
+	// include one. Only change stream cursors have a postBatchResumeToken.
+	PostBatchResumeToken() bson.Raw
+}
+
 // CursorBuilder is a type that can build a Cursor.
 type CursorBuilder interface {
 	BuildCursor(bson.Raw, *session.Client, *session.ClusterClock, ...bsonx.Elem) (Cursor, error)
@@ -68,6 +88,7 @@ type emptyCursor struct{}
 
 func (ec emptyCursor) ID() int64                      { return -1 }
 func (ec emptyCursor) Next(context.Context) bool      { return false }
+func (ec emptyCursor) TryNext(context.Context) bool   { return false }
 func (ec emptyCursor) Decode(interface{}) error       { return nil }
 func (ec emptyCursor) DecodeBytes() (bson.Raw, error) { return nil, nil }
 func (ec emptyCursor) Err() error                     { return nil }
@@ -30,6 +30,11 @@ var (
 	ErrDocumentTooLarge = errors.New("an inserted document is too large")
 	// ErrNonPrimaryRP occurs when a nonprimary read preference is used with a transaction.
 	ErrNonPrimaryRP = errors.New("read preference in a transaction must be primary")
+	// ErrSnapshotReadConcern occurs when a snapshot read concern without an atClusterTime is used outside of a
+	// transaction.
+	ErrSnapshotReadConcern = errors.New("read concern level snapshot is only permitted in a transaction or with atClusterTime")
+	// ErrAppNameTooLong occurs when the application name sent in the handshake exceeds MaxAppNameLength bytes.
+	ErrAppNameTooLong = errors.New("application name must not exceed 128 bytes")
 	// UnknownTransactionCommitResult is an error label for unknown transaction commit results.
 	UnknownTransactionCommitResult = "UnknownTransactionCommitResult"
 	// TransientTransactionError is an error label for transient errors with transactions.
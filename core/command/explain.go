@@ -0,0 +1,90 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// Explainable is a command that can be wrapped in an explain command. It is implemented by the
+// Aggregate, Count, Distinct, and Find commands.
+type Explainable interface {
+	encode(desc description.SelectedServer) (*Read, error)
+}
+
+// Explain represents the explain command.
+//
+// The explain command returns information about how the server would execute the wrapped command.
+// The wrapped command is encoded exactly as it would be if it were run on its own, except that the
+// read concern is not sent because the explain command does not support it.
+type Explain struct {
+	Command   Explainable
+	Verbosity string
+
+	result bson.Raw
+	err    error
+}
+
+// Encode will encode this command into a wire message for the given server description.
+func (e *Explain) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+	cmd, err := e.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd.Encode(desc)
+}
+
+func (e *Explain) encode(desc description.SelectedServer) (*Read, error) {
+	cmd, err := e.Command.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	command := bsonx.Doc{{"explain", bsonx.Document(cmd.Command)}}
+	if e.Verbosity != "" {
+		command = append(command, bsonx.Elem{"verbosity", bsonx.String(e.Verbosity)})
+	}
+
+	cmd.Command = command
+	cmd.ReadConcern = nil
+	return cmd, nil
+}
+
+// Decode will decode the wire message using the provided server description. Errors during decoding
+// are deferred until either the Result or Err methods are called.
+func (e *Explain) Decode(desc description.SelectedServer, wm wiremessage.WireMessage) *Explain {
+	e.result, e.err = (&Read{}).Decode(desc, wm).Result()
+	return e
+}
+
+// Result returns the result of a decoded wire message and server description.
+func (e *Explain) Result() (bson.Raw, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	return e.result, nil
+}
+
+// Err returns the error set on this command.
+func (e *Explain) Err() error { return e.err }
+
+// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
+func (e *Explain) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Raw, error) {
+	cmd, err := e.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	e.result, e.err = cmd.RoundTrip(ctx, desc, rw)
+	return e.Result()
+}
@@ -24,6 +24,7 @@ import (
 type Find struct {
 	NS          Namespace
 	Filter      bsonx.Doc
+	RawFilter   bson.Raw // a pre-serialized filter sent as is in place of Filter
 	CursorOpts  []bsonx.Elem
 	Opts        []bsonx.Elem
 	ReadPref    *readpref.ReadPref
@@ -52,7 +53,10 @@ func (f *Find) encode(desc description.SelectedServer) (*Read, error) {
 
 	command := bsonx.Doc{{"find", bsonx.String(f.NS.Collection)}}
 
-	if f.Filter != nil {
+	switch {
+	case f.RawFilter != nil:
+		command = append(command, bsonx.Elem{"filter", bsonx.Document(bsonx.RawDoc(f.RawFilter))})
+	case f.Filter != nil:
 		command = append(command, bsonx.Elem{"filter", bsonx.Document(f.Filter)})
 	}
 
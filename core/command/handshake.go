@@ -9,6 +9,7 @@ package command
 import (
 	"context"
 	"runtime"
+	"unicode/utf8"
 
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/description"
@@ -21,11 +22,13 @@ import (
 // Handshake represents a generic MongoDB Handshake. It calls isMaster and
 // buildInfo.
 //
-// The isMaster and buildInfo commands are used to build a server description.
+// The isMaster and buildInfo commands are used to build a server description. If UseHello is set,
+// hello is sent over OP_MSG in place of isMaster, as required when a server API version is declared.
 type Handshake struct {
 	Client             bsonx.Doc
 	Compressors        []string
 	SaslSupportedMechs string
+	UseHello           bool
 
 	ismstr result.IsMaster
 	err    error
@@ -38,6 +41,7 @@ func (h *Handshake) Encode() (wiremessage.WireMessage, error) {
 		Client:             h.Client,
 		Compressors:        h.Compressors,
 		SaslSupportedMechs: h.SaslSupportedMechs,
+		UseHello:           h.UseHello,
 	}).Encode()
 	if err != nil {
 		return wm, err
@@ -90,9 +94,30 @@ func (h *Handshake) Handshake(ctx context.Context, addr address.Address, rw wire
 	return h.Decode(wm).Result(addr)
 }
 
+// MaxAppNameLength is the maximum length in bytes of the application name the server accepts
+// in the handshake.
+const MaxAppNameLength = 128
+
+// ValidateAppName returns ErrAppNameTooLong if app is longer than MaxAppNameLength bytes.
+func ValidateAppName(app string) error {
+	if len(app) > MaxAppNameLength {
+		return ErrAppNameTooLong
+	}
+	return nil
+}
+
+// MaxClientMetadataSize is the maximum size in bytes of the client metadata document the server
+// accepts in the handshake.
+const MaxClientMetadataSize = 512
+
 // ClientDoc creates a client information document for use in an isMaster
-// command.
+// command. The platform is truncated if the document would otherwise exceed
+// MaxClientMetadataSize bytes.
 func ClientDoc(app string) bsonx.Doc {
+	return clientDoc(app, runtime.Version())
+}
+
+func clientDoc(app string, platform string) bsonx.Doc {
 	doc := bsonx.Doc{
 		{"driver",
 			bsonx.Document(bsonx.Doc{
@@ -106,12 +131,38 @@ func ClientDoc(app string) bsonx.Doc {
 				{"architecture", bsonx.String(runtime.GOARCH)},
 			}),
 		},
-		{"platform", bsonx.String(runtime.Version())},
+		{"platform", bsonx.String("")},
 	}
 
 	if app != "" {
 		doc = append(doc, bsonx.Elem{"application", bsonx.Document(bsonx.Doc{{"name", bsonx.String(app)}})})
 	}
 
+	// the platform is the least important field, so it only gets the space that is left over
+	remaining := MaxClientMetadataSize - clientDocSize(doc)
+	if remaining < 0 {
+		remaining = 0
+	}
+	doc[2].Value = bsonx.String(truncateString(platform, remaining))
+
 	return doc
 }
+
+func clientDocSize(doc bsonx.Doc) int {
+	b, err := doc.MarshalBSON()
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+// truncateString shortens s to at most n bytes without splitting a multi-byte character.
+func truncateString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
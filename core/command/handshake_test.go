@@ -0,0 +1,130 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/version"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+func TestHandshakeAppName(t *testing.T) {
+	appNameFromHandshake := func(t *testing.T, app string) (string, bool) {
+		wm, err := (&Handshake{Client: ClientDoc(app)}).Encode()
+		noerr(t, err)
+		query, ok := wm.(wiremessage.Query)
+		if !ok {
+			t.Fatalf("Expected the handshake to be an OP_QUERY. got %T", wm)
+		}
+		val, err := query.Query.LookupErr("client", "application", "name")
+		if err != nil {
+			return "", false
+		}
+		return val.StringValue(), true
+	}
+
+	t.Run("included", func(t *testing.T) {
+		name, ok := appNameFromHandshake(t, "reporting-service")
+		if !ok || name != "reporting-service" {
+			t.Errorf("Incorrect application name. got %q (found %v); want %q", name, ok, "reporting-service")
+		}
+	})
+	t.Run("omitted when empty", func(t *testing.T) {
+		if _, ok := appNameFromHandshake(t, ""); ok {
+			t.Error("Expected no application document for an empty name")
+		}
+	})
+	t.Run("validate", func(t *testing.T) {
+		if err := ValidateAppName(strings.Repeat("a", MaxAppNameLength)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if err := ValidateAppName(strings.Repeat("a", MaxAppNameLength+1)); err != ErrAppNameTooLong {
+			t.Errorf("Expected ErrAppNameTooLong. got %v", err)
+		}
+	})
+}
+
+func TestClientDoc(t *testing.T) {
+	t.Run("structure", func(t *testing.T) {
+		doc := ClientDoc("app")
+		raw, err := doc.MarshalBSON()
+		noerr(t, err)
+		rdr := bson.Raw(raw)
+
+		testCases := []struct {
+			path     []string
+			expected string
+		}{
+			{[]string{"driver", "name"}, "mongo-go-driver"},
+			{[]string{"driver", "version"}, version.Driver},
+			{[]string{"os", "type"}, runtime.GOOS},
+			{[]string{"os", "architecture"}, runtime.GOARCH},
+			{[]string{"platform"}, runtime.Version()},
+			{[]string{"application", "name"}, "app"},
+		}
+		for _, tc := range testCases {
+			val, err := rdr.LookupErr(tc.path...)
+			noerr(t, err)
+			if got := val.StringValue(); got != tc.expected {
+				t.Errorf("Incorrect value for %s. got %q; want %q", strings.Join(tc.path, "."), got, tc.expected)
+			}
+		}
+	})
+	t.Run("size cap", func(t *testing.T) {
+		app := strings.Repeat("a", MaxAppNameLength)
+		doc := clientDoc(app, strings.Repeat("p", 2*MaxClientMetadataSize))
+		raw, err := doc.MarshalBSON()
+		noerr(t, err)
+		if len(raw) != MaxClientMetadataSize {
+			t.Errorf("Expected the platform to fill the document up to the limit. got %d bytes; want %d", len(raw), MaxClientMetadataSize)
+		}
+		platform := doc.Lookup("platform").StringValue()
+		if len(platform) == 0 || strings.Trim(platform, "p") != "" {
+			t.Errorf("Expected a truncated platform. got %q", platform)
+		}
+		if got := doc.Lookup("application", "name").StringValue(); got != app {
+			t.Errorf("The application name should not be truncated. got %d bytes", len(got))
+		}
+
+		// a short platform is left alone
+		doc = clientDoc(app, "go1.11")
+		if got := doc.Lookup("platform").StringValue(); got != "go1.11" {
+			t.Errorf("Incorrect platform. got %q; want %q", got, "go1.11")
+		}
+	})
+}
+
+func TestHandshakeUseHello(t *testing.T) {
+	wm, err := (&Handshake{Client: ClientDoc("app"), UseHello: true}).Encode()
+	noerr(t, err)
+	msg, ok := wm.(wiremessage.Msg)
+	if !ok {
+		t.Fatalf("Expected the handshake to be an OP_MSG. got %T", wm)
+	}
+	cmd := msg.Sections[0].(wiremessage.SectionBody).Document
+	if name := cmd.Index(0).Key(); name != "hello" {
+		t.Errorf("Incorrect command name. got %q; want %q", name, "hello")
+	}
+	if db, err := cmd.LookupErr("$db"); err != nil || db.StringValue() != "admin" {
+		t.Errorf("Expected $db to be admin. got %v (err %v)", db, err)
+	}
+
+	reply, err := bsonx.Doc{{"ok", bsonx.Int32(1)}, {"isWritablePrimary", bsonx.Boolean(true)}}.MarshalBSON()
+	noerr(t, err)
+	res, err := (&IsMaster{UseHello: true}).Decode(wiremessage.Msg{
+		Sections: []wiremessage.Section{wiremessage.SectionBody{Document: reply}},
+	}).Result()
+	noerr(t, err)
+	if !res.IsMaster {
+		t.Error("Expected isWritablePrimary to be reported as isMaster")
+	}
+}
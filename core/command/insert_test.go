@@ -7,11 +7,15 @@
 package command
 
 import (
+	"context"
 	"testing"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInsertCommandSplitting(t *testing.T) {
@@ -93,3 +97,53 @@ func TestInsertCommandSplitting(t *testing.T) {
 		}
 	})
 }
+
+// replyReadWriter answers each written wire message with the next of its replies.
+type replyReadWriter struct {
+	replies []bsonx.Doc
+	written int
+}
+
+func (rw *replyReadWriter) WriteWireMessage(context.Context, wiremessage.WireMessage) error {
+	rw.written++
+	return nil
+}
+
+func (rw *replyReadWriter) ReadWireMessage(context.Context) (wiremessage.WireMessage, error) {
+	doc, err := rw.replies[rw.written-1].MarshalBSON()
+	if err != nil {
+		return nil, err
+	}
+	return wiremessage.Reply{NumberReturned: 1, Documents: []bson.Raw{doc}}, nil
+}
+
+func TestInsertUnorderedWriteErrorsAcrossBatches(t *testing.T) {
+	writeErr := func(index int32) bsonx.Val {
+		return bsonx.Document(bsonx.Doc{
+			{"index", bsonx.Int32(index)},
+			{"code", bsonx.Int32(11000)},
+			{"errmsg", bsonx.String("duplicate key")},
+		})
+	}
+	rw := &replyReadWriter{replies: []bsonx.Doc{
+		{{"ok", bsonx.Int32(1)}, {"n", bsonx.Int32(1)}, {"writeErrors", bsonx.Array(bsonx.Arr{writeErr(1)})}},
+		{{"ok", bsonx.Int32(1)}, {"n", bsonx.Int32(1)}, {"writeErrors", bsonx.Array(bsonx.Arr{writeErr(0)})}},
+	}}
+
+	i := &Insert{
+		NS:   Namespace{DB: "db", Collection: "coll"},
+		Opts: []bsonx.Elem{{"ordered", bsonx.Boolean(false)}},
+	}
+	for n := 0; n < 4; n++ {
+		i.Docs = append(i.Docs, bsonx.Doc{{"_id", bsonx.Int32(int32(n))}})
+	}
+
+	desc := description.SelectedServer{Server: description.Server{MaxBatchCount: 2, MaxDocumentSize: 1024}}
+	res, err := i.RoundTrip(context.Background(), desc, rw)
+	require.NoError(t, err)
+	require.Equal(t, 2, rw.written, "expected both batches to be sent")
+	require.Equal(t, 2, res.N)
+	require.Len(t, res.WriteErrors, 2)
+	require.Equal(t, 1, res.WriteErrors[0].Index)
+	require.Equal(t, 2, res.WriteErrors[1].Index)
+}
@@ -22,10 +22,14 @@ import (
 // for monitoring a MongoDB server.
 //
 // Since IsMaster can only be run on a connection, there is no Dispatch method.
+//
+// If UseHello is set, the hello command is sent over OP_MSG instead. It must be set when a server
+// API version is declared, since isMaster is not part of the Stable API.
 type IsMaster struct {
 	Client             bsonx.Doc
 	Compressors        []string
 	SaslSupportedMechs string
+	UseHello           bool
 
 	err error
 	res result.IsMaster
@@ -34,6 +38,9 @@ type IsMaster struct {
 // Encode will encode this command into a wire message for the given server description.
 func (im *IsMaster) Encode() (wiremessage.WireMessage, error) {
 	cmd := bsonx.Doc{{"isMaster", bsonx.Int32(1)}}
+	if im.UseHello {
+		cmd = bsonx.Doc{{"hello", bsonx.Int32(1)}}
+	}
 	if im.Client != nil {
 		cmd = append(cmd, bsonx.Elem{"client", bsonx.Document(im.Client)})
 	}
@@ -49,6 +56,17 @@ func (im *IsMaster) Encode() (wiremessage.WireMessage, error) {
 
 	cmd = append(cmd, bsonx.Elem{"compression", bsonx.Array(array)})
 
+	if im.UseHello {
+		rdr, err := opmsgAddGlobals(cmd, "admin", nil)
+		if err != nil {
+			return nil, err
+		}
+		return wiremessage.Msg{
+			MsgHeader: wiremessage.Header{RequestID: wiremessage.NextRequestID()},
+			Sections:  []wiremessage.Section{wiremessage.SectionBody{Document: rdr}},
+		}, nil
+	}
+
 	rdr, err := cmd.MarshalBSON()
 	if err != nil {
 		return nil, err
@@ -66,12 +84,16 @@ func (im *IsMaster) Encode() (wiremessage.WireMessage, error) {
 // Decode will decode the wire message using the provided server description. Errors during decoding
 // are deferred until either the Result or Err methods are called.
 func (im *IsMaster) Decode(wm wiremessage.WireMessage) *IsMaster {
-	reply, ok := wm.(wiremessage.Reply)
-	if !ok {
-		im.err = fmt.Errorf("unsupported response wiremessage type %T", wm)
-		return im
+	var rdr bson.Raw
+	var err error
+	switch converted := wm.(type) {
+	case wiremessage.Reply:
+		rdr, err = decodeCommandOpReply(converted)
+	case wiremessage.Msg:
+		rdr, err = decodeCommandOpMsg(converted)
+	default:
+		err = fmt.Errorf("unsupported response wiremessage type %T", wm)
 	}
-	rdr, err := decodeCommandOpReply(reply)
 	if err != nil {
 		im.err = err
 		return im
@@ -82,6 +104,11 @@ func (im *IsMaster) Decode(wm wiremessage.WireMessage) *IsMaster {
 		return im
 	}
 
+	// hello reports isWritablePrimary in place of ismaster
+	if im.res.IsWritablePrimary {
+		im.res.IsMaster = true
+	}
+
 	// Reconstructs the $clusterTime doc after decode
 	if im.res.ClusterTime != nil {
 		im.res.ClusterTime = bsonx.Doc{{"$clusterTime", bsonx.Document(im.res.ClusterTime)}}
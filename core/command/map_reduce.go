@@ -0,0 +1,171 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/readpref"
+	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// MapReduce represents the mapReduce command.
+//
+// The mapReduce command runs a map-reduce aggregation over a collection. When Out is nil the
+// results are returned inline and exposed as a cursor; otherwise they are written to the
+// collection described by Out and an empty cursor is returned.
+type MapReduce struct {
+	NS           Namespace
+	Map          string
+	Reduce       string
+	Out          bsonx.Doc
+	Opts         []bsonx.Elem
+	ReadPref     *readpref.ReadPref
+	WriteConcern *writeconcern.WriteConcern
+	ReadConcern  *readconcern.ReadConcern
+	Clock        *session.ClusterClock
+	Session      *session.Client
+
+	result Cursor
+	err    error
+}
+
+// Encode will encode this command into a wire message for the given server description.
+func (mr *MapReduce) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+	cmd, err := mr.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd.Encode(desc)
+}
+
+func (mr *MapReduce) encode(desc description.SelectedServer) (*Read, error) {
+	if err := mr.NS.Validate(); err != nil {
+		return nil, err
+	}
+
+	out := bsonx.Document(bsonx.Doc{{"inline", bsonx.Int32(1)}})
+	if !mr.Inline() {
+		out = bsonx.Document(mr.Out)
+	}
+
+	command := bsonx.Doc{
+		{"mapReduce", bsonx.String(mr.NS.Collection)},
+		{"map", bsonx.JavaScript(mr.Map)},
+		{"reduce", bsonx.JavaScript(mr.Reduce)},
+		{"out", out},
+	}
+	command = append(command, mr.Opts...)
+
+	// add write concern because it won't be added by the Read command's Encode()
+	if !mr.Inline() && mr.WriteConcern != nil {
+		element, err := mr.WriteConcern.MarshalBSONElement()
+		if err != nil {
+			return nil, err
+		}
+
+		command = append(command, element)
+	}
+
+	return &Read{
+		DB:          mr.NS.DB,
+		Command:     command,
+		ReadPref:    mr.ReadPref,
+		ReadConcern: mr.ReadConcern,
+		Clock:       mr.Clock,
+		Session:     mr.Session,
+	}, nil
+}
+
+// Inline returns true if the results are returned in the command response instead of being
+// written to a collection.
+func (mr *MapReduce) Inline() bool {
+	return mr.Out == nil
+}
+
+// Decode will decode the wire message using the provided server description. Errors during decoding
+// are deferred until either the Result or Err methods are called.
+func (mr *MapReduce) Decode(desc description.SelectedServer, cb CursorBuilder, wm wiremessage.WireMessage) *MapReduce {
+	rdr, err := (&Read{}).Decode(desc, wm).Result()
+	if err != nil {
+		mr.err = err
+		return mr
+	}
+
+	return mr.decode(desc, cb, rdr)
+}
+
+func (mr *MapReduce) decode(desc description.SelectedServer, cb CursorBuilder, rdr bson.Raw) *MapReduce {
+	if !mr.Inline() {
+		mr.result = emptyCursor{}
+		return mr
+	}
+
+	results, err := rdr.LookupErr("results")
+	if err != nil {
+		mr.err = err
+		return mr
+	}
+	arr, ok := results.ArrayOK()
+	if !ok {
+		mr.err = fmt.Errorf("results should be an array but it is a BSON %s", results.Type)
+		return mr
+	}
+	var batch bsonx.Arr
+	if err = batch.UnmarshalBSONValue(bson.TypeArray, arr); err != nil {
+		mr.err = err
+		return mr
+	}
+
+	// inline results are returned in full, so they are exposed as an exhausted cursor
+	cursorDoc, err := bsonx.Doc{{"cursor", bsonx.Document(bsonx.Doc{
+		{"id", bsonx.Int64(0)},
+		{"ns", bsonx.String(mr.NS.FullName())},
+		{"firstBatch", bsonx.Array(batch)},
+	})}}.MarshalBSON()
+	if err != nil {
+		mr.err = err
+		return mr
+	}
+
+	mr.result, mr.err = cb.BuildCursor(cursorDoc, mr.Session, mr.Clock)
+	return mr
+}
+
+// Result returns the result of a decoded wire message and server description.
+func (mr *MapReduce) Result() (Cursor, error) {
+	if mr.err != nil {
+		return nil, mr.err
+	}
+	return mr.result, nil
+}
+
+// Err returns the error set on this command.
+func (mr *MapReduce) Err() error { return mr.err }
+
+// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
+func (mr *MapReduce) RoundTrip(ctx context.Context, desc description.SelectedServer, cb CursorBuilder, rw wiremessage.ReadWriter) (Cursor, error) {
+	cmd, err := mr.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	rdr, err := cmd.RoundTrip(ctx, desc, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	return mr.decode(desc, cb, rdr).Result()
+}
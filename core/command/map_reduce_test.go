@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+type recordingCursorBuilder struct {
+	result bson.Raw
+}
+
+func (rcb *recordingCursorBuilder) BuildCursor(result bson.Raw, _ *session.Client, _ *session.ClusterClock, _ ...bsonx.Elem) (Cursor, error) {
+	rcb.result = result
+	return emptyCursor{}, nil
+}
+
+func TestMapReduce(t *testing.T) {
+	desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 6}}}
+
+	t.Run("inline", func(t *testing.T) {
+		mr := &MapReduce{NS: NewNamespace("db", "coll"), Map: "function() {}", Reduce: "function(k, v) {}"}
+		read, err := mr.encode(desc)
+		noerr(t, err)
+		want := bsonx.Doc{
+			{"mapReduce", bsonx.String("coll")},
+			{"map", bsonx.JavaScript("function() {}")},
+			{"reduce", bsonx.JavaScript("function(k, v) {}")},
+			{"out", bsonx.Document(bsonx.Doc{{"inline", bsonx.Int32(1)}})},
+		}
+		if !read.Command.Equal(want) {
+			t.Errorf("Commands do not match. got %v; want %v", read.Command, want)
+		}
+
+		results := bsonx.Arr{
+			bsonx.Document(bsonx.Doc{{"_id", bsonx.String("a")}, {"value", bsonx.Double(2)}}),
+			bsonx.Document(bsonx.Doc{{"_id", bsonx.String("b")}, {"value", bsonx.Double(1)}}),
+		}
+		rdr, err := bsonx.Doc{{"results", bsonx.Array(results)}, {"ok", bsonx.Int32(1)}}.MarshalBSON()
+		noerr(t, err)
+
+		cb := &recordingCursorBuilder{}
+		_, err = mr.decode(desc, cb, rdr).Result()
+		noerr(t, err)
+		got, err := bsonx.ReadDoc(cb.result)
+		noerr(t, err)
+		wantCursor := bsonx.Doc{{"cursor", bsonx.Document(bsonx.Doc{
+			{"id", bsonx.Int64(0)},
+			{"ns", bsonx.String("db.coll")},
+			{"firstBatch", bsonx.Array(results)},
+		})}}
+		if !got.Equal(wantCursor) {
+			t.Errorf("Cursor documents do not match. got %v; want %v", got, wantCursor)
+		}
+	})
+	t.Run("out", func(t *testing.T) {
+		out := bsonx.Doc{{"merge", bsonx.String("results")}}
+		mr := &MapReduce{NS: NewNamespace("db", "coll"), Map: "m", Reduce: "r", Out: out}
+		read, err := mr.encode(desc)
+		noerr(t, err)
+		if got := read.Command.Lookup("out"); !got.Equal(bsonx.Document(out)) {
+			t.Errorf("Out does not match. got %v; want %v", got, out)
+		}
+
+		rdr, err := bsonx.Doc{{"result", bsonx.String("results")}, {"ok", bsonx.Int32(1)}}.MarshalBSON()
+		noerr(t, err)
+		cb := &recordingCursorBuilder{}
+		cursor, err := mr.decode(desc, cb, rdr).Result()
+		noerr(t, err)
+		if cb.result != nil {
+			t.Error("Expected no cursor to be built for an output collection")
+		}
+		if cursor.Next(context.Background()) {
+			t.Error("Expected an empty cursor for an output collection")
+		}
+	})
+	t.Run("results not an array", func(t *testing.T) {
+		mr := &MapReduce{NS: NewNamespace("db", "coll"), Map: "m", Reduce: "r"}
+		rdr, err := bsonx.Doc{{"results", bsonx.String("foo")}, {"ok", bsonx.Int32(1)}}.MarshalBSON()
+		noerr(t, err)
+		if _, err = mr.decode(desc, &recordingCursorBuilder{}, rdr).Result(); err == nil {
+			t.Error("Expected an error for results that are not an array")
+		}
+	})
+}
@@ -49,9 +49,11 @@ func decodeCommandOpMsg(msg wiremessage.Msg) (bson.Raw, error) {
 		return nil, NewCommandResponseError("malformed OP_MSG: invalid document", err)
 	}
 
+	// the response is returned alongside a command error so that cluster and operation times can still be
+	// gossiped from it
 	err = extractError(rdr)
 	if err != nil {
-		return nil, err
+		return rdr, err
 	}
 	return rdr, nil
 }
@@ -35,9 +35,11 @@ func decodeCommandOpReply(reply wiremessage.Reply) (bson.Raw, error) {
 		}
 	}
 
+	// the response is returned alongside a command error so that cluster and operation times can still be
+	// gossiped from it
 	err = extractError(rdr)
 	if err != nil {
-		return nil, err
+		return rdr, err
 	}
 	return rdr, nil
 }
@@ -257,7 +257,14 @@ func (r *Read) Err() error {
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
 func (r *Read) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Raw, error) {
-	wm, err := r.Encode(desc)
+	read := *r
+	cmd, err := addMaxTimeMS(ctx, desc, r.Command)
+	if err != nil {
+		return nil, err
+	}
+	read.Command = cmd
+
+	wm, err := read.Encode(desc)
 	if err != nil {
 		return nil, err
 	}
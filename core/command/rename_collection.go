@@ -0,0 +1,110 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// RenameCollection represents the renameCollection command.
+//
+// The renameCollection command renames a collection. It is always run against the admin database.
+type RenameCollection struct {
+	From         Namespace
+	To           Namespace
+	Opts         []bsonx.Elem
+	WriteConcern *writeconcern.WriteConcern
+	Clock        *session.ClusterClock
+	Session      *session.Client
+
+	result bson.Raw
+	err    error
+}
+
+// Encode will encode this command into a wire message for the given server description.
+func (rc *RenameCollection) Encode(desc description.SelectedServer) (wiremessage.WireMessage, error) {
+	cmd, err := rc.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd.Encode(desc)
+}
+
+func (rc *RenameCollection) encode(desc description.SelectedServer) (*Write, error) {
+	if err := rc.From.Validate(); err != nil {
+		return nil, err
+	}
+	if err := rc.To.Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd := bsonx.Doc{
+		{"renameCollection", bsonx.String(rc.From.FullName())},
+		{"to", bsonx.String(rc.To.FullName())},
+	}
+	cmd = append(cmd, rc.Opts...)
+
+	return &Write{
+		Clock:        rc.Clock,
+		WriteConcern: rc.WriteConcern,
+		DB:           "admin",
+		Command:      cmd,
+		Session:      rc.Session,
+	}, nil
+}
+
+// Decode will decode the wire message using the provided server description. Errors during decoding
+// are deferred until either the Result or Err methods are called.
+func (rc *RenameCollection) Decode(desc description.SelectedServer, wm wiremessage.WireMessage) *RenameCollection {
+	rdr, err := (&Write{}).Decode(desc, wm).Result()
+	if err != nil {
+		rc.err = err
+		return rc
+	}
+
+	return rc.decode(desc, rdr)
+}
+
+func (rc *RenameCollection) decode(desc description.SelectedServer, rdr bson.Raw) *RenameCollection {
+	rc.result = rdr
+	return rc
+}
+
+// Result returns the result of a decoded wire message and server description.
+func (rc *RenameCollection) Result() (bson.Raw, error) {
+	if rc.err != nil {
+		return nil, rc.err
+	}
+
+	return rc.result, nil
+}
+
+// Err returns the error set on this command.
+func (rc *RenameCollection) Err() error { return rc.err }
+
+// RoundTrip handles the execution of this command using the provided wiremessage.ReadWriter.
+func (rc *RenameCollection) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Raw, error) {
+	cmd, err := rc.encode(desc)
+	if err != nil {
+		return nil, err
+	}
+
+	rdr, err := cmd.RoundTrip(ctx, desc, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.decode(desc, rdr).Result()
+}
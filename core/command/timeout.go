@@ -0,0 +1,93 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// TimeoutError is returned when an operation's timeout expires before the operation completes.
+type TimeoutError struct {
+	// Wrapped is the error that occurred because the timeout expired, if any.
+	Wrapped error
+}
+
+func (e TimeoutError) Error() string {
+	if e.Wrapped != nil {
+		return "operation timed out: " + e.Wrapped.Error()
+	}
+	return "operation timed out"
+}
+
+// Timeout returns true. It allows a TimeoutError to be detected in the same way as a net.Error.
+func (e TimeoutError) Timeout() bool { return true }
+
+type timeoutKey struct{}
+
+// WithTimeoutDeadline returns a copy of ctx with the deadline d, which is also recorded as the
+// deadline of the operation's timeout. Commands run with the returned context send a maxTimeMS
+// computed from the time remaining before d.
+func WithTimeoutDeadline(ctx context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(context.WithValue(ctx, timeoutKey{}, d), d)
+}
+
+// MaxTimeMS returns the maxTimeMS to send with a command run with ctx against a server with the
+// given average round trip time. ok is false if ctx was not created by WithTimeoutDeadline. If
+// there isn't enough time left to run the command, a TimeoutError is returned.
+func MaxTimeMS(ctx context.Context, rtt time.Duration) (ms int64, ok bool, err error) {
+	d, ok := ctx.Value(timeoutKey{}).(time.Time)
+	if !ok {
+		return 0, false, nil
+	}
+
+	if rtt < 0 {
+		// the round trip time hasn't been measured yet
+		rtt = 0
+	}
+
+	// A maxTimeMS of 0 means no limit, so at least a millisecond must remain.
+	remaining := time.Until(d) - rtt
+	if remaining < time.Millisecond {
+		return 0, true, TimeoutError{Wrapped: context.DeadlineExceeded}
+	}
+	return int64(remaining / time.Millisecond), true, nil
+}
+
+// LimitMaxTimeMS returns a copy of opts with maxTimeMS set to ms, unless opts already contains a
+// smaller maxTimeMS.
+func LimitMaxTimeMS(opts []bsonx.Elem, ms int64) []bsonx.Elem {
+	limited := make([]bsonx.Elem, 0, len(opts)+1)
+	for _, elem := range opts {
+		if elem.Key == "maxTimeMS" {
+			if cur, ok := elem.Value.Int64OK(); ok && cur > 0 && cur < ms {
+				ms = cur
+			}
+			continue
+		}
+		limited = append(limited, elem)
+	}
+	return append(limited, bsonx.Elem{"maxTimeMS", bsonx.Int64(ms)})
+}
+
+// addMaxTimeMS returns cmd with a maxTimeMS computed from the time remaining in the timeout of ctx,
+// if it has one. A getMore is returned unchanged, since the server only accepts a maxTimeMS on the
+// getMore of a tailable await cursor and the cursor sets it itself.
+func addMaxTimeMS(ctx context.Context, desc description.SelectedServer, cmd bsonx.Doc) (bsonx.Doc, error) {
+	if len(cmd) > 0 && cmd[0].Key == "getMore" {
+		return cmd, nil
+	}
+
+	ms, ok, err := MaxTimeMS(ctx, desc.AverageRTT)
+	if err != nil || !ok {
+		return cmd, err
+	}
+	return LimitMaxTimeMS(cmd, ms), nil
+}
@@ -0,0 +1,142 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+func TestMaxTimeMS(t *testing.T) {
+	t.Run("NoTimeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		_, ok, err := MaxTimeMS(ctx, 0)
+		if ok || err != nil {
+			t.Fatalf("expected no maxTimeMS for a plain context deadline. got ok %t, err %v", ok, err)
+		}
+	})
+
+	t.Run("SubtractsRTT", func(t *testing.T) {
+		ctx, cancel := WithTimeoutDeadline(context.Background(), time.Now().Add(10*time.Second))
+		defer cancel()
+
+		ms, ok, err := MaxTimeMS(ctx, 2*time.Second)
+		if !ok || err != nil {
+			t.Fatalf("expected a maxTimeMS. got ok %t, err %v", ok, err)
+		}
+		if ms > 8000 || ms < 7000 {
+			t.Fatalf("expected maxTimeMS close to 8000. got %d", ms)
+		}
+	})
+
+	t.Run("UnsetRTT", func(t *testing.T) {
+		ctx, cancel := WithTimeoutDeadline(context.Background(), time.Now().Add(10*time.Second))
+		defer cancel()
+
+		ms, _, err := MaxTimeMS(ctx, -1*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ms > 10000 {
+			t.Fatalf("expected maxTimeMS of at most 10000. got %d", ms)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		ctx, cancel := WithTimeoutDeadline(context.Background(), time.Now().Add(time.Second))
+		defer cancel()
+
+		_, ok, err := MaxTimeMS(ctx, 2*time.Second)
+		if !ok {
+			t.Fatal("expected ok to be true")
+		}
+		if _, isTimeout := err.(TimeoutError); !isTimeout {
+			t.Fatalf("expected a TimeoutError. got %v", err)
+		}
+	})
+}
+
+func TestLimitMaxTimeMS(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []bsonx.Elem
+		ms   int64
+		want int64
+	}{
+		{"Appended", []bsonx.Elem{{"batchSize", bsonx.Int32(2)}}, 500, 500},
+		{"SmallerExisting", []bsonx.Elem{{"maxTimeMS", bsonx.Int64(100)}}, 500, 100},
+		{"LargerExisting", []bsonx.Elem{{"maxTimeMS", bsonx.Int64(1000)}}, 500, 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orig := append([]bsonx.Elem(nil), tc.opts...)
+
+			got := LimitMaxTimeMS(tc.opts, tc.ms)
+
+			var count int
+			for _, elem := range got {
+				if elem.Key != "maxTimeMS" {
+					continue
+				}
+				count++
+				if elem.Value.Int64() != tc.want {
+					t.Errorf("incorrect maxTimeMS. expected %d got %d", tc.want, elem.Value.Int64())
+				}
+			}
+			if count != 1 {
+				t.Errorf("expected exactly one maxTimeMS. got %d", count)
+			}
+			for i := range orig {
+				if !orig[i].Equal(tc.opts[i]) {
+					t.Errorf("opts were modified. expected %v got %v", orig[i], tc.opts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAddMaxTimeMS(t *testing.T) {
+	ctx, cancel := WithTimeoutDeadline(context.Background(), time.Now().Add(10*time.Second))
+	defer cancel()
+
+	t.Run("Command", func(t *testing.T) {
+		cmd, err := addMaxTimeMS(ctx, description.SelectedServer{}, bsonx.Doc{{"find", bsonx.String("foo")}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cmd.LookupErr("maxTimeMS"); err != nil {
+			t.Errorf("expected maxTimeMS to be added. got %v", cmd)
+		}
+	})
+
+	t.Run("GetMore", func(t *testing.T) {
+		cmd, err := addMaxTimeMS(ctx, description.SelectedServer{}, bsonx.Doc{{"getMore", bsonx.Int64(1)}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cmd.LookupErr("maxTimeMS"); err == nil {
+			t.Errorf("expected no maxTimeMS on a getMore. got %v", cmd)
+		}
+	})
+
+	t.Run("NoTimeout", func(t *testing.T) {
+		cmd, err := addMaxTimeMS(context.Background(), description.SelectedServer{}, bsonx.Doc{{"find", bsonx.String("foo")}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cmd) != 1 {
+			t.Errorf("expected the command to be unchanged. got %v", cmd)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/internal"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBatchResults(t *testing.T) {
+	docs := make([]bsonx.Doc, 0, 6)
+	for n := 0; n < 6; n++ {
+		docs = append(docs, bsonx.Doc{
+			{"q", bsonx.Document(bsonx.Doc{{"a", bsonx.Int32(int32(n))}})},
+			{"u", bsonx.Document(bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"b", bsonx.Int32(1)}})}})},
+			{"upsert", bsonx.Boolean(true)},
+		})
+	}
+
+	runUpdate := func(t *testing.T, maxBatchCount uint32, replies ...bsonx.Doc) result.Update {
+		resps := make(chan wiremessage.WireMessage, len(replies))
+		for _, reply := range replies {
+			resps <- internal.MakeReply(t, reply)
+		}
+		conn := &internal.ChannelConn{T: t, Written: make(chan wiremessage.WireMessage, len(replies)), ReadResp: resps}
+		desc := description.SelectedServer{
+			Server: description.Server{MaxBatchCount: maxBatchCount, MaxDocumentSize: 16 * 1024 * 1024},
+		}
+
+		cmd := &Update{NS: Namespace{DB: "db", Collection: "coll"}, Docs: docs, ContinueOnError: true}
+		res, err := cmd.RoundTrip(context.Background(), desc, conn)
+		noerr(t, err)
+		if len(conn.Written) != len(replies) {
+			t.Fatalf("expected %d batches to be sent but had %d", len(replies), len(conn.Written))
+		}
+		return res
+	}
+
+	upserted := func(ids ...int32) bsonx.Val {
+		arr := make(bsonx.Arr, 0, len(ids))
+		for _, id := range ids {
+			arr = append(arr, bsonx.Document(bsonx.Doc{{"index", bsonx.Int64(1)}, {"_id", bsonx.Int32(id)}}))
+		}
+		return bsonx.Array(arr)
+	}
+
+	single := runUpdate(t, 6, bsonx.Doc{
+		{"ok", bsonx.Int32(1)},
+		{"n", bsonx.Int32(6)},
+		{"nModified", bsonx.Int32(3)},
+		{"upserted", bsonx.Array(bsonx.Arr{
+			bsonx.Document(bsonx.Doc{{"index", bsonx.Int64(1)}, {"_id", bsonx.Int32(0)}}),
+			bsonx.Document(bsonx.Doc{{"index", bsonx.Int64(3)}, {"_id", bsonx.Int32(1)}}),
+			bsonx.Document(bsonx.Doc{{"index", bsonx.Int64(5)}, {"_id", bsonx.Int32(2)}}),
+		})},
+		{"writeErrors", bsonx.Array(bsonx.Arr{
+			bsonx.Document(bsonx.Doc{{"index", bsonx.Int32(4)}, {"code", bsonx.Int32(11000)}, {"errmsg", bsonx.String("duplicate key")}}),
+		})},
+	})
+
+	batchReply := func(id int32) bsonx.Doc {
+		return bsonx.Doc{
+			{"ok", bsonx.Int32(1)},
+			{"n", bsonx.Int32(2)},
+			{"nModified", bsonx.Int32(1)},
+			{"upserted", upserted(id)},
+		}
+	}
+	last := batchReply(2)
+	last = append(last, bsonx.Elem{"writeErrors", bsonx.Array(bsonx.Arr{
+		bsonx.Document(bsonx.Doc{{"index", bsonx.Int32(0)}, {"code", bsonx.Int32(11000)}, {"errmsg", bsonx.String("duplicate key")}}),
+	})})
+	split := runUpdate(t, 2, batchReply(0), batchReply(1), last)
+
+	assert.Equal(t, int64(6), split.MatchedCount)
+	assert.Equal(t, int64(3), split.ModifiedCount)
+	assert.Equal(t, []result.Upsert{{Index: 1, ID: int32(0)}, {Index: 3, ID: int32(1)}, {Index: 5, ID: int32(2)}}, split.Upserted)
+	assert.Equal(t, []result.WriteError{{Index: 4, Code: 11000, ErrMsg: "duplicate key"}}, split.WriteErrors)
+	assert.Equal(t, single, split)
+}
@@ -205,7 +205,14 @@ func (w *Write) Err() error {
 
 // RoundTrip handles the execution of this command using the provided wiremessage.ReadWriteCloser.
 func (w *Write) RoundTrip(ctx context.Context, desc description.SelectedServer, rw wiremessage.ReadWriter) (bson.Raw, error) {
-	wm, err := w.Encode(desc)
+	write := *w
+	cmd, err := addMaxTimeMS(ctx, desc, w.Command)
+	if err != nil {
+		return nil, err
+	}
+	write.Command = cmd
+
+	wm, err := write.Encode(desc)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +89,7 @@ type connection struct {
 	addr        address.Address
 	id          string
 	conn        net.Conn
+	checksum    bool                  // request checksums on outgoing OP_MSG messages
 	compressBuf []byte                // buffer to compress messages
 	compressor  compressor.Compressor // use for compressing messages
 	// server can compress response with any compressor supported by driver
@@ -99,6 +100,7 @@ type connection struct {
 	idleDeadline     time.Time
 	lifetimeDeadline time.Time
 	cmdMonitor       *event.CommandMonitor
+	serverAPI        *ServerAPI
 	readTimeout      time.Duration
 	uncompressBuf    []byte // buffer to uncompress messages
 	writeTimeout     time.Duration
@@ -121,6 +123,16 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 		return nil, nil, err
 	}
 
+	if tcpConn, ok := nc.(*net.TCPConn); ok && cfg.keepAlive > 0 {
+		if err = tcpConn.SetKeepAlive(true); err == nil {
+			err = tcpConn.SetKeepAlivePeriod(cfg.keepAlive)
+		}
+		if err != nil {
+			_ = nc.Close()
+			return nil, nil, err
+		}
+	}
+
 	if cfg.tlsConfig != nil {
 		tlsConfig := cfg.tlsConfig.Clone()
 		nc, err = configureTLS(ctx, nc, addr, tlsConfig)
@@ -144,6 +156,7 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 	c := &connection{
 		id:               id,
 		conn:             nc,
+		checksum:         cfg.checksum,
 		compressBuf:      make([]byte, 256),
 		compressorMap:    compressorMap,
 		commandMap:       make(map[int64]*event.CommandMetadata),
@@ -151,6 +164,7 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 		idleTimeout:      cfg.idleTimeout,
 		lifetimeDeadline: lifetimeDeadline,
 		readTimeout:      cfg.readTimeout,
+		serverAPI:        cfg.serverAPI,
 		writeTimeout:     cfg.writeTimeout,
 		readBuf:          make([]byte, 256),
 		uncompressBuf:    make([]byte, 256),
@@ -192,7 +206,8 @@ func New(ctx context.Context, addr address.Address, opts ...Option) (Connection,
 }
 
 func configureTLS(ctx context.Context, nc net.Conn, addr address.Address, config *TLSConfig) (net.Conn, error) {
-	if !config.InsecureSkipVerify {
+	// The hostname is always sent for SNI, even when verification is disabled.
+	if config.ServerName == "" {
 		hostname := addr.String()
 		colonPos := strings.LastIndex(hostname, ":")
 		if colonPos == -1 {
@@ -226,18 +241,21 @@ func (c *connection) Alive() bool {
 }
 
 func (c *connection) Expired() bool {
-	now := time.Now()
-	if !c.idleDeadline.IsZero() && now.After(c.idleDeadline) {
+	if c.idleExpired() {
 		return true
 	}
 
-	if !c.lifetimeDeadline.IsZero() && now.After(c.lifetimeDeadline) {
+	if !c.lifetimeDeadline.IsZero() && time.Now().After(c.lifetimeDeadline) {
 		return true
 	}
 
 	return c.dead
 }
 
+func (c *connection) idleExpired() bool {
+	return !c.idleDeadline.IsZero() && time.Now().After(c.idleDeadline)
+}
+
 func canCompress(cmd string) bool {
 	if cmd == "isMaster" || cmd == "saslStart" || cmd == "saslContinue" || cmd == "getnonce" || cmd == "authenticate" ||
 		cmd == "createUser" || cmd == "updateUser" || cmd == "copydbSaslStart" || cmd == "copydbgetnonce" || cmd == "copydb" {
@@ -602,6 +620,17 @@ func (c *connection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMe
 	// Truncate the write buffer
 	c.writeBuf = c.writeBuf[:0]
 
+	if c.serverAPI != nil {
+		wm, err = c.serverAPI.apply(wm)
+		if err != nil {
+			return Error{
+				ConnectionID: c.id,
+				Wrapped:      err,
+				message:      "unable to add server API fields to wire message",
+			}
+		}
+	}
+
 	messageToWrite := wm
 	// Compress if possible
 	if c.compressor != nil {
@@ -616,6 +645,15 @@ func (c *connection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMe
 		messageToWrite = compressed
 	}
 
+	// Only uncompressed OP_MSG messages are sent with a checksum.
+	if msg, ok := messageToWrite.(wiremessage.Msg); ok && c.checksum && msg.FlagBits&wiremessage.ChecksumPresent == 0 {
+		msg.FlagBits |= wiremessage.ChecksumPresent
+		if msg.MsgHeader.MessageLength != 0 {
+			msg.MsgHeader.MessageLength += 4
+		}
+		messageToWrite = msg
+	}
+
 	c.writeBuf, err = messageToWrite.AppendWireMessage(c.writeBuf)
 	if err != nil {
 		return Error{
@@ -11,6 +11,9 @@ import (
 	"net"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/address"
 )
 
 // bootstrapConnection creates a listener that will listen for a single connection
@@ -87,3 +90,87 @@ func (d *dialer) lenclosed() int {
 	defer d.Unlock()
 	return len(d.closed)
 }
+
+// blockingConn is a net.Conn whose reads block until the read deadline passes.
+type blockingConn struct {
+	net.Conn
+	mu       sync.Mutex
+	deadline time.Time
+	closed   bool
+}
+
+func (bc *blockingConn) Read([]byte) (int, error) {
+	bc.mu.Lock()
+	deadline := bc.deadline
+	bc.mu.Unlock()
+
+	if deadline.IsZero() {
+		select {}
+	}
+	time.Sleep(time.Until(deadline))
+	return 0, timeoutError{}
+}
+
+func (bc *blockingConn) SetReadDeadline(t time.Time) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.deadline = t
+	return nil
+}
+
+func (bc *blockingConn) Close() error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.closed = true
+	return nil
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestConnection(t *testing.T) {
+	noerr := func(t *testing.T, err error) {
+		t.Helper()
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			t.FailNow()
+		}
+	}
+	t.Run("read timeout closes the connection", func(t *testing.T) {
+		bc := &blockingConn{}
+		d := DialerFunc(func(context.Context, string, string) (net.Conn, error) { return bc, nil })
+		conn, _, err := New(context.Background(), address.Address("localhost:27017"),
+			WithDialer(func(Dialer) Dialer { return d }),
+			WithReadTimeout(func(time.Duration) time.Duration { return 10 * time.Millisecond }),
+		)
+		noerr(t, err)
+
+		_, err = conn.ReadWireMessage(context.Background())
+		connErr, ok := err.(Error)
+		if !ok {
+			t.Fatalf("Expected a connection.Error. got %T; want %T", err, Error{})
+		}
+		if ne, ok := connErr.Wrapped.(net.Error); !ok || !ne.Timeout() {
+			t.Errorf("Expected a network timeout error. got %v", connErr.Wrapped)
+		}
+		if conn.Alive() {
+			t.Errorf("Expected connection to be dead after a read timeout")
+		}
+		if !bc.closed {
+			t.Errorf("Expected the underlying net.Conn to be closed")
+		}
+	})
+	t.Run("keepalive", func(t *testing.T) {
+		addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+			_ = nc.Close()
+		})
+		conn, _, err := New(context.Background(), address.Address(addr.String()),
+			WithKeepAlive(func(time.Duration) time.Duration { return 30 * time.Second }),
+		)
+		noerr(t, err)
+		noerr(t, conn.Close())
+	})
+}
@@ -39,3 +39,15 @@ func (ne NetworkError) Error() string {
 type PoolError string
 
 func (pe PoolError) Error() string { return string(pe) }
+
+// WaitQueueTimeoutError represents a timeout when requesting a connection from the pool. It is
+// returned when the context expires while waiting for a connection to be returned to a full pool,
+// in which case it wraps the context's error, or when the wait exceeds the pool's wait queue
+// timeout, in which case it wraps ErrWaitQueueTimeout.
+type WaitQueueTimeoutError struct {
+	Wrapped error
+}
+
+func (w WaitQueueTimeoutError) Error() string {
+	return fmt.Sprintf("timed out while checking out a connection from connection pool: %s", w.Wrapped.Error())
+}
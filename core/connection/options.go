@@ -15,17 +15,23 @@ import (
 )
 
 type config struct {
-	appName        string
-	connectTimeout time.Duration
-	dialer         Dialer
-	handshaker     Handshaker
-	idleTimeout    time.Duration
-	lifeTimeout    time.Duration
-	cmdMonitor     *event.CommandMonitor
-	readTimeout    time.Duration
-	writeTimeout   time.Duration
-	tlsConfig      *TLSConfig
-	compressors    []compressor.Compressor
+	appName          string
+	checksum         bool
+	connectTimeout   time.Duration
+	dialer           Dialer
+	handshaker       Handshaker
+	idleTimeout      time.Duration
+	keepAlive        time.Duration
+	lifeTimeout      time.Duration
+	maxConnecting    uint64
+	cmdMonitor       *event.CommandMonitor
+	poolMonitor      *event.PoolMonitor
+	readTimeout      time.Duration
+	writeTimeout     time.Duration
+	tlsConfig        *TLSConfig
+	compressors      []compressor.Compressor
+	serverAPI        *ServerAPI
+	waitQueueTimeout time.Duration
 }
 
 func newConfig(opts ...Option) (*config, error) {
@@ -34,6 +40,7 @@ func newConfig(opts ...Option) (*config, error) {
 		dialer:         nil,
 		idleTimeout:    10 * time.Minute,
 		lifeTimeout:    30 * time.Minute,
+		maxConnecting:  2,
 	}
 
 	for _, opt := range opts {
@@ -62,6 +69,15 @@ func WithAppName(fn func(string) string) Option {
 	}
 }
 
+// WithChecksum configures whether OP_MSG messages sent over the connection include a CRC-32C
+// checksum. Checksums on received messages are always validated.
+func WithChecksum(fn func(bool) bool) Option {
+	return func(c *config) error {
+		c.checksum = fn(c.checksum)
+		return nil
+	}
+}
+
 // WithCompressors sets the compressors that can be used for communication.
 func WithCompressors(fn func([]compressor.Compressor) []compressor.Compressor) Option {
 	return func(c *config) error {
@@ -104,6 +120,15 @@ func WithIdleTimeout(fn func(time.Duration) time.Duration) Option {
 	}
 }
 
+// WithKeepAlive configures the TCP keepalive period for a connection. A period of zero leaves
+// the keepalive settings of the dialed connection unchanged.
+func WithKeepAlive(fn func(time.Duration) time.Duration) Option {
+	return func(c *config) error {
+		c.keepAlive = fn(c.keepAlive)
+		return nil
+	}
+}
+
 // WithLifeTimeout configures the maximum life of a connection.
 func WithLifeTimeout(fn func(time.Duration) time.Duration) Option {
 	return func(c *config) error {
@@ -136,6 +161,28 @@ func WithTLSConfig(fn func(*TLSConfig) *TLSConfig) Option {
 	}
 }
 
+// WithMaxConnecting configures the maximum number of connections a pool establishes concurrently.
+// Additional requests for new connections wait until an establishment finishes or an idle
+// connection is returned. Values of 0 are ignored.
+func WithMaxConnecting(fn func(uint64) uint64) Option {
+	return func(c *config) error {
+		if n := fn(c.maxConnecting); n > 0 {
+			c.maxConnecting = n
+		}
+		return nil
+	}
+}
+
+// WithWaitQueueTimeout configures the maximum amount of time a pool checkout waits for a connection
+// to become available. When the wait exceeds it, the checkout fails with a WaitQueueTimeoutError
+// even if its context has not expired. A value of 0 means the wait is bounded only by the context.
+func WithWaitQueueTimeout(fn func(time.Duration) time.Duration) Option {
+	return func(c *config) error {
+		c.waitQueueTimeout = fn(c.waitQueueTimeout)
+		return nil
+	}
+}
+
 // WithMonitor configures a event for command monitoring.
 func WithMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) Option {
 	return func(c *config) error {
@@ -143,3 +190,20 @@ func WithMonitor(fn func(*event.CommandMonitor) *event.CommandMonitor) Option {
 		return nil
 	}
 }
+
+// WithServerAPI configures the server API version declared on every command sent over the
+// connection, including the handshake.
+func WithServerAPI(fn func(*ServerAPI) *ServerAPI) Option {
+	return func(c *config) error {
+		c.serverAPI = fn(c.serverAPI)
+		return nil
+	}
+}
+
+// WithPoolMonitor configures a monitor for the events of the pool that manages the connections.
+func WithPoolMonitor(fn func(*event.PoolMonitor) *event.PoolMonitor) Option {
+	return func(c *config) error {
+		c.poolMonitor = fn(c.poolMonitor)
+		return nil
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/event"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"golang.org/x/sync/semaphore"
 )
@@ -24,6 +26,14 @@ var ErrPoolClosed = PoolError("pool is closed")
 // larger than the capacity.
 var ErrSizeLargerThanCapacity = PoolError("size is larger than capacity")
 
+// ErrMinSizeLargerThanCapacity is returned from an attempt to create a pool with a minimum size
+// larger than the capacity.
+var ErrMinSizeLargerThanCapacity = PoolError("minimum size is larger than capacity")
+
+// ErrWaitQueueTimeout is wrapped by the WaitQueueTimeoutError returned from a checkout that waited
+// longer than the pool's wait queue timeout.
+var ErrWaitQueueTimeout = PoolError("wait queue timeout expired")
+
 // ErrPoolConnected is returned from an attempt to connect an already connected pool
 var ErrPoolConnected = PoolError("pool is connected")
 
@@ -41,6 +51,10 @@ const (
 	connected
 )
 
+// maintainInterval is how often a pool closes its idle connections that have expired and checks
+// that it holds its minimum number of connections.
+var maintainInterval = 10 * time.Second
+
 // Pool is used to pool Connections to a server.
 type Pool interface {
 	// Get must return a nil *description.Server if the returned connection is
@@ -58,6 +72,9 @@ type Pool interface {
 	// all connections managed by this pool must be closed. Calling Disconnect
 	// multiple times after a single Connect call must result in an error.
 	Disconnect(context.Context) error
+	// Drain marks every connection currently managed by this Pool as stale by bumping the pool's
+	// generation. Stale connections are closed instead of being returned to the idle connections
+	// or checked out.
 	Drain() error
 }
 
@@ -67,10 +84,16 @@ type pool struct {
 	conns      chan *pooledConnection
 	generation uint64
 	sem        *semaphore.Weighted
+	connecting chan struct{} // limits the number of connections being established concurrently
 	connected  int32
 	nextid     uint64
 	capacity   uint64
+	minSize    uint64
 	inflight   map[uint64]*pooledConnection
+	done       chan struct{}
+	monitor    *event.PoolMonitor
+
+	waitQueueTimeout time.Duration
 
 	sync.Mutex
 }
@@ -79,24 +102,59 @@ type pool struct {
 // and will create a max of capacity connections. It will use the provided
 // options.
 func NewPool(addr address.Address, size, capacity uint64, opts ...Option) (Pool, error) {
+	return NewPoolWithMinSize(addr, 0, size, capacity, opts...)
+}
+
+// NewPoolWithMinSize creates a new pool like NewPool that, while connected, also
+// maintains at least minSize connections to the server in the background. The
+// pool holds up to minSize idle connections even if size is smaller.
+func NewPoolWithMinSize(addr address.Address, minSize, size, capacity uint64, opts ...Option) (Pool, error) {
 	if size > capacity {
 		return nil, ErrSizeLargerThanCapacity
 	}
+	if minSize > capacity {
+		return nil, ErrMinSizeLargerThanCapacity
+	}
+	if minSize > size {
+		size = minSize
+	}
+	cfg, err := newConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
 	p := &pool{
 		address:    addr,
 		conns:      make(chan *pooledConnection, size),
 		generation: 0,
 		sem:        semaphore.NewWeighted(int64(capacity)),
+		connecting: make(chan struct{}, cfg.maxConnecting),
 		connected:  disconnected,
 		capacity:   capacity,
+		minSize:    minSize,
 		inflight:   make(map[uint64]*pooledConnection),
 		opts:       opts,
+		monitor:    cfg.poolMonitor,
+
+		waitQueueTimeout: cfg.waitQueueTimeout,
 	}
 	return p, nil
 }
 
+func (p *pool) publishEvent(typ string, id uint64, reason string) {
+	if p.monitor == nil || p.monitor.Event == nil {
+		return
+	}
+	p.monitor.Event(&event.PoolEvent{
+		Type:         typ,
+		Address:      p.address.String(),
+		ConnectionID: id,
+		Reason:       reason,
+	})
+}
+
 func (p *pool) Drain() error {
 	atomic.AddUint64(&p.generation, 1)
+	p.publishEvent(event.PoolCleared, 0, "")
 	return nil
 }
 
@@ -105,6 +163,8 @@ func (p *pool) Connect(ctx context.Context) error {
 		return ErrPoolConnected
 	}
 	atomic.AddUint64(&p.generation, 1)
+	p.done = make(chan struct{})
+	go p.maintain(p.done, maintainInterval)
 	return nil
 }
 
@@ -113,6 +173,11 @@ func (p *pool) Disconnect(ctx context.Context) error {
 		return ErrPoolDisconnected
 	}
 
+	if p.done != nil {
+		close(p.done)
+		p.done = nil
+	}
+
 	// We first clear out the idle connections, then we attempt to acquire the entire capacity
 	// semaphore. If the context is either cancelled, the deadline expires, or there is a timeout
 	// the semaphore acquire method will return an error. If that happens, we will aggressively
@@ -123,7 +188,7 @@ loop:
 		select {
 		case pc := <-p.conns:
 			// This error would be overwritten by the semaphore
-			_ = p.closeConnection(pc)
+			_ = p.closeConnection(pc, event.ReasonPoolClosed)
 		default:
 			break loop
 		}
@@ -152,77 +217,241 @@ loop:
 
 func (p *pool) Get(ctx context.Context) (Connection, *description.Server, error) {
 	if atomic.LoadInt32(&p.connected) != connected {
+		p.publishEvent(event.GetFailed, 0, event.ReasonPoolClosed)
 		return nil, nil, ErrPoolClosed
 	}
 
-	err := p.sem.Acquire(ctx, 1)
+	// waitCtx bounds the time spent waiting for a connection, but not establishing one.
+	waitCtx := ctx
+	if p.waitQueueTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, p.waitQueueTimeout)
+		defer cancel()
+	}
+
+	err := p.sem.Acquire(waitCtx, 1)
 	if err != nil {
-		return nil, nil, err
+		p.publishEvent(event.GetFailed, 0, event.ReasonTimedOut)
+		if ctx.Err() == nil {
+			err = ErrWaitQueueTimeout
+		}
+		return nil, nil, WaitQueueTimeoutError{Wrapped: err}
 	}
 
-	return p.get(ctx)
+	return p.get(ctx, waitCtx)
 }
 
-func (p *pool) get(ctx context.Context) (Connection, *description.Server, error) {
-	g := atomic.LoadUint64(&p.generation)
-	select {
-	case c := <-p.conns:
-		if c.Expired() {
-			go p.closeConnection(c)
-			return p.get(ctx)
-		}
+// waitError returns the error for a checkout that stopped waiting for a connection after acquiring
+// a permit. The error of the checkout's own context is returned as is, while expiry of the wait
+// queue timeout is reported as a WaitQueueTimeoutError wrapping ErrWaitQueueTimeout.
+func waitError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return WaitQueueTimeoutError{Wrapped: ErrWaitQueueTimeout}
+}
 
-		return &acquired{Connection: c, sem: p.sem}, nil, nil
-	case <-ctx.Done():
-		p.sem.Release(1)
-		return nil, nil, ctx.Err()
-	default:
-		c, desc, err := New(ctx, p.address, p.opts...)
-		if err != nil {
+// maintain periodically closes idle connections that have expired and opens connections until the
+// pool holds at least minSize of them. It only uses permits that are not needed by callers of Get.
+func (p *pool) maintain(done <-chan struct{}, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.pruneIdleConnections()
+
+		p.Lock()
+		total := uint64(len(p.inflight))
+		p.Unlock()
+
+		for ; total < p.minSize; total++ {
+			if !p.sem.TryAcquire(1) {
+				break
+			}
+			// Leave the connection slots to checkouts when they're all in use.
+			if !p.tryStartConnecting() {
+				p.sem.Release(1)
+				break
+			}
+			err := p.addIdleConnection()
+			<-p.connecting
 			p.sem.Release(1)
-			return nil, nil, err
+			if err != nil {
+				break
+			}
 		}
 
-		pc := &pooledConnection{
-			Connection: c,
-			p:          p,
-			generation: g,
-			id:         atomic.AddUint64(&p.nextid, 1),
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
 		}
-		p.Lock()
-		if atomic.LoadInt32(&p.connected) != connected {
-			p.Unlock()
-			p.sem.Release(1)
-			p.closeConnection(pc)
-			return nil, nil, ErrPoolClosed
+	}
+}
+
+// tryStartConnecting reserves a slot for establishing a connection without waiting. It returns
+// false if maxConnecting connections are already being established.
+func (p *pool) tryStartConnecting() bool {
+	select {
+	case p.connecting <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// pruneIdleConnections closes the idle connections that have expired.
+func (p *pool) pruneIdleConnections() {
+	for i := len(p.conns); i > 0; i-- {
+		select {
+		case pc := <-p.conns:
+			if pc.Expired() {
+				_ = p.closeConnection(pc, p.expiredReason(pc))
+				continue
+			}
+
+			select {
+			case p.conns <- pc:
+			default:
+				_ = p.closeConnection(pc, event.ReasonPoolFull)
+			}
+		default:
+			return
 		}
-		defer p.Unlock()
-		p.inflight[pc.id] = pc
-		return &acquired{Connection: pc, sem: p.sem}, desc, nil
 	}
 }
 
-func (p *pool) closeConnection(pc *pooledConnection) error {
+// addIdleConnection opens a new connection and adds it to the idle connections.
+func (p *pool) addIdleConnection() error {
+	g := atomic.LoadUint64(&p.generation)
+	c, _, err := New(context.Background(), p.address, p.opts...)
+	if err != nil {
+		return err
+	}
+
+	pc := &pooledConnection{
+		Connection: c,
+		p:          p,
+		generation: g,
+		id:         atomic.AddUint64(&p.nextid, 1),
+	}
+	p.Lock()
+	if atomic.LoadInt32(&p.connected) != connected {
+		p.Unlock()
+		return p.closeConnection(pc, event.ReasonPoolClosed)
+	}
+	p.inflight[pc.id] = pc
+	p.Unlock()
+	p.publishEvent(event.ConnectionCreated, pc.id, "")
+	return p.returnConnection(pc)
+}
+
+func (p *pool) get(ctx, waitCtx context.Context) (Connection, *description.Server, error) {
+	select {
+	case c := <-p.conns:
+		return p.getIdle(ctx, waitCtx, c)
+	case <-waitCtx.Done():
+		p.sem.Release(1)
+		p.publishEvent(event.GetFailed, 0, event.ReasonTimedOut)
+		return nil, nil, waitError(ctx)
+	default:
+	}
+
+	// There is no idle connection, so wait until either one is returned or fewer than
+	// maxConnecting connections are being established.
+	select {
+	case c := <-p.conns:
+		return p.getIdle(ctx, waitCtx, c)
+	case <-waitCtx.Done():
+		p.sem.Release(1)
+		p.publishEvent(event.GetFailed, 0, event.ReasonTimedOut)
+		return nil, nil, waitError(ctx)
+	case p.connecting <- struct{}{}:
+	}
+
+	g := atomic.LoadUint64(&p.generation)
+	c, desc, err := New(ctx, p.address, p.opts...)
+	<-p.connecting
+	if err != nil {
+		p.sem.Release(1)
+		p.publishEvent(event.GetFailed, 0, event.ReasonConnectionError)
+		return nil, nil, err
+	}
+
+	pc := &pooledConnection{
+		Connection: c,
+		p:          p,
+		generation: g,
+		id:         atomic.AddUint64(&p.nextid, 1),
+	}
+	p.Lock()
+	if atomic.LoadInt32(&p.connected) != connected {
+		p.Unlock()
+		p.sem.Release(1)
+		p.closeConnection(pc, event.ReasonPoolClosed)
+		p.publishEvent(event.GetFailed, 0, event.ReasonPoolClosed)
+		return nil, nil, ErrPoolClosed
+	}
+	p.inflight[pc.id] = pc
+	p.Unlock()
+	p.publishEvent(event.ConnectionCreated, pc.id, "")
+	p.publishEvent(event.GetSucceeded, pc.id, "")
+	return &acquired{Connection: pc, sem: p.sem}, desc, nil
+}
+
+// getIdle checks out the idle connection c, or gets another connection if c has expired.
+func (p *pool) getIdle(ctx, waitCtx context.Context, c *pooledConnection) (Connection, *description.Server, error) {
+	if c.Expired() {
+		go p.closeConnection(c, p.expiredReason(c))
+		return p.get(ctx, waitCtx)
+	}
+
+	p.publishEvent(event.GetSucceeded, c.id, "")
+	return &acquired{Connection: c, sem: p.sem}, nil, nil
+}
+
+func (p *pool) closeConnection(pc *pooledConnection, reason string) error {
 	if !atomic.CompareAndSwapInt32(&pc.closed, 0, 1) {
 		return nil
 	}
 	p.Lock()
 	delete(p.inflight, pc.id)
 	p.Unlock()
+	p.publishEvent(event.ConnectionClosed, pc.id, reason)
 	return pc.Connection.Close()
 }
 
 func (p *pool) returnConnection(pc *pooledConnection) error {
-	if atomic.LoadInt32(&p.connected) != connected || pc.Expired() {
-		return p.closeConnection(pc)
+	// The idle time of a pooled connection starts when it is returned to the pool.
+	if c, ok := pc.Connection.(*connection); ok {
+		c.bumpIdleDeadline()
+	}
+
+	if atomic.LoadInt32(&p.connected) != connected {
+		return p.closeConnection(pc, event.ReasonPoolClosed)
+	}
+	if pc.Expired() {
+		return p.closeConnection(pc, p.expiredReason(pc))
 	}
 
 	select {
 	case p.conns <- pc:
+		p.publishEvent(event.ConnectionReturned, pc.id, "")
 		return nil
 	default:
-		return p.closeConnection(pc)
+		return p.closeConnection(pc, event.ReasonPoolFull)
+	}
+}
+
+// expiredReason returns the reason an expired connection is closed.
+func (p *pool) expiredReason(pc *pooledConnection) string {
+	if p.isExpired(pc.generation) {
+		return event.ReasonStale
+	}
+	if c, ok := pc.Connection.(*connection); ok && c.idleExpired() {
+		return event.ReasonIdle
 	}
+	return event.ReasonExpired
 }
 
 func (p *pool) isExpired(generation uint64) bool {
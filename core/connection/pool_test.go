@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/event"
 )
 
 func TestPool(t *testing.T) {
@@ -38,6 +39,19 @@ func TestPool(t *testing.T) {
 				t.Errorf("Expected new pool to be connected. got %v; want %v", p.connected, connected)
 			}
 		})
+		t.Run("minimum size cannot be larger than capacity", func(t *testing.T) {
+			_, err := NewPoolWithMinSize(address.Address(""), 3, 1, 2)
+			if err != ErrMinSizeLargerThanCapacity {
+				t.Errorf("Should receive error when minimum size is larger than capacity. got %v; want %v", err, ErrMinSizeLargerThanCapacity)
+			}
+		})
+		t.Run("holds minimum size idle connections", func(t *testing.T) {
+			P, err := NewPoolWithMinSize(address.Address(""), 2, 1, 2)
+			noerr(t, err)
+			if got := cap(P.(*pool).conns); got != 2 {
+				t.Errorf("Expected the pool to hold the minimum size of idle connections. got %d; want %d", got, 2)
+			}
+		})
 		t.Run("size cannot be larger than capcity", func(t *testing.T) {
 			_, err := NewPool(address.Address(""), 5, 1)
 			if err != ErrSizeLargerThanCapacity {
@@ -301,8 +315,8 @@ func TestPool(t *testing.T) {
 				t.Errorf("Could not acquire the entire semaphore.")
 			}
 			_, _, err = p.Get(ctx)
-			if err != context.DeadlineExceeded {
-				t.Errorf("Should return context error when already canclled. got %v; want %v", err, context.DeadlineExceeded)
+			if err != (WaitQueueTimeoutError{Wrapped: context.DeadlineExceeded}) {
+				t.Errorf("Should return wait queue timeout error. got %v; want %v", err, WaitQueueTimeoutError{Wrapped: context.DeadlineExceeded})
 			}
 			close(cleanup)
 		})
@@ -473,8 +487,8 @@ func TestPool(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
 			defer cancel()
 			_, _, err = p.Get(ctx)
-			if err != context.DeadlineExceeded {
-				t.Errorf("Should not be able to get more than capacity connections. got %v; want %v", err, context.DeadlineExceeded)
+			if _, ok := err.(WaitQueueTimeoutError); !ok {
+				t.Errorf("Should not be able to get more than capacity connections. got %v; want %T", err, WaitQueueTimeoutError{})
 			}
 			err = conns[0].Close()
 			noerr(t, err)
@@ -537,6 +551,237 @@ func TestPool(t *testing.T) {
 			wg.Wait()
 			close(cleanup)
 		})
+		t.Run("blocks until a connection is returned when at max size", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 1, 1, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			conn, _, err := p.Get(context.Background())
+			noerr(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, _, err = p.Get(ctx)
+			wqte, ok := err.(WaitQueueTimeoutError)
+			if !ok {
+				t.Fatalf("Should return a wait queue timeout error. got %v; want %T", err, WaitQueueTimeoutError{})
+			}
+			if wqte.Wrapped != context.DeadlineExceeded {
+				t.Errorf("Should wrap the context error. got %v; want %v", wqte.Wrapped, context.DeadlineExceeded)
+			}
+
+			got := make(chan Connection)
+			go func() {
+				c, _, err := p.Get(context.Background())
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				got <- c
+			}()
+			select {
+			case <-got:
+				t.Fatalf("Should block while the only connection is checked out")
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			id := conn.ID()
+			err = conn.Close()
+			noerr(t, err)
+			select {
+			case c := <-got:
+				if c == nil {
+					t.Fatalf("Should receive the returned connection")
+				}
+				if c.ID() != id {
+					t.Errorf("Should reuse the returned connection. got %s; want %s", c.ID(), id)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("Should receive a connection once the first one is returned")
+			}
+			if d.lenopened() != 1 {
+				t.Errorf("Should have opened 1 connection. got %d; want %d", d.lenopened(), 1)
+			}
+		})
+		t.Run("wait queue timeout expires before the context", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 1, 1,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithWaitQueueTimeout(func(time.Duration) time.Duration { return 50 * time.Millisecond }),
+			)
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			conn, _, err := p.Get(context.Background())
+			noerr(t, err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			start := time.Now()
+			_, _, err = p.Get(ctx)
+			wqte, ok := err.(WaitQueueTimeoutError)
+			if !ok {
+				t.Fatalf("Should return a wait queue timeout error. got %v; want %T", err, WaitQueueTimeoutError{})
+			}
+			if wqte.Wrapped != ErrWaitQueueTimeout {
+				t.Errorf("Should wrap the wait queue timeout error. got %v; want %v", wqte.Wrapped, ErrWaitQueueTimeout)
+			}
+			if ctx.Err() != nil {
+				t.Errorf("Should fail before the context expires. took %v", time.Since(start))
+			}
+		})
+		t.Run("context expires before the wait queue timeout", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 1, 1,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithWaitQueueTimeout(func(time.Duration) time.Duration { return 10 * time.Second }),
+			)
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			conn, _, err := p.Get(context.Background())
+			noerr(t, err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, _, err = p.Get(ctx)
+			if err != (WaitQueueTimeoutError{Wrapped: context.DeadlineExceeded}) {
+				t.Errorf("Should wrap the context error. got %v; want %v", err, WaitQueueTimeoutError{Wrapped: context.DeadlineExceeded})
+			}
+		})
+		t.Run("maintains minimum size", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			P, err := NewPoolWithMinSize(address.Address(addr.String()), 2, 2, 3, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			p := P.(*pool)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			deadline := time.Now().Add(time.Second)
+			for len(p.conns) < 2 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if len(p.conns) != 2 {
+				t.Errorf("Should have opened the minimum number of idle connections. got %d; want %d", len(p.conns), 2)
+			}
+			err = p.Disconnect(context.Background())
+			noerr(t, err)
+			if d.lenclosed() != 2 {
+				t.Errorf("Should have closed 2 connections. got %d; want %d", d.lenclosed(), 2)
+			}
+		})
+		t.Run("closes idle connections on checkout", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			events := make(chan *event.PoolEvent, 10)
+			monitor := &event.PoolMonitor{Event: func(evt *event.PoolEvent) { events <- evt }}
+			p, err := NewPool(address.Address(addr.String()), 1, 1,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithIdleTimeout(func(time.Duration) time.Duration { return 10 * time.Millisecond }),
+				WithPoolMonitor(func(*event.PoolMonitor) *event.PoolMonitor { return monitor }),
+			)
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			c, _, err := p.Get(context.Background())
+			noerr(t, err)
+			id := c.ID()
+			err = c.Close()
+			noerr(t, err)
+
+			time.Sleep(20 * time.Millisecond)
+			c, _, err = p.Get(context.Background())
+			noerr(t, err)
+			if c.ID() == id {
+				t.Errorf("Should not reuse an idle connection. got %s; want a new connection", c.ID())
+			}
+			if d.lenopened() != 2 {
+				t.Errorf("Should have opened 2 connections. got %d; want %d", d.lenopened(), 2)
+			}
+
+			var closed *event.PoolEvent
+			for len(events) > 0 {
+				if evt := <-events; evt.Type == event.ConnectionClosed {
+					closed = evt
+				}
+			}
+			if closed == nil {
+				t.Fatalf("Should have published a %s event", event.ConnectionClosed)
+			}
+			if closed.Reason != event.ReasonIdle {
+				t.Errorf("Unexpected reason for closing the connection. got %s; want %s", closed.Reason, event.ReasonIdle)
+			}
+			if closed.Address != addr.String() {
+				t.Errorf("Unexpected address. got %s; want %s", closed.Address, addr.String())
+			}
+		})
+		t.Run("prunes idle connections in the background", func(t *testing.T) {
+			defer func(interval time.Duration) { maintainInterval = interval }(maintainInterval)
+			maintainInterval = 10 * time.Millisecond
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 1, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			P, err := NewPool(address.Address(addr.String()), 1, 1,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithIdleTimeout(func(time.Duration) time.Duration { return 10 * time.Millisecond }),
+			)
+			noerr(t, err)
+			p := P.(*pool)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			c, _, err := p.Get(context.Background())
+			noerr(t, err)
+			err = c.Close()
+			noerr(t, err)
+
+			deadline := time.Now().Add(time.Second)
+			for d.lenclosed() < 1 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if d.lenclosed() != 1 {
+				t.Errorf("Should have closed the idle connection. got %d; want %d", d.lenclosed(), 1)
+			}
+			if len(p.conns) != 0 {
+				t.Errorf("Should be no connections in pool. got %d; want %d", len(p.conns), 0)
+			}
+			err = p.Disconnect(context.Background())
+			noerr(t, err)
+		})
 		t.Run("Does not leak permit from failure to dial connection", func(t *testing.T) {
 			cleanup := make(chan struct{})
 			addr := bootstrapConnections(t, 0, func(nc net.Conn) {
@@ -624,6 +869,94 @@ func TestPool(t *testing.T) {
 				p.(*pool).sem.Release(int64(p.(*pool).capacity))
 			}
 		})
+		t.Run("limits concurrent connection establishment", func(t *testing.T) {
+			var current, max int32
+			d := DialerFunc(func(context.Context, string, string) (net.Conn, error) {
+				n := atomic.AddInt32(&current, 1)
+				defer atomic.AddInt32(&current, -1)
+				for {
+					m := atomic.LoadInt32(&max)
+					if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				client, server := net.Pipe()
+				_ = server.Close()
+				return client, nil
+			})
+			p, err := NewPool(
+				address.Address("localhost:27017"), 10, 10,
+				WithDialer(func(Dialer) Dialer { return d }),
+				WithMaxConnecting(func(uint64) uint64 { return 2 }),
+			)
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+
+			var wg sync.WaitGroup
+			conns := make(chan Connection, 10)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					c, _, err := p.Get(context.Background())
+					if err != nil {
+						t.Errorf("Unexpected error from Get: %v", err)
+						return
+					}
+					conns <- c
+				}()
+			}
+			wg.Wait()
+			close(conns)
+			for c := range conns {
+				_ = c.Close()
+			}
+
+			if got := atomic.LoadInt32(&max); got > 2 {
+				t.Errorf("Too many connections established concurrently. got %d; want at most %d", got, 2)
+			}
+			if got := atomic.LoadInt32(&max); got < 2 {
+				t.Errorf("Connections should be established concurrently. got %d; want %d", got, 2)
+			}
+		})
+		t.Run("does not return connections from before a drain", func(t *testing.T) {
+			cleanup := make(chan struct{})
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				nc.Close()
+			})
+			d := newdialer(&net.Dialer{})
+			p, err := NewPool(address.Address(addr.String()), 2, 2, WithDialer(func(Dialer) Dialer { return d }))
+			noerr(t, err)
+			err = p.Connect(context.Background())
+			noerr(t, err)
+			c, _, err := p.Get(context.Background())
+			noerr(t, err)
+			old := c.(*acquired).Connection.(*pooledConnection)
+			err = c.Close()
+			noerr(t, err)
+
+			err = p.Drain()
+			noerr(t, err)
+
+			c, _, err = p.Get(context.Background())
+			noerr(t, err)
+			pc := c.(*acquired).Connection.(*pooledConnection)
+			if pc == old {
+				t.Errorf("Get should not return a connection from before the pool was drained")
+			}
+			if pc.generation <= old.generation {
+				t.Errorf("Connection should have a newer generation. got %d; want > %d", pc.generation, old.generation)
+			}
+			if d.lenopened() != 2 {
+				t.Errorf("Should have opened 2 connections, but didn't. got %d; want %d", d.lenopened(), 2)
+			}
+			err = c.Close()
+			noerr(t, err)
+			close(cleanup)
+		})
 	})
 	t.Run("Connection", func(t *testing.T) {
 		t.Run("Connection Close Does Not Error After Pool Is Disconnected", func(t *testing.T) {
@@ -0,0 +1,126 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package connection
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+)
+
+// ServerAPI is the server API version declared on every command sent over a connection. Declaring
+// an API version requires MongoDB 5.0+.
+type ServerAPI struct {
+	Version           string
+	Strict            *bool
+	DeprecationErrors *bool
+}
+
+// fields returns the encoded API version fields.
+func (sa *ServerAPI) fields() []byte {
+	elems := bsoncore.AppendStringElement(nil, "apiVersion", sa.Version)
+	if sa.Strict != nil {
+		elems = bsoncore.AppendBooleanElement(elems, "apiStrict", *sa.Strict)
+	}
+	if sa.DeprecationErrors != nil {
+		elems = bsoncore.AppendBooleanElement(elems, "apiDeprecationErrors", *sa.DeprecationErrors)
+	}
+	return elems
+}
+
+// addFields returns a copy of the command document with the encoded API version fields elems
+// appended. The command is not decoded, only its first key and apiVersion are looked up.
+func addFields(cmd bsoncore.Document, elems []byte) (bson.Raw, error) {
+	// OP_QUERY commands with a read preference wrap the command in $query.
+	if first, err := cmd.IndexErr(0); err == nil && first.Key() == "$query" {
+		if inner, ok := first.Value().DocumentOK(); ok {
+			skip, err := skipFields(inner)
+			if err != nil || skip {
+				return bson.Raw(cmd), err
+			}
+			wrapped := buildDocument(elements(inner), elems)
+			return buildDocument(bsoncore.AppendDocumentElement(nil, "$query", wrapped), cmd[4+len(first):len(cmd)-1]), nil
+		}
+	}
+
+	skip, err := skipFields(cmd)
+	if err != nil || skip {
+		return bson.Raw(cmd), err
+	}
+	return buildDocument(elements(cmd), elems), nil
+}
+
+// skipFields reports whether the API version fields must not be added to cmd, either because it
+// already declares an API version or because it's a getMore command, which must not declare one.
+func skipFields(cmd bsoncore.Document) (bool, error) {
+	first, err := cmd.IndexErr(0)
+	switch {
+	case err == bsoncore.ErrOutOfBounds:
+		return false, nil
+	case err != nil:
+		return false, err
+	case first.Key() == "getMore":
+		return true, nil
+	}
+
+	_, err = cmd.LookupErr("apiVersion")
+	return err == nil, nil
+}
+
+// elements returns the encoded elements of doc.
+func elements(doc bsoncore.Document) []byte {
+	return doc[4 : len(doc)-1]
+}
+
+// buildDocument builds a document from the encoded elements in each of elems.
+func buildDocument(elems ...[]byte) bson.Raw {
+	size := 5
+	for _, e := range elems {
+		size += len(e)
+	}
+
+	idx, dst := bsoncore.ReserveLength(make([]byte, 0, size))
+	for _, e := range elems {
+		dst = append(dst, e...)
+	}
+	dst = append(dst, 0x00)
+	return bsoncore.UpdateLength(dst, idx, int32(len(dst[idx:])))
+}
+
+// apply adds the API version fields to the command in wm as it's written. Wire messages that do not
+// carry a command are returned unchanged.
+func (sa *ServerAPI) apply(wm wiremessage.WireMessage) (wiremessage.WireMessage, error) {
+	elems := sa.fields()
+	switch converted := wm.(type) {
+	case wiremessage.Msg:
+		sections := make([]wiremessage.Section, len(converted.Sections))
+		copy(sections, converted.Sections)
+		for i, section := range sections {
+			body, ok := section.(wiremessage.SectionBody)
+			if !ok {
+				continue
+			}
+			doc, err := addFields(bsoncore.Document(body.Document), elems)
+			if err != nil {
+				return nil, err
+			}
+			body.Document = doc
+			sections[i] = body
+		}
+		converted.Sections = sections
+		return converted, nil
+	case wiremessage.Query:
+		doc, err := addFields(bsoncore.Document(converted.Query), elems)
+		if err != nil {
+			return nil, err
+		}
+		converted.Query = doc
+		return converted, nil
+	}
+
+	return wm, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package connection
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerAPIApply(t *testing.T) {
+	strict := true
+	sa := &ServerAPI{Version: "1", Strict: &strict}
+	want := bsonx.Doc{
+		{"find", bsonx.String("coll")},
+		{"$db", bsonx.String("db")},
+		{"apiVersion", bsonx.String("1")},
+		{"apiStrict", bsonx.Boolean(true)},
+	}
+
+	marshal := func(t *testing.T, doc bsonx.Doc) bson.Raw {
+		rdr, err := doc.MarshalBSON()
+		require.NoError(t, err)
+		return rdr
+	}
+	cmd := bsonx.Doc{{"find", bsonx.String("coll")}, {"$db", bsonx.String("db")}}
+
+	t.Run("OP_MSG", func(t *testing.T) {
+		wm, err := sa.apply(wiremessage.Msg{
+			Sections: []wiremessage.Section{
+				wiremessage.SectionBody{PayloadType: wiremessage.SingleDocument, Document: marshal(t, cmd)},
+			},
+		})
+		require.NoError(t, err)
+
+		got, err := bsonx.ReadDoc(wm.(wiremessage.Msg).Sections[0].(wiremessage.SectionBody).Document)
+		require.NoError(t, err)
+		require.True(t, want.Equal(got), "expected %v, got %v", want, got)
+	})
+	t.Run("OP_QUERY with $query", func(t *testing.T) {
+		wm, err := sa.apply(wiremessage.Query{
+			Query: marshal(t, bsonx.Doc{
+				{"$query", bsonx.Document(cmd)},
+				{"$readPreference", bsonx.Document(bsonx.Doc{{"mode", bsonx.String("secondary")}})},
+			}),
+		})
+		require.NoError(t, err)
+
+		got, err := bsonx.ReadDoc(wm.(wiremessage.Query).Query)
+		require.NoError(t, err)
+		require.True(t, want.Equal(got[0].Value.Document()), "expected %v, got %v", want, got)
+		require.Equal(t, "$readPreference", got[1].Key)
+	})
+	t.Run("already declared", func(t *testing.T) {
+		declared := append(cmd.Copy(), bsonx.Elem{"apiVersion", bsonx.String("2")})
+		wm, err := sa.apply(wiremessage.Query{Query: marshal(t, declared)})
+		require.NoError(t, err)
+
+		got, err := bsonx.ReadDoc(wm.(wiremessage.Query).Query)
+		require.NoError(t, err)
+		require.True(t, declared.Equal(got), "expected %v, got %v", declared, got)
+	})
+	t.Run("getMore", func(t *testing.T) {
+		getMore := bsonx.Doc{{"getMore", bsonx.Int64(1)}, {"collection", bsonx.String("coll")}, {"$db", bsonx.String("db")}}
+		wm, err := sa.apply(wiremessage.Msg{
+			Sections: []wiremessage.Section{
+				wiremessage.SectionBody{PayloadType: wiremessage.SingleDocument, Document: marshal(t, getMore)},
+			},
+		})
+		require.NoError(t, err)
+
+		got, err := bsonx.ReadDoc(wm.(wiremessage.Msg).Sections[0].(wiremessage.SectionBody).Document)
+		require.NoError(t, err)
+		require.True(t, getMore.Equal(got), "expected %v, got %v", getMore, got)
+	})
+	t.Run("empty document", func(t *testing.T) {
+		wm, err := sa.apply(wiremessage.Query{Query: marshal(t, bsonx.Doc{})})
+		require.NoError(t, err)
+
+		got, err := bsonx.ReadDoc(wm.(wiremessage.Query).Query)
+		require.NoError(t, err)
+		require.True(t, want[2:].Equal(got), "expected %v, got %v", want[2:], got)
+	})
+	t.Run("invalid document", func(t *testing.T) {
+		_, err := sa.apply(wiremessage.Query{Query: bson.Raw{0x0A, 0x00}})
+		require.Error(t, err)
+	})
+}
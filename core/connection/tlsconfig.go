@@ -46,20 +46,15 @@ func (c *TLSConfig) SetInsecure(allow bool) {
 	c.InsecureSkipVerify = allow
 }
 
-// AddCACertFromFile adds a root CA certificate to the configuration given a path
-// to the containing file.
+// AddCACertFromFile adds the root CA certificates to the configuration given a path
+// to the containing file. The file may contain a bundle of several certificates.
 func (c *TLSConfig) AddCACertFromFile(file string) error {
 	data, err := ioutil.ReadFile(file)
 	if err != nil {
 		return err
 	}
 
-	certBytes, err := loadCert(data)
-	if err != nil {
-		return err
-	}
-
-	cert, err := x509.ParseCertificate(certBytes)
+	certs, err := loadCerts(data)
 	if err != nil {
 		return err
 	}
@@ -68,7 +63,14 @@ func (c *TLSConfig) AddCACertFromFile(file string) error {
 		c.RootCAs = x509.NewCertPool()
 	}
 
-	c.RootCAs.AddCert(cert)
+	for _, certBytes := range certs {
+		cert, err := x509.ParseCertificate(certBytes)
+		if err != nil {
+			return err
+		}
+
+		c.RootCAs.AddCert(cert)
+	}
 
 	return nil
 }
@@ -137,32 +139,27 @@ func (c *TLSConfig) AddClientCertFromFile(clientFile string) (string, error) {
 	return x509CertSubject(crt), nil
 }
 
-func loadCert(data []byte) ([]byte, error) {
-	var certBlock *pem.Block
-
-	for certBlock == nil {
-		if data == nil || len(data) == 0 {
-			return nil, errors.New(".pem file must have both a CERTIFICATE and an RSA PRIVATE KEY section")
-		}
+func loadCerts(data []byte) ([][]byte, error) {
+	var certs [][]byte
 
+	for len(data) > 0 {
 		block, rest := pem.Decode(data)
 		if block == nil {
-			return nil, errors.New("invalid .pem file")
+			break
 		}
 
-		switch block.Type {
-		case "CERTIFICATE":
-			if certBlock != nil {
-				return nil, errors.New("multiple CERTIFICATE sections in .pem file")
-			}
-
-			certBlock = block
+		if block.Type == "CERTIFICATE" {
+			certs = append(certs, block.Bytes)
 		}
 
 		data = rest
 	}
 
-	return certBlock.Bytes, nil
+	if len(certs) == 0 {
+		return nil, errors.New(".pem file must have a CERTIFICATE section")
+	}
+
+	return certs, nil
 }
 
 // Because the functionality to convert a pkix.Name to a string wasn't added until Go 1.10, we
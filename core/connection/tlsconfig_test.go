@@ -0,0 +1,208 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package connection
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/core/address"
+)
+
+// newTestCertificates creates a CA certificate and a server certificate for localhost signed by it.
+func newTestCertificates(t *testing.T) (caPEM []byte, serverCert tls.Certificate) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Could not create CA certificate: %v", err)
+	}
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Could not generate server key: %v", err)
+	}
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caTemplate, &serverKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("Could not create server certificate: %v", err)
+	}
+
+	caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	serverCert = tls.Certificate{Certificate: [][]byte{serverDER}, PrivateKey: serverKey}
+	return caPEM, serverCert
+}
+
+// bootstrapTLSConnection starts a TLS listener for a single connection and returns its address and
+// a channel that receives the result of the server side of the handshake.
+func bootstrapTLSConnection(t *testing.T, cert tls.Certificate) (net.Addr, <-chan error) {
+	l, err := tls.Listen("tcp", "localhost:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		t.Fatalf("Could not set up a listener: %v", err)
+	}
+	errs := make(chan error, 1)
+	go func() {
+		defer l.Close()
+		c, err := l.Accept()
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer c.Close()
+		errs <- c.(*tls.Conn).Handshake()
+	}()
+	return l.Addr(), errs
+}
+
+func TestTLSConfig(t *testing.T) {
+	caPEM, serverCert := newTestCertificates(t)
+
+	caFile, err := ioutil.TempFile("", "ca.pem")
+	if err != nil {
+		t.Fatalf("Could not create CA file: %v", err)
+	}
+	defer os.Remove(caFile.Name())
+	if _, err = caFile.Write(caPEM); err != nil {
+		t.Fatalf("Could not write CA file: %v", err)
+	}
+	_ = caFile.Close()
+
+	t.Run("handshake succeeds with custom CA", func(t *testing.T) {
+		tlsConfig := NewTLSConfig()
+		if err := tlsConfig.AddCACertFromFile(caFile.Name()); err != nil {
+			t.Fatalf("Could not add CA certificate: %v", err)
+		}
+
+		addr, errs := bootstrapTLSConnection(t, serverCert)
+		conn, _, err := New(context.Background(), address.Address(addr.String()),
+			WithTLSConfig(func(*TLSConfig) *TLSConfig { return tlsConfig }),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer conn.Close()
+
+		if err = <-errs; err != nil {
+			t.Errorf("Unexpected server handshake error: %v", err)
+		}
+	})
+	t.Run("handshake fails without custom CA", func(t *testing.T) {
+		addr, _ := bootstrapTLSConnection(t, serverCert)
+		_, _, err := New(context.Background(), address.Address(addr.String()),
+			WithTLSConfig(func(*TLSConfig) *TLSConfig { return NewTLSConfig() }),
+		)
+		if err == nil {
+			t.Errorf("Expected certificate verification to fail")
+		}
+	})
+	t.Run("insecure skips verification", func(t *testing.T) {
+		tlsConfig := NewTLSConfig()
+		tlsConfig.SetInsecure(true)
+
+		addr, errs := bootstrapTLSConnection(t, serverCert)
+		conn, _, err := New(context.Background(), address.Address(addr.String()),
+			WithTLSConfig(func(*TLSConfig) *TLSConfig { return tlsConfig }),
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer conn.Close()
+
+		if err = <-errs; err != nil {
+			t.Errorf("Unexpected server handshake error: %v", err)
+		}
+	})
+	t.Run("client certificate subject", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("Could not generate client key: %v", err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(3),
+			Subject: pkix.Name{
+				CommonName:         "client",
+				OrganizationalUnit: []string{"drivers"},
+				Organization:       []string{"MongoDB"},
+				Locality:           []string{"New York City"},
+				Province:           []string{"New York"},
+				Country:            []string{"US"},
+			},
+			NotBefore: time.Now().Add(-time.Hour),
+			NotAfter:  time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatalf("Could not create client certificate: %v", err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatalf("Could not marshal client key: %v", err)
+		}
+
+		clientFile, err := ioutil.TempFile("", "client.pem")
+		if err != nil {
+			t.Fatalf("Could not create client certificate file: %v", err)
+		}
+		defer os.Remove(clientFile.Name())
+		_ = pem.Encode(clientFile, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+		_ = pem.Encode(clientFile, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+		_ = clientFile.Close()
+
+		subject, err := NewTLSConfig().AddClientCertFromFile(clientFile.Name())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		want := "CN=client,OU=drivers,O=MongoDB,L=New York City,ST=New York,C=US"
+		if subject != want {
+			t.Errorf("Unexpected subject. got %s; want %s", subject, want)
+		}
+	})
+	t.Run("CA bundle", func(t *testing.T) {
+		otherCA, _ := newTestCertificates(t)
+		certs, err := loadCerts(append(otherCA, caPEM...))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(certs) != 2 {
+			t.Errorf("Expected both certificates to be loaded. got %d; want %d", len(certs), 2)
+		}
+	})
+}
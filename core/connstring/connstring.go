@@ -9,6 +9,7 @@ package connstring
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/url"
 	"runtime"
@@ -44,6 +45,8 @@ type ConnString struct {
 	ConnectTimeout                     time.Duration
 	ConnectTimeoutSet                  bool
 	Database                           string
+	DirectConnection                   bool
+	DirectConnectionSet                bool
 	HeartbeatInterval                  time.Duration
 	HeartbeatIntervalSet               bool
 	Hosts                              []string
@@ -58,11 +61,15 @@ type ConnString struct {
 	MaxConnsPerHostSet                 bool
 	MaxIdleConnsPerHost                uint16
 	MaxIdleConnsPerHostSet             bool
+	MinPoolSize                        uint16
+	MinPoolSizeSet                     bool
 	Password                           string
 	PasswordSet                        bool
 	ReadConcernLevel                   string
 	ReadPreference                     string
 	ReadPreferenceTagSets              []map[string]string
+	RetryReads                         bool
+	RetryReadsSet                      bool
 	RetryWrites                        bool
 	RetryWritesSet                     bool
 	MaxStaleness                       time.Duration
@@ -110,6 +117,13 @@ const (
 	SingleConnect
 )
 
+// lookupSRV and lookupTXT resolve the DNS records for mongodb+srv connection strings. They are
+// variables so that tests can supply synthetic records.
+var (
+	lookupSRV = net.LookupSRV
+	lookupTXT = net.LookupTXT
+)
+
 type parser struct {
 	ConnString
 }
@@ -195,7 +209,7 @@ func (p *parser) parse(original string) error {
 
 		// error ignored because finding a TXT record should not be
 		// considered an error.
-		recordsFromTXT, _ := net.LookupTXT(hosts)
+		recordsFromTXT, _ := lookupTXT(hosts)
 
 		// This is a temporary fix to get around bug https://github.com/golang/go/issues/21472.
 		// It will currently incorrectly concatenate multiple TXT records to one
@@ -252,6 +266,17 @@ func (p *parser) parse(original string) error {
 		}
 	}
 
+	if p.DirectConnection {
+		switch {
+		case isSRV:
+			return fmt.Errorf("directConnection cannot be used with SRV URIs")
+		case len(p.Hosts) > 1:
+			return fmt.Errorf("directConnection cannot be used with multiple hosts")
+		case p.ReplicaSet != "":
+			return fmt.Errorf("directConnection cannot be used with replicaSet")
+		}
+	}
+
 	err = p.setDefaultAuthParams(extractedDatabase.db)
 	if err != nil {
 		return err
@@ -262,6 +287,11 @@ func (p *parser) parse(original string) error {
 		return err
 	}
 
+	err = p.validatePoolSize()
+	if err != nil {
+		return err
+	}
+
 	// Check for invalid write concern (i.e. w=0 and j=true)
 	if p.WNumberSet && p.WNumber == 0 && p.JSet && p.J {
 		return writeconcern.ErrInconsistent
@@ -323,6 +353,15 @@ func (p *parser) setDefaultAuthParams(dbName string) error {
 	return nil
 }
 
+// validatePoolSize returns an error if the minimum pool size is larger than the maximum pool size.
+// A maximum pool size of 0 means the pool is unbounded.
+func (p *parser) validatePoolSize() error {
+	if p.MinPoolSizeSet && p.MaxConnsPerHostSet && p.MaxConnsPerHost != 0 && p.MinPoolSize > p.MaxConnsPerHost {
+		return fmt.Errorf("minPoolSize %d is larger than maxPoolSize %d", p.MinPoolSize, p.MaxConnsPerHost)
+	}
+	return nil
+}
+
 func (p *parser) validateAuth() error {
 	switch strings.ToLower(p.AuthMechanism) {
 	case "mongodb-cr":
@@ -399,7 +438,7 @@ func fetchSeedlistFromSRV(host string) ([]string, error) {
 		return nil, fmt.Errorf("URI with srv must not include a port number")
 	}
 
-	_, addresses, err := net.LookupSRV("mongodb", "tcp", host)
+	_, addresses, err := lookupSRV("mongodb", "tcp", host)
 	if err != nil {
 		return nil, err
 	}
@@ -450,7 +489,7 @@ func (p *parser) addHost(host string) error {
 func (p *parser) addOption(pair string) error {
 	kv := strings.SplitN(pair, "=", 2)
 	if len(kv) != 2 || kv[0] == "" {
-		return fmt.Errorf("invalid option")
+		return fmt.Errorf("invalid option \"%s\"", pair)
 	}
 
 	key, err := url.QueryUnescape(kv[0])
@@ -475,7 +514,7 @@ func (p *parser) addOption(pair string) error {
 		for _, pair := range pairs {
 			kv := strings.SplitN(pair, ":", 2)
 			if len(kv) != 2 || kv[0] == "" {
-				return fmt.Errorf("invalid authMechanism property")
+				return fmt.Errorf("invalid value for %s: %s", key, value)
 			}
 			p.AuthMechanismProperties[kv[0]] = kv[1]
 		}
@@ -497,6 +536,16 @@ func (p *parser) addOption(pair string) error {
 		}
 
 		p.ConnectSet = true
+	case "directconnection":
+		switch strings.ToLower(value) {
+		case "true":
+			p.DirectConnection = true
+		case "false":
+		default:
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+
+		p.DirectConnectionSet = true
 	case "connecttimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
@@ -565,6 +614,13 @@ func (p *parser) addOption(pair string) error {
 		p.MaxConnsPerHostSet = true
 		p.MaxIdleConnsPerHost = uint16(n)
 		p.MaxIdleConnsPerHostSet = true
+	case "minpoolsize":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > math.MaxUint16 {
+			return fmt.Errorf("invalid value for %s: %s", key, value)
+		}
+		p.MinPoolSize = uint16(n)
+		p.MinPoolSizeSet = true
 	case "readconcernlevel":
 		p.ReadConcernLevel = value
 	case "readpreference":
@@ -589,6 +645,9 @@ func (p *parser) addOption(pair string) error {
 		p.MaxStalenessSet = true
 	case "replicaset":
 		p.ReplicaSet = value
+	case "retryreads":
+		p.RetryReads = value == "true"
+		p.RetryReadsSet = true
 	case "retrywrites":
 		p.RetryWrites = value == "true"
 		p.RetryWritesSet = true
@@ -598,13 +657,15 @@ func (p *parser) addOption(pair string) error {
 			return fmt.Errorf("invalid value for %s: %s", key, value)
 		}
 		p.ServerSelectionTimeout = time.Duration(n) * time.Millisecond
+		p.ServerSelectionTimeoutSet = true
 	case "sockettimeoutms":
 		n, err := strconv.Atoi(value)
 		if err != nil || n < 0 {
 			return fmt.Errorf("invalid value for %s: %s", key, value)
 		}
 		p.SocketTimeout = time.Duration(n) * time.Millisecond
-	case "ssl":
+		p.SocketTimeoutSet = true
+	case "ssl", "tls":
 		switch value {
 		case "true":
 			p.SSL = true
@@ -615,15 +676,15 @@ func (p *parser) addOption(pair string) error {
 		}
 
 		p.SSLSet = true
-	case "sslclientcertificatekeyfile":
+	case "sslclientcertificatekeyfile", "tlscertificatekeyfile":
 		p.SSL = true
 		p.SSLSet = true
 		p.SSLClientCertificateKeyFile = value
 		p.SSLClientCertificateKeyFileSet = true
-	case "sslclientcertificatekeypassword":
+	case "sslclientcertificatekeypassword", "tlscertificatekeyfilepassword":
 		p.SSLClientCertificateKeyPassword = func() string { return value }
 		p.SSLClientCertificateKeyPasswordSet = true
-	case "sslinsecure":
+	case "sslinsecure", "tlsinsecure":
 		switch value {
 		case "true":
 			p.SSLInsecure = true
@@ -634,7 +695,7 @@ func (p *parser) addOption(pair string) error {
 		}
 
 		p.SSLInsecureSet = true
-	case "sslcertificateauthorityfile":
+	case "sslcertificateauthorityfile", "tlscafile":
 		p.SSL = true
 		p.SSLSet = true
 		p.SSLCaFile = value
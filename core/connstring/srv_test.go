@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package connstring
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubResolver replaces the DNS lookups used for mongodb+srv connection strings with synthetic records
+// and returns a function that restores them.
+func stubResolver(t *testing.T, srv []*net.SRV, txt []string) func() {
+	oldSRV, oldTXT := lookupSRV, lookupTXT
+	lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		require.Equal(t, "mongodb", service)
+		require.Equal(t, "tcp", proto)
+		if srv == nil {
+			return "", nil, errors.New("no such host")
+		}
+		return "_mongodb._tcp." + name, srv, nil
+	}
+	lookupTXT = func(name string) ([]string, error) {
+		if txt == nil {
+			return nil, errors.New("no such host")
+		}
+		return txt, nil
+	}
+	return func() { lookupSRV, lookupTXT = oldSRV, oldTXT }
+}
+
+func TestSRVResolution(t *testing.T) {
+	tests := []struct {
+		name       string
+		uri        string
+		srv        []*net.SRV
+		txt        []string
+		hosts      []string
+		replicaSet string
+		authSource string
+		err        bool
+	}{
+		{
+			name: "seed list and options",
+			uri:  "mongodb+srv://cluster.example.com/",
+			srv: []*net.SRV{
+				{Target: "node1.example.com.", Port: 27017},
+				{Target: "node2.example.com.", Port: 27018},
+			},
+			txt:        []string{"replicaSet=rs0&authSource=thirdparty"},
+			hosts:      []string{"node1.example.com:27017", "node2.example.com:27018"},
+			replicaSet: "rs0",
+			authSource: "thirdparty",
+		},
+		{
+			name:       "no TXT record",
+			uri:        "mongodb+srv://cluster.example.com/",
+			srv:        []*net.SRV{{Target: "node1.example.com.", Port: 27017}},
+			hosts:      []string{"node1.example.com:27017"},
+			authSource: "admin",
+		},
+		{
+			name:       "URI options override TXT options",
+			uri:        "mongodb+srv://cluster.example.com/?replicaSet=rs1",
+			srv:        []*net.SRV{{Target: "node1.example.com.", Port: 27017}},
+			txt:        []string{"replicaSet=rs0"},
+			hosts:      []string{"node1.example.com:27017"},
+			replicaSet: "rs1",
+			authSource: "admin",
+		},
+		{
+			name: "host outside the parent domain",
+			uri:  "mongodb+srv://cluster.example.com/",
+			srv:  []*net.SRV{{Target: "node1.evil.com.", Port: 27017}},
+			err:  true,
+		},
+		{
+			name: "multiple TXT records",
+			uri:  "mongodb+srv://cluster.example.com/",
+			srv:  []*net.SRV{{Target: "node1.example.com.", Port: 27017}},
+			txt:  []string{"replicaSet=rs0", "authSource=admin"},
+			err:  true,
+		},
+		{
+			name: "disallowed TXT option",
+			uri:  "mongodb+srv://cluster.example.com/",
+			srv:  []*net.SRV{{Target: "node1.example.com.", Port: 27017}},
+			txt:  []string{"ssl=false"},
+			err:  true,
+		},
+		{
+			name: "SRV lookup failure",
+			uri:  "mongodb+srv://cluster.example.com/",
+			err:  true,
+		},
+		{
+			name: "port in SRV host",
+			uri:  "mongodb+srv://cluster.example.com:27017/",
+			srv:  []*net.SRV{{Target: "node1.example.com.", Port: 27017}},
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restore := stubResolver(t, test.srv, test.txt)
+			defer restore()
+
+			cs, err := Parse(test.uri)
+			if test.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.hosts, cs.Hosts)
+			require.Equal(t, test.replicaSet, cs.ReplicaSet)
+			require.Equal(t, test.authSource, cs.AuthSource)
+			require.True(t, cs.SSL)
+		})
+	}
+}
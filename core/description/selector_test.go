@@ -169,6 +169,39 @@ func TestServerSelection(t *testing.T) {
 			})
 		}
 	})
+	t.Run("FastestSelector", func(t *testing.T) {
+		fast := Server{Addr: address.Address("localhost:27017"), AverageRTT: 5 * time.Millisecond, AverageRTTSet: true}
+		medium := Server{Addr: address.Address("localhost:27018"), AverageRTT: 10 * time.Millisecond, AverageRTTSet: true}
+		slow := Server{Addr: address.Address("localhost:27019"), AverageRTT: 50 * time.Millisecond, AverageRTTSet: true}
+		unknown := Server{Addr: address.Address("localhost:27020")}
+
+		testCases := []struct {
+			name       string
+			n          int
+			candidates []Server
+			expected   []Server
+		}{
+			{"sorted and truncated", 2, []Server{slow, unknown, fast, medium}, []Server{fast, medium}},
+			{"fewer candidates than n", 3, []Server{medium, fast}, []Server{fast, medium}},
+			{"no RTT is slowest", 3, []Server{unknown, slow, fast}, []Server{fast, slow, unknown}},
+			{"no candidates", 2, []Server{}, []Server{}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				candidates := make([]Server, len(tc.candidates))
+				copy(candidates, tc.candidates)
+				result, err := FastestSelector(tc.n).SelectServer(Topology{Servers: tc.candidates}, candidates)
+				noerr(t, err)
+				if diff := cmp.Diff(result, tc.expected); diff != "" {
+					t.Errorf("Incorrect servers selected (-got +want):\n%s", diff)
+				}
+				if diff := cmp.Diff(candidates, tc.candidates); diff != "" {
+					t.Errorf("Candidates were modified (-got +want):\n%s", diff)
+				}
+			})
+		}
+	})
 }
 
 var readPrefTestPrimary = Server{
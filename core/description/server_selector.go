@@ -9,6 +9,7 @@ package description
 import (
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/tag"
@@ -95,6 +96,27 @@ func (ls *latencySelector) SelectServer(t Topology, candidates []Server) ([]Serv
 	}
 }
 
+// FastestSelector creates a ServerSelector which selects at most n of the servers with the lowest
+// average round trip time, ordered from fastest to slowest. Servers without a round trip time are
+// considered slower than all others.
+func FastestSelector(n int) ServerSelector {
+	return ServerSelectorFunc(func(t Topology, candidates []Server) ([]Server, error) {
+		result := make([]Server, len(candidates))
+		copy(result, candidates)
+		sort.SliceStable(result, func(i, j int) bool {
+			if result[i].AverageRTTSet != result[j].AverageRTTSet {
+				return result[i].AverageRTTSet
+			}
+			return result[i].AverageRTT < result[j].AverageRTT
+		})
+
+		if n >= 0 && len(result) > n {
+			result = result[:n]
+		}
+		return result, nil
+	})
+}
+
 // WriteSelector selects all the writable servers.
 func WriteSelector() ServerSelector {
 	return ServerSelectorFunc(func(t Topology, candidates []Server) ([]Server, error) {
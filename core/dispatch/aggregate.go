@@ -32,33 +32,18 @@ func Aggregate(
 	clientID uuid.UUID,
 	pool *session.Pool,
 	registry *bsoncodec.Registry,
+	retryRead bool,
 	opts ...*options.AggregateOptions,
 ) (command.Cursor, error) {
 
 	dollarOut := cmd.HasDollarOut()
 
-	var ss *topology.SelectedServer
-	var err error
-	switch dollarOut {
-	case true:
-		ss, err = topo.SelectServer(ctx, writeSelector)
-		if err != nil {
-			return nil, err
-		}
-	case false:
-		ss, err = topo.SelectServer(ctx, readSelector)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	desc := ss.Description()
-	conn, err := ss.Connection(ctx)
+	ss, err := topo.SelectServer(ctx, aggregateSelector(&cmd, readSelector, writeSelector))
 	if err != nil {
 		return nil, err
 	}
 
-	defer conn.Close()
+	desc := ss.Description()
 
 	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
 	if err != nil {
@@ -74,8 +59,61 @@ func Aggregate(
 		}
 	}
 
+	if err := applyAggregateOptions(&cmd, desc, registry, opts...); err != nil {
+		return nil, err
+	}
+
+	// aggregations that write their results with $out are not retryable reads
+	retry := retryRead && !dollarOut && retryReadSupported(topo, desc, cmd.Session)
+	res, err := retryableRead(ctx, ss, retry, reselectForRetryRead(topo, readSelector, cmd.Session),
+		func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+			conn, err := ss.Connection(ctx)
+			if err != nil {
+				return nil, err
+			}
+			defer conn.Close()
+
+			return cmd.RoundTrip(ctx, ss.Description(), ss, conn)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return res.(command.Cursor), nil
+}
+
+// aggregateSelector returns the selector for the server that runs cmd. An aggregation that writes its
+// results with $out needs a writable server. Any other aggregation is a read and is routed by the read
+// preference. This includes Atlas Search pipelines, since every data-bearing member can serve a search,
+// even though they are run without a read concern.
+func aggregateSelector(cmd *command.Aggregate, readSelector, writeSelector description.ServerSelector) description.ServerSelector {
+	if cmd.HasDollarOut() {
+		return writeSelector
+	}
+	return readSelector
+}
+
+// applyAggregateOptions adds the aggregate options to cmd for the selected server. Explained
+// aggregations are built with it as well so that the explained command matches the command that would
+// actually be run.
+func applyAggregateOptions(
+	cmd *command.Aggregate,
+	desc description.SelectedServer,
+	registry *bsoncodec.Registry,
+	opts ...*options.AggregateOptions,
+) error {
+
 	aggOpts := options.MergeAggregateOptions(opts...)
 
+	// Atlas Search pipelines are not run with a read concern.
+	search := cmd.HasSearchStage()
+	if aggOpts.Search != nil {
+		search = *aggOpts.Search
+	}
+	if search {
+		cmd.ReadConcern = nil
+	}
+
 	if aggOpts.AllowDiskUse != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"allowDiskUse", bsonx.Boolean(*aggOpts.AllowDiskUse)})
 	}
@@ -89,7 +127,7 @@ func Aggregate(
 	}
 	if aggOpts.Collation != nil {
 		if desc.WireVersion.Max < 5 {
-			return nil, ErrCollation
+			return ErrCollation
 		}
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(aggOpts.Collation.ToDocument())})
 	}
@@ -103,16 +141,32 @@ func Aggregate(
 		})
 	}
 	if aggOpts.Comment != nil {
-		cmd.Opts = append(cmd.Opts, bsonx.Elem{"comment", bsonx.String(*aggOpts.Comment)})
+		commentElem, err := interfaceToValueElement("comment", aggOpts.Comment, registry)
+		if err != nil {
+			return err
+		}
+
+		cmd.Opts = append(cmd.Opts, commentElem)
 	}
 	if aggOpts.Hint != nil {
 		hintElem, err := interfaceToElement("hint", aggOpts.Hint, registry)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		cmd.Opts = append(cmd.Opts, hintElem)
 	}
+	if aggOpts.Let != nil {
+		if desc.WireVersion.Max < 13 {
+			return ErrLet
+		}
+		let, err := interfaceToDocument(aggOpts.Let, registry)
+		if err != nil {
+			return err
+		}
+
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"let", bsonx.Document(let)})
+	}
 
-	return cmd.RoundTrip(ctx, desc, ss, conn)
+	return nil
 }
@@ -58,6 +58,10 @@ func BulkWrite(
 	registry *bsoncodec.Registry,
 	opts ...*options.BulkWriteOptions,
 ) (result.BulkWrite, error) {
+	if !writeConcern.IsValid() {
+		return result.BulkWrite{}, writeconcern.ErrInconsistent
+	}
+
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
 		return result.BulkWrite{}, err
@@ -179,9 +183,13 @@ func runBatch(
 
 	batchErr.WriteErrors = make([]BulkWriteError, 0, len(writeErrors))
 	for _, we := range writeErrors {
+		model := batch.models[0]
+		if we.Index >= 0 && we.Index < len(batch.models) {
+			model = batch.models[we.Index]
+		}
 		batchErr.WriteErrors = append(batchErr.WriteErrors, BulkWriteError{
 			WriteError: we,
-			Model:      batch.models[0],
+			Model:      model,
 		})
 	}
 
@@ -9,6 +9,7 @@ package dispatch
 import (
 	"testing"
 
+	"github.com/mongodb/mongo-go-driver/core/result"
 	"github.com/stretchr/testify/require"
 )
 
@@ -63,4 +64,27 @@ func TestBulkWrite(t *testing.T) {
 			})
 		}
 	})
+	t.Run("TestMergeResults", func(t *testing.T) {
+		batches := []result.BulkWrite{
+			{InsertedCount: 2, UpsertedIDs: map[int64]interface{}{}},
+			{MatchedCount: 2, ModifiedCount: 1, UpsertedCount: 1, UpsertedIDs: map[int64]interface{}{1: "a"}},
+			{DeletedCount: 2, UpsertedIDs: map[int64]interface{}{}},
+		}
+
+		agg := result.BulkWrite{UpsertedIDs: make(map[int64]interface{})}
+		var opIndex int64
+		for _, batch := range batches {
+			mergeResults(&agg, batch, opIndex)
+			opIndex += 2
+		}
+
+		require.Equal(t, result.BulkWrite{
+			InsertedCount: 2,
+			MatchedCount:  2,
+			ModifiedCount: 1,
+			DeletedCount:  2,
+			UpsertedCount: 1,
+			UpsertedIDs:   map[int64]interface{}{3: "a"},
+		}, agg)
+	})
 }
@@ -31,26 +31,68 @@ func Count(
 	clientID uuid.UUID,
 	pool *session.Pool,
 	registry *bsoncodec.Registry,
+	retryRead bool,
 	opts ...*options.CountOptions,
 ) (int64, error) {
 
+	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
+	if err != nil {
+		return 0, err
+	}
+	cmd.ReadPref = rp
+
+	countOpts := options.MergeCountOptions(opts...)
+	if err := applyCountOptions(&cmd, registry, countOpts); err != nil {
+		return 0, err
+	}
+
+	servers, err := hedgedServers(ctx, topo, selector, cmd.ReadPref, cmd.Session)
+	if err != nil {
+		return 0, err
+	}
+	if servers != nil {
+		res, err := hedge(ctx, servers, func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+			return countOnServer(ctx, cmd, topo, ss, clientID, pool, countOpts)
+		})
+		if err != nil {
+			return 0, err
+		}
+		return res.(int64), nil
+	}
+
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
 		return 0, err
 	}
 
-	desc := ss.Description()
-	conn, err := ss.Connection(ctx)
+	retry := retryRead && retryReadSupported(topo, ss.Description(), cmd.Session)
+	res, err := retryableRead(ctx, ss, retry, reselectForRetryRead(topo, selector, cmd.Session),
+		func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+			return countOnServer(ctx, cmd, topo, ss, clientID, pool, countOpts)
+		},
+	)
 	if err != nil {
 		return 0, err
 	}
-	defer conn.Close()
+	return res.(int64), nil
+}
 
-	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
+func countOnServer(
+	ctx context.Context,
+	cmd command.Count,
+	topo *topology.Topology,
+	ss *topology.SelectedServer,
+	clientID uuid.UUID,
+	pool *session.Pool,
+	countOpts *options.CountOptions,
+) (int64, error) {
+
+	desc := ss.Description()
+	conn, err := ss.Connection(ctx)
 	if err != nil {
 		return 0, err
 	}
-	cmd.ReadPref = rp
+	defer conn.Close()
 
 	// If no explicit session and deployment supports sessions, start implicit session.
 	if cmd.Session == nil && topo.SupportsSessions() {
@@ -61,7 +103,17 @@ func Count(
 		defer cmd.Session.EndSession()
 	}
 
-	countOpts := options.MergeCountOptions(opts...)
+	if err := applyCountCollation(&cmd, desc, countOpts); err != nil {
+		return 0, err
+	}
+
+	return cmd.RoundTrip(ctx, desc, conn)
+}
+
+// applyCountOptions adds the count options that do not depend on the selected server to cmd. Explained
+// counts are built with it as well so that the explained command matches the command that would
+// actually be run.
+func applyCountOptions(cmd *command.Count, registry *bsoncodec.Registry, countOpts *options.CountOptions) error {
 
 	if countOpts.Limit != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"limit", bsonx.Int64(*countOpts.Limit)})
@@ -74,20 +126,36 @@ func Count(
 	if countOpts.Skip != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"skip", bsonx.Int64(*countOpts.Skip)})
 	}
-	if countOpts.Collation != nil {
-		if desc.WireVersion.Max < 5 {
-			return 0, ErrCollation
+	if countOpts.Comment != nil {
+		commentElem, err := interfaceToValueElement("comment", countOpts.Comment, registry)
+		if err != nil {
+			return err
 		}
-		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(countOpts.Collation.ToDocument())})
+
+		cmd.Opts = append(cmd.Opts, commentElem)
 	}
 	if countOpts.Hint != nil {
 		hintElem, err := interfaceToElement("hint", countOpts.Hint, registry)
 		if err != nil {
-			return 0, err
+			return err
 		}
 
 		cmd.Opts = append(cmd.Opts, hintElem)
 	}
 
-	return cmd.RoundTrip(ctx, desc, conn)
+	return nil
+}
+
+// applyCountCollation adds the collation option to cmd if the selected server supports it.
+func applyCountCollation(cmd *command.Count, desc description.SelectedServer, countOpts *options.CountOptions) error {
+	if countOpts.Collation != nil {
+		if desc.WireVersion.Max < 5 {
+			return ErrCollation
+		}
+		// copy the options so that concurrent hedged attempts do not share a backing array
+		cmd.Opts = append(cmd.Opts[:len(cmd.Opts):len(cmd.Opts)],
+			bsonx.Elem{"collation", bsonx.Document(countOpts.Collation.ToDocument())})
+	}
+
+	return nil
 }
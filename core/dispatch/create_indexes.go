@@ -38,6 +38,10 @@ func CreateIndexes(
 		return result.CreateIndexes{}, err
 	}
 
+	if err := checkIndexOptions(cmd.Indexes, ss.Description()); err != nil {
+		return result.CreateIndexes{}, err
+	}
+
 	conn, err := ss.Connection(ctx)
 	if err != nil {
 		return result.CreateIndexes{}, err
@@ -60,3 +64,33 @@ func CreateIndexes(
 
 	return cmd.RoundTrip(ctx, ss.Description(), conn)
 }
+
+// checkIndexOptions returns an error if any of the index specifications uses an option the selected
+// server is too old to support. Servers ignore index options they do not know about, so these must be
+// rejected before the command is sent.
+func checkIndexOptions(indexes bsonx.Arr, desc description.SelectedServer) error {
+	if indexesHaveOption(indexes, "collation") && desc.WireVersion.Max < 5 {
+		return ErrCollation
+	}
+	if indexesHaveOption(indexes, "wildcardProjection") && desc.WireVersion.Max < 8 {
+		return ErrWildcardProjection
+	}
+	if indexesHaveOption(indexes, "hidden") && desc.WireVersion.Max < 9 {
+		return ErrHiddenIndex
+	}
+	return nil
+}
+
+// indexesHaveOption returns true if any of the index specifications includes the given option.
+func indexesHaveOption(indexes bsonx.Arr, option string) bool {
+	for _, index := range indexes {
+		doc, ok := index.DocumentOK()
+		if !ok {
+			continue
+		}
+		if _, err := doc.LookupErr(option); err == nil {
+			return true
+		}
+	}
+	return false
+}
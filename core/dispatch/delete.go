@@ -34,6 +34,10 @@ func Delete(
 	opts ...*options.DeleteOptions,
 ) (result.Delete, error) {
 
+	if !cmd.WriteConcern.IsValid() {
+		return result.Delete{}, writeconcern.ErrInconsistent
+	}
+
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
 		return result.Delete{}, err
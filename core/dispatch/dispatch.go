@@ -20,6 +20,19 @@ var ErrCollation = errors.New("collation cannot be set for server versions < 3.4
 // ErrArrayFilters is caused if array filters are given for an invalid server version.
 var ErrArrayFilters = errors.New("array filters cannot be set for server versions < 3.6")
 
+// ErrWildcardProjection is caused if a wildcard projection is given for an invalid server version.
+var ErrWildcardProjection = errors.New("wildcard projection cannot be set for server versions < 4.2")
+
+// ErrHiddenIndex is caused if the hidden index option is given for an invalid server version.
+var ErrHiddenIndex = errors.New("hidden indexes cannot be created for server versions < 4.4")
+
+// ErrLet is caused if let variables are given for an invalid server version.
+var ErrLet = errors.New("let cannot be set for server versions < 5.0")
+
+// errRetryReadNotSupported is returned when the server selected for a read retry does not support
+// retryable reads.
+var errRetryReadNotSupported = errors.New("selected server does not support retryable reads")
+
 func interfaceToDocument(val interface{}, registry *bsoncodec.Registry) (bsonx.Doc, error) {
 	if val == nil {
 		return bsonx.Doc{}, nil
@@ -58,3 +71,14 @@ func interfaceToElement(key string, i interface{}, registry *bsoncodec.Registry)
 		return bsonx.Elem{key, bsonx.Document(doc)}, nil
 	}
 }
+
+// interfaceToValueElement creates an element with the given key whose value is i marshaled with the registry. Unlike
+// interfaceToElement, i can be any value the registry is able to encode and not only a string or a document.
+func interfaceToValueElement(key string, i interface{}, registry *bsoncodec.Registry) (bsonx.Elem, error) {
+	doc, err := interfaceToDocument(bson.D{{Key: key, Value: i}}, registry)
+	if err != nil {
+		return bsonx.Elem{}, err
+	}
+
+	return doc[0], nil
+}
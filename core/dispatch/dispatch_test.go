@@ -0,0 +1,374 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/topology"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/core/wiremessage"
+	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/readpref"
+	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
+	"github.com/mongodb/mongo-go-driver/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceToValueElement(t *testing.T) {
+	testCases := []struct {
+		name     string
+		val      interface{}
+		expected bsonx.Val
+	}{
+		{"string", "foo", bsonx.String("foo")},
+		{"int32", int32(42), bsonx.Int32(42)},
+		{"document", bson.D{{"foo", "bar"}}, bsonx.Document(bsonx.Doc{{"foo", bsonx.String("bar")}})},
+		{"bsonx.Doc", bsonx.Doc{{"foo", bsonx.Int32(1)}}, bsonx.Document(bsonx.Doc{{"foo", bsonx.Int32(1)}})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elem, err := interfaceToValueElement("comment", tc.val, nil)
+			require.NoError(t, err)
+			require.Equal(t, "comment", elem.Key)
+			require.True(t, elem.Value.Equal(tc.expected), "expected %v, got %v", tc.expected, elem.Value)
+		})
+	}
+}
+
+func TestInconsistentWriteConcern(t *testing.T) {
+	// The write concern must be rejected before a server is selected, so no topology is needed.
+	wc := writeconcern.New(writeconcern.W(0), writeconcern.J(true))
+	ns := command.Namespace{DB: "db", Collection: "coll"}
+	ctx := context.Background()
+
+	t.Run("Insert", func(t *testing.T) {
+		_, err := Insert(ctx, command.Insert{NS: ns, WriteConcern: wc}, nil, nil, uuid.UUID{}, nil, false)
+		require.Equal(t, writeconcern.ErrInconsistent, err)
+	})
+	t.Run("Update", func(t *testing.T) {
+		_, err := Update(ctx, command.Update{NS: ns, WriteConcern: wc}, nil, nil, uuid.UUID{}, nil, nil, false)
+		require.Equal(t, writeconcern.ErrInconsistent, err)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		_, err := Delete(ctx, command.Delete{NS: ns, WriteConcern: wc}, nil, nil, uuid.UUID{}, nil, false)
+		require.Equal(t, writeconcern.ErrInconsistent, err)
+	})
+	t.Run("BulkWrite", func(t *testing.T) {
+		_, err := BulkWrite(ctx, ns, nil, nil, nil, uuid.UUID{}, nil, false, nil, wc, nil, nil)
+		require.Equal(t, writeconcern.ErrInconsistent, err)
+	})
+}
+
+func TestHedge(t *testing.T) {
+	t.Run("first success wins", func(t *testing.T) {
+		fast, slow := &topology.SelectedServer{}, &topology.SelectedServer{}
+		latencies := map[*topology.SelectedServer]time.Duration{fast: 10 * time.Millisecond, slow: 5 * time.Second}
+
+		var calls int32
+		slowCancelled := make(chan struct{})
+		res, err := hedge(context.Background(), []*topology.SelectedServer{slow, fast},
+			func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				select {
+				case <-time.After(latencies[ss]):
+					return ss, nil
+				case <-ctx.Done():
+					if ss == slow {
+						close(slowCancelled)
+					}
+					return nil, ctx.Err()
+				}
+			})
+		require.NoError(t, err)
+		require.True(t, res == fast, "expected the fastest server to win the race")
+
+		select {
+		case <-slowCancelled:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for the slower attempt to be cancelled")
+		}
+		require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+	t.Run("failures fall through to the next reply", func(t *testing.T) {
+		failing, working := &topology.SelectedServer{}, &topology.SelectedServer{}
+		res, err := hedge(context.Background(), []*topology.SelectedServer{failing, working},
+			func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+				if ss == failing {
+					return nil, errors.New("network error")
+				}
+				time.Sleep(10 * time.Millisecond)
+				return int64(42), nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, int64(42), res)
+	})
+	t.Run("all attempts fail", func(t *testing.T) {
+		first, second := errors.New("first"), errors.New("second")
+		servers := []*topology.SelectedServer{{}, {}}
+		_, err := hedge(context.Background(), servers,
+			func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+				if ss == servers[0] {
+					time.Sleep(10 * time.Millisecond)
+					return nil, first
+				}
+				return nil, second
+			})
+		require.Equal(t, first, err)
+	})
+}
+
+func TestHedgedServers(t *testing.T) {
+	// reads that should not be hedged never touch the topology
+	testCases := []struct {
+		name string
+		rp   *readpref.ReadPref
+		sess *session.Client
+	}{
+		{"no read preference", nil, nil},
+		{"not nearest", readpref.Secondary(readpref.WithParallelHedge(2)), nil},
+		{"no hedge", readpref.Nearest(), nil},
+		{"hedge of one", readpref.Nearest(readpref.WithParallelHedge(1)), nil},
+		{"explicit session", readpref.Nearest(readpref.WithParallelHedge(2)), &session.Client{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			servers, err := hedgedServers(context.Background(), nil, nil, tc.rp, tc.sess)
+			require.NoError(t, err)
+			require.Nil(t, servers)
+		})
+	}
+}
+
+func TestRetryableRead(t *testing.T) {
+	networkErr := command.Error{Message: "connection reset", Labels: []string{command.NetworkError}}
+
+	// op fails with err against the first server it is given and succeeds against any other.
+	failFirst := func(first *topology.SelectedServer, err error, calls *int) func(context.Context, *topology.SelectedServer) (interface{}, error) {
+		return func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+			*calls++
+			if ss == first {
+				return nil, err
+			}
+			return ss, nil
+		}
+	}
+
+	t.Run("network error is retried on a new server", func(t *testing.T) {
+		first, second := &topology.SelectedServer{}, &topology.SelectedServer{}
+		var calls int
+		res, err := retryableRead(context.Background(), first, true,
+			func(context.Context) (*topology.SelectedServer, error) { return second, nil },
+			failFirst(first, networkErr, &calls))
+		require.NoError(t, err)
+		require.True(t, res == second, "expected the retry to run against the reselected server")
+		require.Equal(t, 2, calls)
+	})
+	t.Run("retry disabled", func(t *testing.T) {
+		first, second := &topology.SelectedServer{}, &topology.SelectedServer{}
+		var calls int
+		_, err := retryableRead(context.Background(), first, false,
+			func(context.Context) (*topology.SelectedServer, error) { return second, nil },
+			failFirst(first, networkErr, &calls))
+		require.Equal(t, networkErr, err)
+		require.Equal(t, 1, calls)
+	})
+	t.Run("non-retryable error", func(t *testing.T) {
+		first, second := &topology.SelectedServer{}, &topology.SelectedServer{}
+		cmdErr := command.Error{Code: 2, Message: "bad value"}
+		var calls int
+		_, err := retryableRead(context.Background(), first, true,
+			func(context.Context) (*topology.SelectedServer, error) { return second, nil },
+			failFirst(first, cmdErr, &calls))
+		require.Equal(t, cmdErr, err)
+		require.Equal(t, 1, calls)
+	})
+	t.Run("reselection failure returns original error", func(t *testing.T) {
+		first := &topology.SelectedServer{}
+		var calls int
+		_, err := retryableRead(context.Background(), first, true,
+			func(context.Context) (*topology.SelectedServer, error) { return nil, errRetryReadNotSupported },
+			failFirst(first, networkErr, &calls))
+		require.Equal(t, networkErr, err)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestIndexesHaveOption(t *testing.T) {
+	plain := bsonx.Document(bsonx.Doc{{"key", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}, {"name", bsonx.String("x_1")}})
+	partial := bsonx.Document(bsonx.Doc{
+		{"key", bsonx.Document(bsonx.Doc{{"y", bsonx.Int32(1)}})},
+		{"partialFilterExpression", bsonx.Document(bsonx.Doc{{"y", bsonx.Document(bsonx.Doc{{"$gt", bsonx.Int32(2)}})}})},
+		{"name", bsonx.String("y_1")},
+	})
+	collated := bsonx.Document(bsonx.Doc{
+		{"key", bsonx.Document(bsonx.Doc{{"z", bsonx.Int32(1)}})},
+		{"collation", bsonx.Document(bsonx.Doc{{"locale", bsonx.String("fr")}})},
+		{"name", bsonx.String("z_1")},
+	})
+
+	require.False(t, indexesHaveOption(bsonx.Arr{plain, partial}, "collation"))
+	require.True(t, indexesHaveOption(bsonx.Arr{plain, collated}, "collation"))
+	require.True(t, indexesHaveOption(bsonx.Arr{plain, partial}, "partialFilterExpression"))
+}
+
+func TestCheckIndexOptions(t *testing.T) {
+	index := func(opt string, val bsonx.Val) bsonx.Val {
+		return bsonx.Document(bsonx.Doc{{"key", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}, {opt, val}})
+	}
+	server := func(maxWireVersion int32) description.SelectedServer {
+		return description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: maxWireVersion}}}
+	}
+
+	testCases := []struct {
+		name    string
+		index   bsonx.Val
+		wire    int32
+		wantErr error
+	}{
+		{"collation supported", index("collation", bsonx.Document(bsonx.Doc{{"locale", bsonx.String("fr")}})), 5, nil},
+		{"collation unsupported", index("collation", bsonx.Document(bsonx.Doc{{"locale", bsonx.String("fr")}})), 4, ErrCollation},
+		{"wildcard supported", index("wildcardProjection", bsonx.Document(bsonx.Doc{{"a", bsonx.Int32(1)}})), 8, nil},
+		{"wildcard unsupported", index("wildcardProjection", bsonx.Document(bsonx.Doc{{"a", bsonx.Int32(1)}})), 7, ErrWildcardProjection},
+		{"hidden supported", index("hidden", bsonx.Boolean(true)), 9, nil},
+		{"hidden unsupported", index("hidden", bsonx.Boolean(true)), 8, ErrHiddenIndex},
+		{"no gated options", index("unique", bsonx.Boolean(true)), 2, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.wantErr, checkIndexOptions(bsonx.Arr{tc.index}, server(tc.wire)))
+		})
+	}
+}
+
+func TestLetOption(t *testing.T) {
+	server := func(maxWireVersion int32) description.SelectedServer {
+		return description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: maxWireVersion}}}
+	}
+	let := bson.D{{"target", int32(3)}}
+	want := bsonx.Elem{"let", bsonx.Document(bsonx.Doc{{"target", bsonx.Int32(3)}})}
+
+	t.Run("find", func(t *testing.T) {
+		cmd := &command.Find{}
+		require.NoError(t, applyFindOptions(cmd, server(13), nil, options.Find().SetLet(let)))
+		require.Contains(t, cmd.Opts, want)
+
+		require.Equal(t, ErrLet, applyFindOptions(&command.Find{}, server(12), nil, options.Find().SetLet(let)))
+	})
+	t.Run("aggregate", func(t *testing.T) {
+		cmd := &command.Aggregate{}
+		require.NoError(t, applyAggregateOptions(cmd, server(13), nil, options.Aggregate().SetLet(let)))
+		require.Contains(t, cmd.Opts, want)
+
+		require.Equal(t, ErrLet, applyAggregateOptions(&command.Aggregate{}, server(12), nil, options.Aggregate().SetLet(let)))
+	})
+}
+
+func TestAggregateSearchOmitsReadConcern(t *testing.T) {
+	desc := description.SelectedServer{Server: description.Server{WireVersion: &description.VersionRange{Max: 13}}}
+	stage := func(name string) bsonx.Val {
+		return bsonx.Document(bsonx.Doc{{name, bsonx.Document(bsonx.Doc{})}})
+	}
+
+	testCases := []struct {
+		name        string
+		pipeline    bsonx.Arr
+		opts        *options.AggregateOptions
+		readConcern bool
+	}{
+		{"$search", bsonx.Arr{stage("$search"), stage("$limit")}, nil, false},
+		{"$searchMeta", bsonx.Arr{stage("$searchMeta")}, nil, false},
+		{"$search not first", bsonx.Arr{stage("$match"), stage("$search")}, nil, true},
+		{"marked as search", bsonx.Arr{stage("$match")}, options.Aggregate().SetSearch(true), false},
+		{"detection disabled", bsonx.Arr{stage("$search")}, options.Aggregate().SetSearch(false), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &command.Aggregate{
+				NS:          command.Namespace{DB: "db", Collection: "coll"},
+				Pipeline:    tc.pipeline,
+				ReadConcern: readconcern.Majority(),
+			}
+			require.NoError(t, applyAggregateOptions(cmd, desc, nil, tc.opts))
+
+			wm, err := cmd.Encode(desc)
+			require.NoError(t, err)
+			msg, ok := wm.(wiremessage.Msg)
+			require.True(t, ok, "expected an OP_MSG, got %T", wm)
+			body, ok := msg.Sections[0].(wiremessage.SectionBody)
+			require.True(t, ok, "expected a body section, got %T", msg.Sections[0])
+
+			_, err = body.Document.LookupErr("readConcern")
+			require.Equal(t, tc.readConcern, err == nil, "readConcern in %v", body.Document)
+		})
+	}
+}
+
+func TestAggregateSearchSelectsServer(t *testing.T) {
+	primary := description.Server{Addr: "primary:27017", Kind: description.RSPrimary, WireVersion: &description.VersionRange{Max: 13}}
+	secondary := description.Server{Addr: "secondary:27017", Kind: description.RSSecondary, WireVersion: &description.VersionRange{Max: 13}}
+	topo := description.Topology{Kind: description.ReplicaSetWithPrimary, Servers: []description.Server{primary, secondary}}
+
+	readSelector := description.ReadPrefSelector(readpref.Secondary())
+	writeSelector := description.WriteSelector()
+	stage := func(name string) bsonx.Val {
+		return bsonx.Document(bsonx.Doc{{name, bsonx.Document(bsonx.Doc{})}})
+	}
+
+	testCases := []struct {
+		name     string
+		pipeline bsonx.Arr
+		want     description.Server
+	}{
+		{"$search", bsonx.Arr{stage("$search"), stage("$limit")}, secondary},
+		{"$searchMeta", bsonx.Arr{stage("$searchMeta")}, secondary},
+		{"$search with $out", bsonx.Arr{stage("$search"), bsonx.Document(bsonx.Doc{{"$out", bsonx.String("out")}})}, primary},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &command.Aggregate{NS: command.Namespace{DB: "db", Collection: "coll"}, Pipeline: tc.pipeline}
+
+			selected, err := aggregateSelector(cmd, readSelector, writeSelector).SelectServer(topo, topo.Servers)
+			require.NoError(t, err)
+			require.Len(t, selected, 1)
+			require.Equal(t, tc.want.Addr, selected[0].Addr)
+		})
+	}
+}
+
+func TestCountHintOption(t *testing.T) {
+	testCases := []struct {
+		name string
+		hint interface{}
+		want bsonx.Elem
+	}{
+		{"index name", "x_1", bsonx.Elem{"hint", bsonx.String("x_1")}},
+		{"key document", bson.D{{"x", int32(1)}}, bsonx.Elem{"hint", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}})}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &command.Count{}
+			require.NoError(t, applyCountOptions(cmd, bson.DefaultRegistry, options.Count().SetHint(tc.hint)))
+			require.Contains(t, cmd.Opts, tc.want)
+		})
+	}
+}
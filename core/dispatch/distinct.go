@@ -30,26 +30,71 @@ func Distinct(
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
+	retryRead bool,
 	opts ...*options.DistinctOptions,
 ) (result.Distinct, error) {
 
+	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
+	if err != nil {
+		return result.Distinct{}, err
+	}
+	cmd.ReadPref = rp
+
+	distinctOpts := options.MergeDistinctOptions(opts...)
+
+	if distinctOpts.MaxTime != nil {
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{
+			"maxTimeMS", bsonx.Int64(int64(time.Duration(*distinctOpts.MaxTime) / time.Millisecond)),
+		})
+	}
+
+	servers, err := hedgedServers(ctx, topo, selector, cmd.ReadPref, cmd.Session)
+	if err != nil {
+		return result.Distinct{}, err
+	}
+	if servers != nil {
+		res, err := hedge(ctx, servers, func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+			return distinctOnServer(ctx, cmd, topo, ss, clientID, pool, distinctOpts)
+		})
+		if err != nil {
+			return result.Distinct{}, err
+		}
+		return res.(result.Distinct), nil
+	}
+
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
 		return result.Distinct{}, err
 	}
 
-	desc := ss.Description()
-	conn, err := ss.Connection(ctx)
+	retry := retryRead && retryReadSupported(topo, ss.Description(), cmd.Session)
+	res, err := retryableRead(ctx, ss, retry, reselectForRetryRead(topo, selector, cmd.Session),
+		func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+			return distinctOnServer(ctx, cmd, topo, ss, clientID, pool, distinctOpts)
+		},
+	)
 	if err != nil {
 		return result.Distinct{}, err
 	}
-	defer conn.Close()
+	return res.(result.Distinct), nil
+}
 
-	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
+func distinctOnServer(
+	ctx context.Context,
+	cmd command.Distinct,
+	topo *topology.Topology,
+	ss *topology.SelectedServer,
+	clientID uuid.UUID,
+	pool *session.Pool,
+	distinctOpts *options.DistinctOptions,
+) (result.Distinct, error) {
+
+	desc := ss.Description()
+	conn, err := ss.Connection(ctx)
 	if err != nil {
 		return result.Distinct{}, err
 	}
-	cmd.ReadPref = rp
+	defer conn.Close()
 
 	// If no explicit session and deployment supports sessions, start implicit session.
 	if cmd.Session == nil && topo.SupportsSessions() {
@@ -60,18 +105,13 @@ func Distinct(
 		defer cmd.Session.EndSession()
 	}
 
-	distinctOpts := options.MergeDistinctOptions(opts...)
-
-	if distinctOpts.MaxTime != nil {
-		cmd.Opts = append(cmd.Opts, bsonx.Elem{
-			"maxTimeMS", bsonx.Int64(int64(time.Duration(*distinctOpts.MaxTime) / time.Millisecond)),
-		})
-	}
 	if distinctOpts.Collation != nil {
 		if desc.WireVersion.Max < 5 {
 			return result.Distinct{}, ErrCollation
 		}
-		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(distinctOpts.Collation.ToDocument())})
+		// copy the options so that concurrent hedged attempts do not share a backing array
+		cmd.Opts = append(cmd.Opts[:len(cmd.Opts):len(cmd.Opts)],
+			bsonx.Elem{"collation", bsonx.Document(distinctOpts.Collation.ToDocument())})
 	}
 
 	return cmd.RoundTrip(ctx, desc, conn)
@@ -31,6 +31,7 @@ func Find(
 	clientID uuid.UUID,
 	pool *session.Pool,
 	registry *bsoncodec.Registry,
+	retryRead bool,
 	opts ...*options.FindOptions,
 ) (command.Cursor, error) {
 
@@ -40,11 +41,6 @@ func Find(
 	}
 
 	desc := ss.Description()
-	conn, err := ss.Connection(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer conn.Close()
 
 	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
 	if err != nil {
@@ -60,6 +56,37 @@ func Find(
 		}
 	}
 
+	if err := applyFindOptions(&cmd, desc, registry, opts...); err != nil {
+		return nil, err
+	}
+
+	retry := retryRead && retryReadSupported(topo, desc, cmd.Session)
+	res, err := retryableRead(ctx, ss, retry, reselectForRetryRead(topo, selector, cmd.Session),
+		func(ctx context.Context, ss *topology.SelectedServer) (interface{}, error) {
+			conn, err := ss.Connection(ctx)
+			if err != nil {
+				return nil, err
+			}
+			defer conn.Close()
+
+			return cmd.RoundTrip(ctx, ss.Description(), ss, conn)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return res.(command.Cursor), nil
+}
+
+// applyFindOptions adds the find options to cmd for the selected server. Explained finds are built with
+// it as well so that the explained command matches the command that would actually be run.
+func applyFindOptions(
+	cmd *command.Find,
+	desc description.SelectedServer,
+	registry *bsoncodec.Registry,
+	opts ...*options.FindOptions,
+) error {
+
 	fo := options.MergeFindOptions(opts...)
 	if fo.AllowPartialResults != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"allowPartialResults", bsonx.Boolean(*fo.AllowPartialResults)})
@@ -75,7 +102,7 @@ func Find(
 	}
 	if fo.Collation != nil {
 		if desc.WireVersion.Max < 5 {
-			return nil, ErrCollation
+			return ErrCollation
 		}
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(fo.Collation.ToDocument())})
 	}
@@ -93,18 +120,29 @@ func Find(
 	if fo.Hint != nil {
 		hintElem, err := interfaceToElement("hint", fo.Hint, registry)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		cmd.Opts = append(cmd.Opts, hintElem)
 	}
+	if fo.Let != nil {
+		if desc.WireVersion.Max < 13 {
+			return ErrLet
+		}
+		let, err := interfaceToDocument(fo.Let, registry)
+		if err != nil {
+			return err
+		}
+
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"let", bsonx.Document(let)})
+	}
 	if fo.Limit != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"limit", bsonx.Int64(*fo.Limit)})
 	}
 	if fo.Max != nil {
 		maxElem, err := interfaceToElement("max", fo.Max, registry)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		cmd.Opts = append(cmd.Opts, maxElem)
@@ -119,7 +157,7 @@ func Find(
 	if fo.Min != nil {
 		minElem, err := interfaceToElement("min", fo.Min, registry)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		cmd.Opts = append(cmd.Opts, minElem)
@@ -133,7 +171,7 @@ func Find(
 	if fo.Projection != nil {
 		projElem, err := interfaceToElement("projection", fo.Projection, registry)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		cmd.Opts = append(cmd.Opts, projElem)
@@ -153,11 +191,11 @@ func Find(
 	if fo.Sort != nil {
 		sortElem, err := interfaceToElement("sort", fo.Sort, registry)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		cmd.Opts = append(cmd.Opts, sortElem)
 	}
 
-	return cmd.RoundTrip(ctx, desc, ss, conn)
+	return nil
 }
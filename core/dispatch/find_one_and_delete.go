@@ -37,6 +37,10 @@ func FindOneAndDelete(
 	opts ...*options.FindOneAndDeleteOptions,
 ) (result.FindAndModify, error) {
 
+	if !cmd.WriteConcern.IsValid() {
+		return result.FindAndModify{}, writeconcern.ErrInconsistent
+	}
+
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
 		return result.FindAndModify{}, err
@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dispatch
+
+import (
+	"context"
+
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/topology"
+	"github.com/mongodb/mongo-go-driver/mongo/readpref"
+)
+
+// hedgedServers selects the lowest latency servers a read should be raced against when rp asks for a
+// parallel hedge. It returns nil if the read should not be hedged. Reads using an explicit session are
+// never hedged because a session cannot be used by two operations at once.
+func hedgedServers(
+	ctx context.Context,
+	topo *topology.Topology,
+	selector description.ServerSelector,
+	rp *readpref.ReadPref,
+	sess *session.Client,
+) ([]*topology.SelectedServer, error) {
+
+	if rp == nil || rp.Mode() != readpref.NearestMode || rp.ParallelHedge() < 2 || sess != nil {
+		return nil, nil
+	}
+
+	servers, err := topo.SelectServers(ctx, description.CompositeSelector([]description.ServerSelector{
+		selector,
+		description.FastestSelector(rp.ParallelHedge()),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) < 2 {
+		return nil, nil
+	}
+	return servers, nil
+}
+
+type hedgeResult struct {
+	server int
+	res    interface{}
+	err    error
+}
+
+// hedge runs op against each of the servers concurrently and returns the result of the first attempt
+// to succeed, cancelling the others. If every attempt fails, the error from the first server is
+// returned.
+func hedge(
+	ctx context.Context,
+	servers []*topology.SelectedServer,
+	op func(context.Context, *topology.SelectedServer) (interface{}, error),
+) (interface{}, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// buffered so that the attempts that lose the race never block
+	results := make(chan hedgeResult, len(servers))
+	for i, ss := range servers {
+		go func(i int, ss *topology.SelectedServer) {
+			res, err := op(ctx, ss)
+			results <- hedgeResult{server: i, res: res, err: err}
+		}(i, ss)
+	}
+
+	errs := make([]error, len(servers))
+	for range servers {
+		r := <-results
+		if r.err == nil {
+			return r.res, nil
+		}
+		errs[r.server] = r.err
+	}
+	return nil, errs[0]
+}
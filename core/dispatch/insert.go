@@ -34,6 +34,10 @@ func Insert(
 	opts ...*options.InsertManyOptions,
 ) (result.Insert, error) {
 
+	if !cmd.WriteConcern.IsValid() {
+		return result.Insert{}, writeconcern.ErrInconsistent
+	}
+
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
 		return result.Insert{}, err
@@ -0,0 +1,130 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package dispatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/session"
+	"github.com/mongodb/mongo-go-driver/core/topology"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
+	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
+	"github.com/mongodb/mongo-go-driver/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// MapReduce handles the full cycle dispatch and execution of a mapReduce command against the provided
+// topology. Inline results are returned as a cursor; when an output collection is specified the results
+// are written to it and an empty cursor is returned.
+func MapReduce(
+	ctx context.Context,
+	cmd command.MapReduce,
+	topo *topology.Topology,
+	readSelector, writeSelector description.ServerSelector,
+	clientID uuid.UUID,
+	pool *session.Pool,
+	registry *bsoncodec.Registry,
+	opts ...*options.MapReduceOptions,
+) (command.Cursor, error) {
+
+	mrOpts := options.MergeMapReduceOptions(opts...)
+
+	if mrOpts.Out != nil {
+		action := options.MapReduceReplace
+		if mrOpts.OutAction != nil {
+			action = *mrOpts.OutAction
+		}
+		cmd.Out = bsonx.Doc{{string(action), bsonx.String(*mrOpts.Out)}}
+	}
+
+	selector := readSelector
+	if !cmd.Inline() {
+		if !cmd.WriteConcern.IsValid() {
+			return nil, writeconcern.ErrInconsistent
+		}
+		selector = writeSelector
+	}
+
+	ss, err := topo.SelectServer(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := ss.Description()
+	conn, err := ss.Connection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	rp, err := getReadPrefBasedOnTransaction(cmd.ReadPref, cmd.Session)
+	if err != nil {
+		return nil, err
+	}
+	cmd.ReadPref = rp
+
+	// If no explicit session and deployment supports sessions, start implicit session.
+	if cmd.Session == nil && topo.SupportsSessions() {
+		cmd.Session, err = session.NewClientSession(pool, clientID, session.Implicit)
+		if err != nil {
+			return nil, err
+		}
+		// inline results hand the session to the cursor, which ends it once the results are exhausted
+		if !cmd.Inline() {
+			defer cmd.Session.EndSession()
+		}
+	}
+
+	if mrOpts.Query != nil {
+		queryElem, err := interfaceToElement("query", mrOpts.Query, registry)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Opts = append(cmd.Opts, queryElem)
+	}
+	if mrOpts.Sort != nil {
+		sortElem, err := interfaceToElement("sort", mrOpts.Sort, registry)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Opts = append(cmd.Opts, sortElem)
+	}
+	if mrOpts.Limit != nil {
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"limit", bsonx.Int64(*mrOpts.Limit)})
+	}
+	if mrOpts.Finalize != nil {
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"finalize", bsonx.JavaScript(*mrOpts.Finalize)})
+	}
+	if mrOpts.Scope != nil {
+		scopeElem, err := interfaceToElement("scope", mrOpts.Scope, registry)
+		if err != nil {
+			return nil, err
+		}
+
+		cmd.Opts = append(cmd.Opts, scopeElem)
+	}
+	if mrOpts.BypassDocumentValidation != nil && !cmd.Inline() && desc.WireVersion.Includes(4) {
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"bypassDocumentValidation", bsonx.Boolean(*mrOpts.BypassDocumentValidation)})
+	}
+	if mrOpts.Collation != nil {
+		if desc.WireVersion.Max < 5 {
+			return nil, ErrCollation
+		}
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(mrOpts.Collation.ToDocument())})
+	}
+	if mrOpts.MaxTime != nil {
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"maxTimeMS", bsonx.Int64(int64(*mrOpts.MaxTime / time.Millisecond))})
+	}
+
+	return cmd.RoundTrip(ctx, desc, ss, conn)
+}
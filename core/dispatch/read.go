@@ -83,3 +83,55 @@ func checkTransactionReadPref(pref *readpref.ReadPref) error {
 	}
 	return nil
 }
+
+// Retryable reads are supported if the server supports sessions and the operation is not within a
+// transaction.
+func retryReadSupported(topo *topology.Topology, desc description.SelectedServer, sess *session.Client) bool {
+	return topo.SupportsSessions() &&
+		description.SessionsSupported(desc.WireVersion) &&
+		!(sess != nil && sess.TransactionRunning())
+}
+
+// reselectForRetryRead returns a function that selects the server a failed read should be retried
+// against. It returns an error if the newly selected server does not support retryable reads.
+func reselectForRetryRead(
+	topo *topology.Topology,
+	selector description.ServerSelector,
+	sess *session.Client,
+) func(context.Context) (*topology.SelectedServer, error) {
+	return func(ctx context.Context) (*topology.SelectedServer, error) {
+		ss, err := topo.SelectServer(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		if !retryReadSupported(topo, ss.Description(), sess) {
+			return nil, errRetryReadNotSupported
+		}
+		return ss, nil
+	}
+}
+
+// retryableRead runs op against ss. If retry is true and the attempt fails with a retryable error,
+// reselect is used to choose a new server and op is run once more. The original error is returned if
+// a new server cannot be selected.
+func retryableRead(
+	ctx context.Context,
+	ss *topology.SelectedServer,
+	retry bool,
+	reselect func(context.Context) (*topology.SelectedServer, error),
+	op func(context.Context, *topology.SelectedServer) (interface{}, error),
+) (interface{}, error) {
+
+	res, originalErr := op(ctx, ss)
+	cerr, ok := originalErr.(command.Error)
+	if !retry || !ok || !cerr.Retryable() {
+		return res, originalErr
+	}
+
+	ss, err := reselect(ctx)
+	if err != nil {
+		return res, originalErr
+	}
+
+	return op(ctx, ss)
+}
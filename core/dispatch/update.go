@@ -9,6 +9,7 @@ package dispatch
 import (
 	"context"
 
+	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/options"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 
@@ -30,10 +31,15 @@ func Update(
 	selector description.ServerSelector,
 	clientID uuid.UUID,
 	pool *session.Pool,
+	registry *bsoncodec.Registry,
 	retryWrite bool,
 	opts ...*options.UpdateOptions,
 ) (result.Update, error) {
 
+	if !cmd.WriteConcern.IsValid() {
+		return result.Update{}, writeconcern.ErrInconsistent
+	}
+
 	ss, err := topo.SelectServer(ctx, selector)
 	if err != nil {
 		return result.Update{}, err
@@ -69,6 +75,16 @@ func Update(
 		}
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"collation", bsonx.Document(updateOpts.Collation.ToDocument())})
 	}
+	if updateOpts.Let != nil {
+		if ss.Description().WireVersion.Max < 13 {
+			return result.Update{}, ErrLet
+		}
+		let, err := interfaceToDocument(updateOpts.Let, registry)
+		if err != nil {
+			return result.Update{}, err
+		}
+		cmd.Opts = append(cmd.Opts, bsonx.Elem{"let", bsonx.Document(let)})
+	}
 	if updateOpts.Upsert != nil {
 		cmd.Opts = append(cmd.Opts, bsonx.Elem{"upsert", bsonx.Boolean(*updateOpts.Upsert)})
 	}
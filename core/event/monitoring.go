@@ -69,3 +69,37 @@ type CommandMonitor struct {
 	Succeeded func(context.Context, *CommandSucceededEvent)
 	Failed    func(context.Context, *CommandFailedEvent)
 }
+
+// These constants are the types of events published by a connection pool.
+const (
+	ConnectionCreated  = "ConnectionCreated"
+	ConnectionClosed   = "ConnectionClosed"
+	ConnectionReturned = "ConnectionReturned"
+	GetSucceeded       = "GetSucceeded"
+	GetFailed          = "GetFailed"
+	PoolCleared        = "PoolCleared"
+)
+
+// These constants are the reasons given in pool events for closing a connection or failing to get one.
+const (
+	ReasonIdle            = "idle"
+	ReasonStale           = "stale"
+	ReasonExpired         = "expired"
+	ReasonPoolClosed      = "poolClosed"
+	ReasonPoolFull        = "poolFull"
+	ReasonTimedOut        = "timeout"
+	ReasonConnectionError = "connectionError"
+)
+
+// PoolEvent represents an event generated by a connection pool.
+type PoolEvent struct {
+	Type         string
+	Address      string
+	ConnectionID uint64
+	Reason       string
+}
+
+// PoolMonitor represents a monitor that is triggered for connection pool events.
+type PoolMonitor struct {
+	Event func(*PoolEvent)
+}
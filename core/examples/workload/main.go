@@ -143,6 +143,7 @@ func work(ctx context.Context, idx int, c *topology.Topology) {
 				id,
 				&session.Pool{},
 				bson.DefaultRegistry,
+				true,
 				aggOpts,
 			)
 			if err != nil {
@@ -78,7 +78,7 @@ type WriteError struct {
 type WriteConcernError struct {
 	Code    int
 	ErrMsg  string
-	ErrInfo bson.Raw
+	ErrInfo bson.Raw `bson:"errInfo"`
 }
 
 // ListDatabases is the result from a listDatabases command.
@@ -101,6 +101,7 @@ type IsMaster struct {
 	Hidden                       bool              `bson:"hidden,omitempty"`
 	Hosts                        []string          `bson:"hosts,omitempty"`
 	IsMaster                     bool              `bson:"ismaster,omitempty"`
+	IsWritablePrimary            bool              `bson:"isWritablePrimary,omitempty"`
 	IsReplicaSet                 bool              `bson:"isreplicaset,omitempty"`
 	LastWriteTimestamp           time.Time         `bson:"lastWriteDate,omitempty"`
 	LogicalSessionTimeoutMinutes uint32            `bson:"logicalSessionTimeoutMinutes,omitempty"`
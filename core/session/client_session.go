@@ -10,6 +10,7 @@ import (
 	"errors"
 
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
@@ -79,6 +80,11 @@ type Client struct {
 	CurrentRp *readpref.ReadPref
 	CurrentWc *writeconcern.WriteConcern
 
+	// PinnedServer is the mongos that a sharded transaction is pinned to. All operations in the
+	// transaction, including commit and abort, must be sent to it. It is cleared once the transaction
+	// is committed or aborted.
+	PinnedServer *description.Server
+
 	// default transaction options
 	transactionRc *readconcern.ReadConcern
 	transactionRp *readpref.ReadPref
@@ -248,6 +254,7 @@ func (c *Client) StartTransaction(opts *TransactionOptions) error {
 
 	c.IncrementTxnNumber()
 	c.RetryingCommit = false
+	c.PinnedServer = nil
 
 	if opts != nil {
 		c.CurrentRc = opts.ReadConcern
@@ -295,6 +302,7 @@ func (c *Client) CommitTransaction() error {
 		return err
 	}
 	c.state = Committed
+	c.PinnedServer = nil
 	return nil
 }
 
@@ -323,8 +331,10 @@ func (c *Client) AbortTransaction() error {
 	return nil
 }
 
-// ApplyCommand advances the state machine upon command execution.
-func (c *Client) ApplyCommand() {
+// ApplyCommand advances the state machine upon command execution. A transaction running against a mongos is
+// pinned to the mongos that runs its first statement. A statement only runs on another mongos if the pinned
+// one was unavailable when the server was selected, so the transaction is then pinned to the replacement.
+func (c *Client) ApplyCommand(desc description.Server) {
 	if c.Committing {
 		// Do not change state if committing after already committed
 		return
@@ -335,6 +345,11 @@ func (c *Client) ApplyCommand() {
 		c.clearTransactionOpts()
 		c.state = None
 	}
+
+	if c.state == InProgress && desc.Kind == description.Mongos &&
+		(c.PinnedServer == nil || c.PinnedServer.Addr != desc.Addr) {
+		c.PinnedServer = &desc
+	}
 }
 
 func (c *Client) clearTransactionOpts() {
@@ -344,4 +359,5 @@ func (c *Client) clearTransactionOpts() {
 	c.CurrentWc = nil
 	c.CurrentRp = nil
 	c.CurrentRc = nil
+	c.PinnedServer = nil
 }
@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
@@ -150,7 +152,7 @@ func TestClientSession(t *testing.T) {
 			t.Errorf("expected error, got %v", err)
 		}
 
-		sess.ApplyCommand()
+		sess.ApplyCommand(description.Server{})
 		if sess.state != InProgress {
 			t.Errorf("incorrect session state, expected InProgress, received %v", sess.state)
 		}
@@ -193,4 +195,44 @@ func TestClientSession(t *testing.T) {
 			t.Errorf("expected error, got %v", err)
 		}
 	})
+
+	t.Run("TestPinnedServer", func(t *testing.T) {
+		id, _ := uuid.New()
+		sess, err := NewClientSession(&Pool{}, id, Explicit, nil)
+		require.Nil(t, err, "Unexpected error")
+
+		mongosA := description.Server{Addr: address.Address("a:27017"), Kind: description.Mongos}
+		mongosB := description.Server{Addr: address.Address("b:27017"), Kind: description.Mongos}
+
+		// operations outside of a transaction never pin
+		sess.ApplyCommand(mongosA)
+		require.Nil(t, sess.PinnedServer)
+
+		// the first statement pins and later statements keep the pin
+		require.Nil(t, sess.StartTransaction(nil))
+		sess.ApplyCommand(mongosA)
+		require.NotNil(t, sess.PinnedServer)
+		require.Equal(t, mongosA.Addr, sess.PinnedServer.Addr)
+		sess.ApplyCommand(mongosA)
+		require.Equal(t, mongosA.Addr, sess.PinnedServer.Addr)
+
+		// a statement that ran on a replacement for the pinned mongos moves the pin
+		sess.ApplyCommand(mongosB)
+		require.NotNil(t, sess.PinnedServer)
+		require.Equal(t, mongosB.Addr, sess.PinnedServer.Addr)
+
+		require.Nil(t, sess.CommitTransaction())
+		require.Nil(t, sess.PinnedServer)
+
+		require.Nil(t, sess.StartTransaction(nil))
+		sess.ApplyCommand(mongosA)
+		require.NotNil(t, sess.PinnedServer)
+		require.Nil(t, sess.AbortTransaction())
+		require.Nil(t, sess.PinnedServer)
+
+		// replica set members are never pinned
+		require.Nil(t, sess.StartTransaction(nil))
+		sess.ApplyCommand(description.Server{Addr: address.Address("c:27017"), Kind: description.RSPrimary})
+		require.Nil(t, sess.PinnedServer)
+	})
 }
@@ -0,0 +1,90 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
+)
+
+// batchReader reads the values of a firstBatch or nextBatch array one at a time. Unlike bson.Raw's
+// Values method, it does not build a slice of every value in the batch up front; each value is read
+// from the reply bytes only when it is reached.
+//
+// The methods of a nil *batchReader behave as if the batch were empty.
+type batchReader struct {
+	rem     []byte // the elements that have not been read yet
+	current bson.RawValue
+	err     error
+}
+
+var _ bsonrw.ArrayReader = (*batchReader)(nil)
+
+// newBatchReader returns a batchReader over the BSON array arr.
+func newBatchReader(arr bson.Raw) (*batchReader, error) {
+	length, _, ok := bsoncore.ReadLength(arr)
+	if !ok || int(length) > len(arr) || length < 5 {
+		return nil, bsoncore.NewInsufficientBytesError(arr, arr)
+	}
+
+	// skip the length and the trailing null byte
+	return &batchReader{rem: arr[4 : length-1]}, nil
+}
+
+// Next advances to the next value in the batch. It returns false when the batch is exhausted or the
+// next value could not be read, in which case Err returns the error.
+func (br *batchReader) Next() bool {
+	if br == nil || br.err != nil || len(br.rem) == 0 {
+		return false
+	}
+
+	elem, rem, ok := bsoncore.ReadElement(br.rem)
+	if !ok {
+		br.err = bsoncore.NewInsufficientBytesError(br.rem, rem)
+		return false
+	}
+	val, err := elem.ValueErr()
+	if err != nil {
+		br.err = err
+		return false
+	}
+
+	br.rem = rem
+	br.current = bson.RawValue{Type: val.Type, Value: val.Data}
+	return true
+}
+
+// Value returns the value Next advanced to. The value refers to the bytes of the reply the batch was
+// read from.
+func (br *batchReader) Value() bson.RawValue {
+	if br == nil {
+		return bson.RawValue{}
+	}
+	return br.current
+}
+
+// Err returns the error that stopped Next, if any.
+func (br *batchReader) Err() error {
+	if br == nil {
+		return nil
+	}
+	return br.err
+}
+
+// ReadValue implements the bsonrw.ArrayReader interface. It returns bsonrw.ErrEOA once the batch is
+// exhausted.
+func (br *batchReader) ReadValue() (bsonrw.ValueReader, error) {
+	if !br.Next() {
+		if err := br.Err(); err != nil {
+			return nil, err
+		}
+		return nil, bsonrw.ErrEOA
+	}
+
+	return bsonrw.NewBSONValueReader(br.current.Type, br.current.Value), nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package topology
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsonrw"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBatch(t testing.TB, vals ...bsonx.Val) *batchReader {
+	// a nil Arr marshals as null, so always marshal a non-nil array
+	_, arr, err := append(bsonx.Arr{}, vals...).MarshalBSONValue()
+	require.NoError(t, err)
+
+	br, err := newBatchReader(arr)
+	require.NoError(t, err)
+	return br
+}
+
+func TestBatchReader(t *testing.T) {
+	t.Run("iterates values in order", func(t *testing.T) {
+		br := newTestBatch(t, bsonx.String("a"), bsonx.Int32(1), bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(2)}}))
+
+		assert.True(t, br.Next())
+		assert.Equal(t, "a", br.Value().StringValue())
+		assert.True(t, br.Next())
+		assert.Equal(t, int32(1), br.Value().Int32())
+		assert.True(t, br.Next())
+		assert.Equal(t, int32(2), br.Value().Document().Lookup("x").Int32())
+		assert.False(t, br.Next())
+		assert.NoError(t, br.Err())
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		br := newTestBatch(t)
+
+		assert.False(t, br.Next())
+		assert.NoError(t, br.Err())
+	})
+
+	t.Run("nil reader", func(t *testing.T) {
+		var br *batchReader
+
+		assert.False(t, br.Next())
+		assert.NoError(t, br.Err())
+		assert.Equal(t, bson.RawValue{}, br.Value())
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		_, err := newBatchReader(bson.Raw{0x0A, 0x00, 0x00, 0x00, 0x00})
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed element", func(t *testing.T) {
+		// an int32 element "0" that is missing its value
+		arr := bson.Raw{0x08, 0x00, 0x00, 0x00, 0x10, '0', 0x00, 0x00}
+		br, err := newBatchReader(arr)
+		require.NoError(t, err)
+
+		assert.False(t, br.Next())
+		assert.Error(t, br.Err())
+	})
+
+	t.Run("ReadValue", func(t *testing.T) {
+		br := newTestBatch(t, bsonx.String("a"))
+
+		vr, err := br.ReadValue()
+		require.NoError(t, err)
+		s, err := vr.ReadString()
+		require.NoError(t, err)
+		assert.Equal(t, "a", s)
+
+		_, err = br.ReadValue()
+		assert.Equal(t, bsonrw.ErrEOA, err)
+	})
+}
+
+func BenchmarkBatchReader(b *testing.B) {
+	vals := make([]bsonx.Val, 10000)
+	for i := range vals {
+		vals[i] = bsonx.Document(bsonx.Doc{
+			{"_id", bsonx.Int32(int32(i))},
+			{"name", bsonx.String("document")},
+		})
+	}
+	_, arr, err := bsonx.Arr(vals).MarshalBSONValue()
+	require.NoError(b, err)
+
+	b.Run("Values", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			values, err := bson.Raw(arr).Values()
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, val := range values {
+				_ = val.Document()
+			}
+		}
+	})
+
+	b.Run("batchReader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			br, err := newBatchReader(arr)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for br.Next() {
+				_ = br.Value().Document()
+			}
+			if err := br.Err(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -56,6 +56,7 @@ func (sc *sconn) processErr(err error) {
 		desc.LastError = err
 		// updates description to unknown
 		sc.s.updateDescription(desc, false)
+		sc.s.RequestImmediateCheck()
 	}
 
 	ne, ok := err.(connection.NetworkError)
@@ -73,8 +74,10 @@ func (sc *sconn) processErr(err error) {
 	desc := sc.s.Description()
 	desc.Kind = description.Unknown
 	desc.LastError = err
-	// updates description to unknown
+	// updates description to unknown and re-checks the server rather than waiting for the next
+	// heartbeat
 	sc.s.updateDescription(desc, false)
+	sc.s.RequestImmediateCheck()
 }
 
 func isRecoveringError(err command.Error) bool {
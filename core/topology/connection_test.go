@@ -76,3 +76,44 @@ func TestConnectionProcessErrSpec(t *testing.T) {
 	require.NotNil(t, desc.LastError)
 	require.Equal(t, desc.Kind, (description.ServerKind)(description.Unknown))
 }
+
+// failOnce is a connection whose first write fails with a network error.
+type failOnce struct {
+	connect
+	failed bool
+}
+
+func (f *failOnce) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
+	if f.failed {
+		return nil
+	}
+	f.failed = true
+	return *f.err
+}
+
+func TestConnectionProcessErrRequestsImmediateCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("network error", func(t *testing.T) {
+		s, err := NewServer(address.Address("localhost"))
+		require.NoError(t, err)
+		s.connectionstate = connected
+
+		sc := sconn{&failOnce{connect: connect{&connection.NetworkError{"blah", netErr{}}}}, s, 1}
+		require.Error(t, sc.WriteWireMessage(ctx, nil))
+		require.Len(t, s.checkNow, 1, "expected a re-check to be scheduled")
+		<-s.checkNow
+
+		require.NoError(t, sc.WriteWireMessage(ctx, nil))
+		require.Len(t, s.checkNow, 0, "expected no re-check after a successful write")
+	})
+	t.Run("timeout", func(t *testing.T) {
+		s, err := NewServer(address.Address("localhost"))
+		require.NoError(t, err)
+		s.connectionstate = connected
+
+		sc := sconn{connect{&connection.NetworkError{"blah", context.DeadlineExceeded}}, s, 1}
+		require.Error(t, sc.WriteWireMessage(ctx, nil))
+		require.Len(t, s.checkNow, 0, "expected no re-check after a timeout")
+	})
+}
@@ -13,23 +13,22 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
-	"github.com/mongodb/mongo-go-driver/bson/bsontype"
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
 type cursor struct {
-	clientSession *session.Client
-	clock         *session.ClusterClock
-	namespace     command.Namespace
-	current       int
-	batch         []bson.RawValue
-	id            int64
-	err           error
-	server        *Server
-	opts          []bsonx.Elem
-	registry      *bsoncodec.Registry
+	clientSession        *session.Client
+	clock                *session.ClusterClock
+	namespace            command.Namespace
+	batch                *batchReader
+	postBatchResumeToken bson.Raw
+	id                   int64
+	err                  error
+	server               *Server
+	opts                 []bsonx.Elem
+	registry             *bsoncodec.Registry
 }
 
 func newCursor(result bson.Raw, clientSession *session.Client, clock *session.ClusterClock, server *Server, opts ...bsonx.Elem) (command.Cursor, error) {
@@ -48,7 +47,6 @@ func newCursor(result bson.Raw, clientSession *session.Client, clock *session.Cl
 	c := &cursor{
 		clientSession: clientSession,
 		clock:         clock,
-		current:       -1,
 		server:        server,
 		registry:      server.cfg.registry,
 		opts:          opts,
@@ -63,7 +61,7 @@ func newCursor(result bson.Raw, clientSession *session.Client, clock *session.Cl
 			if !ok {
 				return nil, fmt.Errorf("firstBatch should be an array but it is a BSON %s", elem.Value().Type)
 			}
-			c.batch, err = arr.Values()
+			c.batch, err = newBatchReader(arr)
 			if err != nil {
 				return nil, err
 			}
@@ -82,6 +80,11 @@ func newCursor(result bson.Raw, clientSession *session.Client, clock *session.Cl
 			if !ok {
 				return nil, fmt.Errorf("id should be an int64 but it is a BSON %s", elem.Value().Type)
 			}
+		case "postBatchResumeToken":
+			c.postBatchResumeToken, ok = elem.Value().DocumentOK()
+			if !ok {
+				return nil, fmt.Errorf("postBatchResumeToken should be a document but it is a BSON %s", elem.Value().Type)
+			}
 		}
 	}
 
@@ -108,23 +111,48 @@ func (c *cursor) Next(ctx context.Context) bool {
 		ctx = context.Background()
 	}
 
-	c.current++
-	if c.current < len(c.batch) {
+	if c.nextInBatch() {
 		return true
 	}
 
-	c.getMore(ctx)
-
 	// call the getMore command in a loop until at least one document is returned in the next batch
-	for len(c.batch) == 0 {
-		if c.err != nil || (c.id == 0 && len(c.batch) == 0) {
-			return false
+	for c.err == nil && c.id != 0 {
+		c.getMore(ctx)
+		if c.nextInBatch() {
+			return true
 		}
+	}
 
-		c.getMore(ctx)
+	return false
+}
+
+func (c *cursor) TryNext(ctx context.Context) bool {
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	return true
+	if c.nextInBatch() {
+		return true
+	}
+
+	// unlike Next, issue at most one getMore even if it returns an empty batch
+	if c.err != nil || c.id == 0 {
+		return false
+	}
+	c.getMore(ctx)
+	return c.nextInBatch()
+}
+
+// nextInBatch advances to the next document in the current batch, recording any error reading it.
+func (c *cursor) nextInBatch() bool {
+	if c.err != nil {
+		return false
+	}
+	if c.batch.Next() {
+		return true
+	}
+	c.err = c.batch.Err()
+	return false
 }
 
 func (c *cursor) Decode(v interface{}) error {
@@ -137,13 +165,25 @@ func (c *cursor) Decode(v interface{}) error {
 }
 
 func (c *cursor) DecodeBytes() (bson.Raw, error) {
-	br := c.batch[c.current]
+	br := c.batch.Value()
 	if br.Type != bson.TypeEmbeddedDocument {
 		return nil, errors.New("Non-Document in batch of documents for cursor")
 	}
 	return br.Document(), nil
 }
 
+func (c *cursor) Current() bson.Raw {
+	doc, ok := c.batch.Value().DocumentOK()
+	if !ok {
+		return nil
+	}
+	return doc
+}
+
+func (c *cursor) PostBatchResumeToken() bson.Raw {
+	return c.postBatchResumeToken
+}
+
 func (c *cursor) Err() error {
 	return c.err
 }
@@ -174,18 +214,24 @@ func (c *cursor) Close(ctx context.Context) error {
 }
 
 func (c *cursor) getMore(ctx context.Context) {
-	// clear out the batch slice so we can reuse it.
-	for idx := range c.batch {
-		c.batch[idx].Type = bsontype.Type(0)
-		c.batch[idx].Value = nil
-	}
-	c.batch = c.batch[:0]
-	c.current = 0
+	c.batch = nil
 
 	if c.id == 0 {
 		return
 	}
 
+	// The mongo package only records a timeout on ctx for tailable await cursors; the server rejects
+	// a maxTimeMS on the getMore of any other cursor.
+	opts := c.opts
+	maxTimeMS, ok, err := command.MaxTimeMS(ctx, c.server.Description().AverageRTT)
+	if err != nil {
+		c.err = err
+		return
+	}
+	if ok {
+		opts = command.LimitMaxTimeMS(opts, maxTimeMS)
+	}
+
 	conn, err := c.server.Connection(ctx)
 	if err != nil {
 		c.err = err
@@ -196,7 +242,7 @@ func (c *cursor) getMore(ctx context.Context) {
 		Clock:   c.clock,
 		ID:      c.id,
 		NS:      c.namespace,
-		Opts:    c.opts,
+		Opts:    opts,
 		Session: c.clientSession,
 	}).RoundTrip(ctx, c.server.SelectedDescription(), conn)
 	if err != nil {
@@ -216,7 +262,6 @@ func (c *cursor) getMore(ctx context.Context) {
 		c.err = err
 		return
 	}
-	var ok bool
 	c.id, ok = id.Int64OK()
 	if !ok {
 		c.err = fmt.Errorf("BSON Type %s is not %s", id.Type, bson.TypeInt64)
@@ -239,7 +284,16 @@ func (c *cursor) getMore(ctx context.Context) {
 		c.err = fmt.Errorf("BSON Type %s is not %s", batch.Type, bson.TypeArray)
 		return
 	}
-	c.batch, c.err = arr.Values()
+	c.batch, c.err = newBatchReader(arr)
+	if c.err != nil {
+		return
+	}
 
-	return
+	// only change stream cursors on servers that support it return a postBatchResumeToken
+	if token, err := response.LookupErr("cursor", "postBatchResumeToken"); err == nil {
+		c.postBatchResumeToken, ok = token.DocumentOK()
+		if !ok {
+			c.err = fmt.Errorf("BSON Type %s is not %s", token.Type, bson.TypeEmbeddedDocument)
+		}
+	}
 }
@@ -10,15 +10,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
-	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/wiremessage"
 	"github.com/mongodb/mongo-go-driver/internal"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
-	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,10 +31,7 @@ func TestCursorNextDoesNotPanicIfContextisNil(t *testing.T) {
 	// prevents a regression of GODRIVER-298
 
 	c := cursor{
-		batch: []bson.RawValue{
-			{Type: bsontype.String, Value: bsoncore.AppendString(nil, "a")},
-			{Type: bsontype.String, Value: bsoncore.AppendString(nil, "b")},
-		},
+		batch: newTestBatch(t, bsonx.String("a"), bsonx.String("b")),
 	}
 
 	var iterNext bool
@@ -52,13 +49,38 @@ func TestCursorLoopsUntilDocAvailable(t *testing.T) {
 	s := createDefaultConnectedServer(t, false)
 	c := cursor{
 		id:     1,
-		batch:  []bson.RawValue{},
 		server: s,
 	}
 
 	assert.True(t, c.Next(nil))
 }
 
+func TestCursorTryNextDoesNotLoop(t *testing.T) {
+	// TryNext should return false after a single getMore that returns an empty batch, where
+	// Next would keep issuing getMores until a document is available
+
+	s := createDefaultConnectedServer(t, false)
+	c := cursor{
+		id:     1,
+		server: s,
+	}
+
+	assert.False(t, c.TryNext(nil))
+	assert.NoError(t, c.Err())
+	assert.Equal(t, int64(2), c.ID())
+	assert.Len(t, s.pool.(*mockPool).written, 1)
+}
+
+func TestCursorTryNextReturnsBufferedDocs(t *testing.T) {
+	c := cursor{
+		batch: newTestBatch(t, bsonx.String("a"), bsonx.String("b")),
+	}
+
+	assert.True(t, c.TryNext(context.Background()))
+	assert.True(t, c.TryNext(context.Background()))
+	assert.False(t, c.TryNext(context.Background()))
+}
+
 func TestCursorReturnsFalseOnContextCancellation(t *testing.T) {
 	// Next should return false if an error occurs
 	// here the error is the Context being cancelled
@@ -66,7 +88,6 @@ func TestCursorReturnsFalseOnContextCancellation(t *testing.T) {
 	s := createDefaultConnectedServer(t, false)
 	c := cursor{
 		id:     1,
-		batch:  []bson.RawValue{},
 		server: s,
 	}
 
@@ -84,7 +105,6 @@ func TestCursorNextReturnsFalseIfErrorOccurred(t *testing.T) {
 	s := createDefaultConnectedServer(t, true)
 	c := cursor{
 		id:     1,
-		batch:  []bson.RawValue{},
 		server: s,
 	}
 	assert.False(t, c.Next(nil))
@@ -93,10 +113,143 @@ func TestCursorNextReturnsFalseIfErrorOccurred(t *testing.T) {
 func TestCursorNextReturnsFalseIfResIdZeroAndNoMoreDocs(t *testing.T) {
 	// Next should return false if the cursor id is 0 and there are no documents in the next batch
 
-	c := cursor{id: 0, batch: []bson.RawValue{}}
+	c := cursor{id: 0}
 	assert.False(t, c.Next(nil))
 }
 
+func TestCursorGetMoreIncludesCursorOpts(t *testing.T) {
+	// getMore commands should carry the cursor options, such as the maxTimeMS used by
+	// tailable-await and change stream cursors
+
+	s := createDefaultConnectedServer(t, false)
+	c := cursor{
+		id:        1,
+		namespace: command.Namespace{DB: "db", Collection: "coll"},
+		server:    s,
+		opts:      []bsonx.Elem{{"maxTimeMS", bsonx.Int64(1000)}},
+	}
+
+	assert.True(t, c.Next(context.Background()))
+
+	pool := s.pool.(*mockPool)
+	assert.NotEmpty(t, pool.written)
+	for _, wm := range pool.written {
+		query, ok := wm.(wiremessage.Query)
+		if !assert.True(t, ok, "expected an OP_QUERY but got %T", wm) {
+			continue
+		}
+		cmd := query.Query
+		if doc, err := query.Query.LookupErr("$query"); err == nil {
+			cmd = doc.Document()
+		}
+		maxTime, err := cmd.LookupErr("maxTimeMS")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), maxTime.Int64())
+	}
+}
+
+func TestCursorGetMoreTimeout(t *testing.T) {
+	t.Run("maxTimeMS shrinks across getMores", func(t *testing.T) {
+		s := createDefaultConnectedServer(t, false)
+		pool := s.pool.(*mockPool)
+		pool.delay = 20 * time.Millisecond
+		c := cursor{
+			id:        1,
+			namespace: command.Namespace{DB: "db", Collection: "coll"},
+			server:    s,
+		}
+
+		ctx, cancel := command.WithTimeoutDeadline(context.Background(), time.Now().Add(10*time.Second))
+		defer cancel()
+		assert.True(t, c.Next(ctx))
+
+		// the mock returns three empty batches before a batch with a document
+		assert.Len(t, pool.written, 4)
+		prev := int64(10000)
+		for _, wm := range pool.written {
+			query, ok := wm.(wiremessage.Query)
+			if !assert.True(t, ok, "expected an OP_QUERY but got %T", wm) {
+				continue
+			}
+			cmd := query.Query
+			if doc, err := query.Query.LookupErr("$query"); err == nil {
+				cmd = doc.Document()
+			}
+			maxTime, err := cmd.LookupErr("maxTimeMS")
+			if !assert.NoError(t, err) {
+				continue
+			}
+			assert.True(t, maxTime.Int64() < prev, "expected maxTimeMS %d to be less than %d", maxTime.Int64(), prev)
+			prev = maxTime.Int64()
+		}
+	})
+
+	t.Run("expired budget returns a timeout error", func(t *testing.T) {
+		s := createDefaultConnectedServer(t, false)
+		c := cursor{
+			id:        1,
+			namespace: command.Namespace{DB: "db", Collection: "coll"},
+			server:    s,
+		}
+
+		ctx, cancel := command.WithTimeoutDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		assert.False(t, c.Next(ctx))
+
+		_, ok := c.Err().(command.TimeoutError)
+		assert.True(t, ok, "expected a command.TimeoutError but got %v", c.Err())
+		assert.Empty(t, s.pool.(*mockPool).written)
+	})
+}
+
+func TestCursorCurrent(t *testing.T) {
+	c := cursor{
+		batch: newTestBatch(t,
+			bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(1)}}),
+			bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(2)}}),
+		),
+		registry: bson.DefaultRegistry,
+	}
+
+	assert.Nil(t, c.Current())
+
+	for i := int32(1); i <= 2; i++ {
+		assert.True(t, c.Next(context.Background()))
+
+		var decoded bsonx.Doc
+		assert.NoError(t, c.Decode(&decoded))
+		want, err := decoded.MarshalBSON()
+		assert.NoError(t, err)
+		assert.Equal(t, bson.Raw(want), c.Current())
+
+		// Current does not advance the cursor
+		assert.Equal(t, bson.Raw(want), c.Current())
+		assert.Equal(t, i, c.Current().Lookup("x").Int32())
+	}
+}
+
+func TestCursorPostBatchResumeToken(t *testing.T) {
+	s := createDefaultConnectedServer(t, false)
+	s.pool.(*mockPool).resumeTokens = true
+	c := cursor{
+		id:     1,
+		server: s,
+	}
+
+	assert.Nil(t, c.PostBatchResumeToken())
+
+	// each getMore returns an empty batch with a newer token
+	for i := int32(1); i <= 2; i++ {
+		assert.False(t, c.TryNext(context.Background()))
+		assert.NoError(t, c.Err())
+
+		token := c.PostBatchResumeToken()
+		if assert.NotNil(t, token) {
+			assert.Equal(t, i, token.Lookup("n").Int32())
+		}
+	}
+}
+
 func createDefaultConnectedServer(t *testing.T, willErr bool) *Server {
 	s, err := ConnectServer(nil, "127.0.0.1")
 	s.pool = &mockPool{t: t, willErr: willErr}
@@ -121,14 +274,17 @@ func createOKBatchReplyDoc(id int64, batchDocs bsonx.Arr) bsonx.Doc {
 
 // Mock Pool implementation
 type mockPool struct {
-	t       *testing.T
-	willErr bool
-	writes  int // the number of wire messages written so far
+	t            *testing.T
+	willErr      bool
+	resumeTokens bool                      // whether replies carry a postBatchResumeToken
+	delay        time.Duration             // how long each read takes
+	writes       int                       // the number of wire messages written so far
+	written      []wiremessage.WireMessage // the wire messages written so far
 }
 
 func (m *mockPool) Get(ctx context.Context) (connection.Connection, *description.Server, error) {
 	m.writes++
-	return &mockConnection{willErr: m.willErr, writes: m.writes}, nil, nil
+	return &mockConnection{willErr: m.willErr, writes: m.writes, pool: m}, nil, nil
 }
 
 func (*mockPool) Connect(ctx context.Context) error {
@@ -148,34 +304,44 @@ type mockConnection struct {
 	t       *testing.T
 	willErr bool
 	writes  int // the number of wire messages written so far
+	pool    *mockPool
 }
 
-// this mock will not actually write anything
-func (*mockConnection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
+// this mock will not actually write anything, but records the wire messages with the pool
+func (m *mockConnection) WriteWireMessage(ctx context.Context, wm wiremessage.WireMessage) error {
 	select {
 	case <-ctx.Done():
 		return errors.New("intentional mock error")
 	default:
+		m.pool.written = append(m.pool.written, wm)
 		return nil
 	}
 }
 
 // mock a read by returning an empty cursor result until
 func (m *mockConnection) ReadWireMessage(ctx context.Context) (wiremessage.WireMessage, error) {
+	time.Sleep(m.pool.delay)
+
+	var d bsonx.Doc
 	if m.writes < 4 {
 		// write empty batch
-		d := createOKBatchReplyDoc(2, bsonx.Arr{})
-
-		return internal.MakeReply(m.t, d), nil
+		d = createOKBatchReplyDoc(2, bsonx.Arr{})
 	} else if m.willErr {
 		// write error
 		return nil, errors.New("intentional mock error")
 	} else {
 		// write non-empty batch
-		d := createOKBatchReplyDoc(2, bsonx.Arr{bsonx.String("a")})
+		d = createOKBatchReplyDoc(2, bsonx.Arr{bsonx.String("a")})
+	}
 
-		return internal.MakeReply(m.t, d), nil
+	if m.pool.resumeTokens {
+		cur := append(d.Lookup("cursor").Document(), bsonx.Elem{
+			"postBatchResumeToken", bsonx.Document(bsonx.Doc{{"n", bsonx.Int32(int32(m.writes))}}),
+		})
+		d = d.Set("cursor", bsonx.Document(cur))
 	}
+
+	return internal.MakeReply(m.t, d), nil
 }
 
 func (*mockConnection) Close() error {
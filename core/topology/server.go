@@ -124,7 +124,7 @@ func NewServer(addr address.Address, opts ...ServerOption) (*Server, error) {
 		maxConns = uint64(cfg.maxConns)
 	}
 
-	s.pool, err = connection.NewPool(addr, uint64(cfg.maxIdleConns), maxConns, cfg.connectionOpts...)
+	s.pool, err = connection.NewPoolWithMinSize(addr, uint64(cfg.minConns), uint64(cfg.maxIdleConns), maxConns, cfg.connectionOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +192,7 @@ func (s *Server) Connection(ctx context.Context) (connection.Connection, error)
 			} else {
 				_ = s.pool.Drain()
 			}
+			s.RequestImmediateCheck()
 		}
 		return nil, err
 	}
@@ -395,7 +396,7 @@ func (s *Server) heartbeat(conn connection.Connection) (description.Server, conn
 
 		now := time.Now()
 
-		isMasterCmd := &command.IsMaster{Compressors: s.cfg.compressionOpts}
+		isMasterCmd := &command.IsMaster{Compressors: s.cfg.compressionOpts, UseHello: s.cfg.serverAPI != nil}
 		isMaster, err := isMasterCmd.RoundTrip(ctx, conn)
 		if err != nil {
 			saved = err
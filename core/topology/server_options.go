@@ -26,7 +26,9 @@ type serverConfig struct {
 	heartbeatTimeout  time.Duration
 	maxConns          uint16
 	maxIdleConns      uint16
+	minConns          uint16
 	registry          *bsoncodec.Registry
+	serverAPI         *connection.ServerAPI
 }
 
 func newServerConfig(opts ...ServerOption) (*serverConfig, error) {
@@ -103,6 +105,15 @@ func WithMaxIdleConnections(fn func(uint16) uint16) ServerOption {
 	}
 }
 
+// WithMinConnections configures the minimum number of connections the server's
+// pool maintains in the background.
+func WithMinConnections(fn func(uint16) uint16) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.minConns = fn(cfg.minConns)
+		return nil
+	}
+}
+
 // WithClock configures the ClusterClock for the server to use.
 func WithClock(fn func(clock *session.ClusterClock) *session.ClusterClock) ServerOption {
 	return func(cfg *serverConfig) error {
@@ -119,3 +130,16 @@ func WithRegistry(fn func(*bsoncodec.Registry) *bsoncodec.Registry) ServerOption
 		return nil
 	}
 }
+
+// WithDeclaredServerAPI configures the server API version declared on every command sent to the
+// server. When a version is declared, heartbeats use the hello command over OP_MSG.
+func WithDeclaredServerAPI(fn func(*connection.ServerAPI) *connection.ServerAPI) ServerOption {
+	return func(cfg *serverConfig) error {
+		cfg.serverAPI = fn(cfg.serverAPI)
+		serverAPI := cfg.serverAPI
+		cfg.connectionOpts = append(cfg.connectionOpts, connection.WithServerAPI(func(*connection.ServerAPI) *connection.ServerAPI {
+			return serverAPI
+		}))
+		return nil
+	}
+}
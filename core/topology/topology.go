@@ -20,6 +20,7 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/session"
 )
@@ -32,6 +33,10 @@ var ErrSubscribeAfterClosed = errors.New("cannot subscribe after close")
 // closed Topology.
 var ErrTopologyClosed = errors.New("topology is closed")
 
+// ErrTopologyNotConnected is returned when a user attempts to use a Topology
+// that has never been connected.
+var ErrTopologyNotConnected = errors.New("topology is not connected")
+
 // ErrTopologyConnected is returned whena  user attempts to connect to an
 // already connected Topology.
 var ErrTopologyConnected = errors.New("topology is connected or connecting")
@@ -54,6 +59,7 @@ type Topology struct {
 	registry *bsoncodec.Registry
 
 	connectionstate int32
+	everConnected   int32 // set to 1 the first time the topology is connected
 
 	cfg *config
 
@@ -67,6 +73,11 @@ type Topology struct {
 
 	SessionPool *session.Pool
 
+	// rand chooses among the servers suitable for a selection. Each topology seeds its own source so
+	// that clients started together don't all send their reads to the same server.
+	rand     *rand.Rand
+	randLock sync.Mutex
+
 	// This should really be encapsulated into it's own type. This will likely
 	// require a redesign so we can share a minimum of data between the
 	// subscribers and the topology.
@@ -93,6 +104,16 @@ func New(opts ...Option) (*Topology, error) {
 		return nil, err
 	}
 
+	// Check the pool sizes up front, with the server defaults applied, rather than when each
+	// server's pool is created.
+	scfg, err := newServerConfig(cfg.serverOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if scfg.maxConns != 0 && scfg.minConns > scfg.maxConns {
+		return nil, connection.ErrMinSizeLargerThanCapacity
+	}
+
 	t := &Topology{
 		cfg:         cfg,
 		done:        make(chan struct{}),
@@ -100,6 +121,7 @@ func New(opts ...Option) (*Topology, error) {
 		changes:     make(chan description.Server),
 		subscribers: make(map[uint64]chan description.Topology),
 		servers:     make(map[address.Address]*Server),
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 	t.desc.Store(description.Topology{})
 
@@ -123,12 +145,15 @@ func (t *Topology) Connect(ctx context.Context) error {
 	}
 
 	t.desc.Store(description.Topology{})
-	var err error
+	var addErr error
 	t.serversLock.Lock()
 	for _, a := range t.cfg.seedList {
 		addr := address.Address(a).Canonicalize()
 		t.fsm.Servers = append(t.fsm.Servers, description.Server{Addr: addr})
-		err = t.addServer(ctx, addr)
+		addErr = t.addServer(ctx, addr)
+		if addErr != nil {
+			break
+		}
 	}
 	t.serversLock.Unlock()
 
@@ -138,10 +163,18 @@ func (t *Topology) Connect(ctx context.Context) error {
 	t.subscriptionsClosed = false // explicitly set in case topology was disconnected and then reconnected
 
 	atomic.StoreInt32(&t.connectionstate, connected)
+	atomic.StoreInt32(&t.everConnected, 1)
 
 	// After connection, make a subscription to keep the pool updated
 	sub, err := t.Subscribe()
 	t.SessionPool = session.NewPool(sub.C)
+
+	// A server that could not be added leaves the topology unusable, so shut down the servers that
+	// were added before reporting the error.
+	if addErr != nil {
+		_ = t.Disconnect(ctx)
+		return addErr
+	}
 	return err
 }
 
@@ -169,6 +202,14 @@ func (t *Topology) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// closedErr returns the error for an operation attempted while the topology isn't connected.
+func (t *Topology) closedErr() error {
+	if atomic.LoadInt32(&t.everConnected) == 0 {
+		return ErrTopologyNotConnected
+	}
+	return ErrTopologyClosed
+}
+
 // Description returns a description of the topology.
 func (t *Topology) Description() description.Topology {
 	td, ok := t.desc.Load().(description.Topology)
@@ -231,7 +272,7 @@ func (t *Topology) SupportsSessions() bool {
 // parent context is done.
 func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelector) (*SelectedServer, error) {
 	if atomic.LoadInt32(&t.connectionstate) != connected {
-		return nil, ErrTopologyClosed
+		return nil, t.closedErr()
 	}
 	var ssTimeoutCh <-chan time.Time
 
@@ -253,7 +294,7 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 			return nil, err
 		}
 
-		selected := suitable[rand.Intn(len(suitable))]
+		selected := t.pickServer(suitable)
 		selectedS, err := t.FindServer(selected)
 		switch {
 		case err != nil:
@@ -269,11 +310,61 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 	}
 }
 
+// pickServer randomly chooses one of the suitable servers to spread operations evenly across the
+// servers within the latency window.
+func (t *Topology) pickServer(suitable []description.Server) description.Server {
+	t.randLock.Lock()
+	defer t.randLock.Unlock()
+	return suitable[t.rand.Intn(len(suitable))]
+}
+
+// SelectServers selects all of the servers suitable for the given selector, in the order the selector
+// returned them. Servers that are no longer part of the topology are skipped.
+func (t *Topology) SelectServers(ctx context.Context, ss description.ServerSelector) ([]*SelectedServer, error) {
+	if atomic.LoadInt32(&t.connectionstate) != connected {
+		return nil, t.closedErr()
+	}
+	var ssTimeoutCh <-chan time.Time
+
+	if t.cfg.serverSelectionTimeout > 0 {
+		ssTimeout := time.NewTimer(t.cfg.serverSelectionTimeout)
+		ssTimeoutCh = ssTimeout.C
+		defer ssTimeout.Stop()
+	}
+
+	sub, err := t.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		suitable, err := t.selectServer(ctx, sub.C, ss, ssTimeoutCh)
+		if err != nil {
+			return nil, err
+		}
+
+		selected := make([]*SelectedServer, 0, len(suitable))
+		for _, desc := range suitable {
+			selectedS, err := t.FindServer(desc)
+			if err != nil {
+				return nil, err
+			}
+			if selectedS != nil {
+				selected = append(selected, selectedS)
+			}
+		}
+		if len(selected) > 0 {
+			return selected, nil
+		}
+	}
+}
+
 // FindServer will attempt to find a server that fits the given server description.
 // This method will return nil, nil if a matching server could not be found.
 func (t *Topology) FindServer(selected description.Server) (*SelectedServer, error) {
 	if atomic.LoadInt32(&t.connectionstate) != connected {
-		return nil, ErrTopologyClosed
+		return nil, t.closedErr()
 	}
 	t.serversLock.Lock()
 	defer t.serversLock.Unlock()
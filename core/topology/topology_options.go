@@ -7,8 +7,7 @@
 package topology
 
 import (
-	"bytes"
-	"strings"
+	"errors"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/core/auth"
@@ -18,6 +17,8 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/connstring"
 )
 
+var errX509ClientCertificate = errors.New("MONGODB-X509 authentication requires a client certificate")
+
 // Option is a configuration option for a topology.
 type Option func(*config) error
 
@@ -28,6 +29,8 @@ type config struct {
 	serverOpts             []ServerOption
 	cs                     connstring.ConnString
 	serverSelectionTimeout time.Duration
+	serverAPI              *connection.ServerAPI
+	tlsConfig              *connection.TLSConfig
 }
 
 func newConfig(opts ...Option) (*config, error) {
@@ -67,6 +70,17 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			c.mode = SingleMode
 		}
 
+		// A direct connection talks only to its seed, so there is nothing to discover.
+		if cs.DirectConnection {
+			switch {
+			case len(cs.Hosts) > 1:
+				return errors.New("directConnection cannot be used with multiple hosts")
+			case cs.ReplicaSet != "":
+				return errors.New("directConnection cannot be used with replicaSet")
+			}
+			c.mode = SingleMode
+		}
+
 		c.seedList = cs.Hosts
 
 		if cs.ConnectTimeout > 0 {
@@ -89,14 +103,20 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 			connOpts = append(connOpts, connection.WithIdleTimeout(func(time.Duration) time.Duration { return cs.MaxConnIdleTime }))
 		}
 
+		// maxLifeTimeMS limits how long a connection is used in total. It's separate from the idle
+		// timeout set by maxIdleTimeMS, which idle pruning uses, so it must not overwrite it.
 		if cs.MaxConnLifeTime > 0 {
-			connOpts = append(connOpts, connection.WithIdleTimeout(func(time.Duration) time.Duration { return cs.MaxConnLifeTime }))
+			connOpts = append(connOpts, connection.WithLifeTimeout(func(time.Duration) time.Duration { return cs.MaxConnLifeTime }))
 		}
 
 		if cs.MaxConnsPerHostSet {
 			c.serverOpts = append(c.serverOpts, WithMaxConnections(func(uint16) uint16 { return cs.MaxConnsPerHost }))
 		}
 
+		if cs.MinPoolSizeSet {
+			c.serverOpts = append(c.serverOpts, WithMinConnections(func(uint16) uint16 { return cs.MinPoolSize }))
+		}
+
 		if cs.MaxIdleConnsPerHostSet {
 			c.serverOpts = append(c.serverOpts, WithMaxIdleConnections(func(uint16) uint16 { return cs.MaxIdleConnsPerHost }))
 		}
@@ -129,22 +149,24 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 					return err
 				}
 
-				// The Go x509 package gives the subject with the pairs in reverse order that we want.
-				pairs := strings.Split(s, ",")
-				b := bytes.NewBufferString("")
+				// The subject is already in the RFC 2253 form the server uses as the user name.
+				x509Username = s
+			}
 
-				for i := len(pairs) - 1; i >= 0; i-- {
-					b.WriteString(pairs[i])
+			connOpts = append(connOpts, connection.WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig { return tlsConfig }))
+		}
 
-					if i > 0 {
-						b.WriteString(",")
-					}
+		if cs.AuthMechanism == auth.MongoDBX509 {
+			switch {
+			case c.tlsConfig != nil:
+				if cfg := c.tlsConfig.Config; cfg == nil || len(cfg.Certificates) == 0 && cfg.GetClientCertificate == nil {
+					return errX509ClientCertificate
 				}
-
-				x509Username = b.String()
+			case !cs.SSL:
+				return errors.New("MONGODB-X509 authentication requires TLS")
+			case cs.SSLClientCertificateKeyFile == "":
+				return errX509ClientCertificate
 			}
-
-			connOpts = append(connOpts, connection.WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig { return tlsConfig }))
 		}
 
 		if cs.Username != "" || cs.AuthMechanism == auth.MongoDBX509 || cs.AuthMechanism == auth.GSSAPI {
@@ -182,6 +204,7 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 					AppName:       cs.AppName,
 					Authenticator: authenticator,
 					Compressors:   cs.Compressors,
+					UseHello:      c.serverAPI != nil,
 				}
 				if cs.AuthMechanism == "" {
 					// Required for SASL mechanism negotiation during handshake
@@ -192,7 +215,11 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 		} else {
 			// We need to add a non-auth Handshaker to the connection options
 			connOpts = append(connOpts, connection.WithHandshaker(func(h connection.Handshaker) connection.Handshaker {
-				return &command.Handshake{Client: command.ClientDoc(cs.AppName), Compressors: cs.Compressors}
+				return &command.Handshake{
+					Client:      command.ClientDoc(cs.AppName),
+					Compressors: cs.Compressors,
+					UseHello:    c.serverAPI != nil,
+				}
 			}))
 		}
 
@@ -232,6 +259,36 @@ func WithConnString(fn func(connstring.ConnString) connstring.ConnString) Option
 	}
 }
 
+// WithServerAPI configures the server API version declared on every command sent to the servers of
+// the topology. When a version is declared, the connection handshake and heartbeats use the hello
+// command over OP_MSG, since isMaster is not part of the Stable API. It can be applied before or
+// after WithConnString.
+func WithServerAPI(fn func(*connection.ServerAPI) *connection.ServerAPI) Option {
+	return func(c *config) error {
+		c.serverAPI = fn(c.serverAPI)
+		serverAPI := c.serverAPI
+		c.serverOpts = append(c.serverOpts, WithDeclaredServerAPI(func(*connection.ServerAPI) *connection.ServerAPI {
+			return serverAPI
+		}))
+		return nil
+	}
+}
+
+// WithTLSConfig configures the TLS configuration used for connections to the servers of the topology,
+// in place of the SSL options of the connection string. It must be applied before WithConnString.
+func WithTLSConfig(fn func(*connection.TLSConfig) *connection.TLSConfig) Option {
+	return func(c *config) error {
+		c.tlsConfig = fn(c.tlsConfig)
+		tlsConfig := c.tlsConfig
+		c.serverOpts = append(c.serverOpts, WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+			return append(opts, connection.WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig {
+				return tlsConfig
+			}))
+		}))
+		return nil
+	}
+}
+
 // WithMode configures the topology's monitor mode.
 func WithMode(fn func(MonitorMode) MonitorMode) Option {
 	return func(cfg *config) error {
@@ -7,11 +7,16 @@
 package topology
 
 import (
+	"crypto/tls"
 	"testing"
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOptionsSetting(t *testing.T) {
@@ -31,3 +36,84 @@ func TestOptionsSetting(t *testing.T) {
 
 	assert.Equal(t, ssts, conf.serverSelectionTimeout)
 }
+
+func TestOptionsX509WithoutClientCertificate(t *testing.T) {
+	opt := WithConnString(func(connstring.ConnString) connstring.ConnString {
+		return connstring.ConnString{
+			AuthMechanism: "MONGODB-X509",
+			SSL:           true,
+			SSLSet:        true,
+		}
+	})
+
+	assert.EqualError(t, opt(&config{}), "MONGODB-X509 authentication requires a client certificate")
+
+	t.Run("without TLS", func(t *testing.T) {
+		opt := WithConnString(func(connstring.ConnString) connstring.ConnString {
+			return connstring.ConnString{AuthMechanism: "MONGODB-X509"}
+		})
+
+		assert.EqualError(t, opt(&config{}), "MONGODB-X509 authentication requires TLS")
+	})
+	t.Run("TLS config", func(t *testing.T) {
+		opt := WithConnString(func(connstring.ConnString) connstring.ConnString {
+			return connstring.ConnString{AuthMechanism: "MONGODB-X509", SSLSet: true}
+		})
+
+		conf := &config{}
+		require.NoError(t, WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig {
+			return &connection.TLSConfig{Config: &tls.Config{}}
+		})(conf))
+		assert.EqualError(t, opt(conf), "MONGODB-X509 authentication requires a client certificate")
+
+		conf = &config{}
+		require.NoError(t, WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig {
+			return &connection.TLSConfig{Config: &tls.Config{Certificates: []tls.Certificate{{}}}}
+		})(conf))
+		assert.NoError(t, opt(conf))
+	})
+}
+
+func TestOptionsDirectConnection(t *testing.T) {
+	t.Run("stays single-server", func(t *testing.T) {
+		topo, err := New(WithConnString(func(connstring.ConnString) connstring.ConnString {
+			return connstring.ConnString{
+				Hosts:               []string{"a"},
+				DirectConnection:    true,
+				DirectConnectionSet: true,
+			}
+		}))
+		require.NoError(t, err)
+		require.Equal(t, SingleMode, topo.cfg.mode)
+
+		seed := address.Address("a").Canonicalize()
+		topo.fsm.Servers = append(topo.fsm.Servers, description.Server{Addr: seed})
+		desc, err := topo.fsm.apply(description.Server{
+			Addr:    seed,
+			Kind:    description.RSSecondary,
+			SetName: "rs0",
+			Members: []address.Address{seed, address.Address("b:27017"), address.Address("c:27017")},
+		})
+		require.NoError(t, err)
+		require.Equal(t, description.Single, desc.Kind)
+		require.Len(t, desc.Servers, 1)
+		require.Equal(t, seed, desc.Servers[0].Addr)
+	})
+	t.Run("multiple hosts", func(t *testing.T) {
+		opt := WithConnString(func(connstring.ConnString) connstring.ConnString {
+			return connstring.ConnString{Hosts: []string{"a", "b"}, DirectConnection: true, DirectConnectionSet: true}
+		})
+		assert.EqualError(t, opt(&config{}), "directConnection cannot be used with multiple hosts")
+	})
+	t.Run("replica set", func(t *testing.T) {
+		opt := WithConnString(func(connstring.ConnString) connstring.ConnString {
+			return connstring.ConnString{
+				Hosts:               []string{"a"},
+				ReplicaSet:          "rs0",
+				DirectConnection:    true,
+				DirectConnectionSet: true,
+			}
+		})
+		assert.EqualError(t, opt(&config{}), "directConnection cannot be used with replicaSet")
+	})
+}
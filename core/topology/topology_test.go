@@ -15,7 +15,9 @@ import (
 
 	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 )
 
 const testTimeout = 2 * time.Second
@@ -294,6 +296,45 @@ func TestServerSelection(t *testing.T) {
 	})
 }
 
+func TestTopologyMinPoolSize(t *testing.T) {
+	minConns := func(n uint16) Option {
+		return WithServerOptions(func(opts ...ServerOption) []ServerOption {
+			return append(opts, WithMinConnections(func(uint16) uint16 { return n }))
+		})
+	}
+	maxConns := func(n uint16) Option {
+		return WithServerOptions(func(opts ...ServerOption) []ServerOption {
+			return append(opts, WithMaxConnections(func(uint16) uint16 { return n }))
+		})
+	}
+
+	t.Run("larger than the default max pool size", func(t *testing.T) {
+		_, err := New(minConns(150))
+		if err != connection.ErrMinSizeLargerThanCapacity {
+			t.Errorf("Unexpected error. got %v; want %v", err, connection.ErrMinSizeLargerThanCapacity)
+		}
+	})
+	t.Run("unbounded max pool size", func(t *testing.T) {
+		_, err := New(minConns(150), maxConns(0))
+		noerr(t, err)
+	})
+}
+
+func TestTopologyConnectServerError(t *testing.T) {
+	errServer := errors.New("server error")
+	topo, err := New(WithSeedList(func(...string) []string { return []string{"localhost:27017"} }))
+	noerr(t, err)
+	topo.cfg.serverOpts = append(topo.cfg.serverOpts, func(*serverConfig) error { return errServer })
+
+	err = topo.Connect(context.Background())
+	if err != errServer {
+		t.Errorf("Unexpected error. got %v; want %v", err, errServer)
+	}
+	if state := atomic.LoadInt32(&topo.connectionstate); state != disconnected {
+		t.Errorf("Expected the topology to be disconnected. got %d; want %d", state, disconnected)
+	}
+}
+
 func TestSessionTimeout(t *testing.T) {
 	t.Run("UpdateSessionTimeout", func(t *testing.T) {
 		topo, err := New()
@@ -454,3 +495,49 @@ func TestSessionTimeout(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectServerSpreadsLoad(t *testing.T) {
+	topo, err := New()
+	noerr(t, err)
+
+	secondary := func(addr string) description.Server {
+		return description.Server{
+			Addr: address.Address(addr),
+			Kind: description.RSSecondary,
+		}.SetAverageRTT(10 * time.Millisecond)
+	}
+	desc := description.Topology{
+		Kind: description.ReplicaSetWithPrimary,
+		Servers: []description.Server{
+			{Addr: address.Address("primary"), Kind: description.RSPrimary},
+			secondary("one"),
+			secondary("two"),
+			secondary("three"),
+		},
+	}
+	selector := description.CompositeSelector([]description.ServerSelector{
+		description.ReadPrefSelector(readpref.Secondary()),
+		description.LatencySelector(15 * time.Millisecond),
+	})
+
+	subCh := make(chan description.Topology, 1)
+	subCh <- desc
+	suitable, err := topo.selectServer(context.Background(), subCh, selector, nil)
+	noerr(t, err)
+	if len(suitable) != 3 {
+		t.Fatalf("Incorrect number of suitable servers. got %d; want %d", len(suitable), 3)
+	}
+
+	const iterations = 3000
+	counts := make(map[address.Address]int)
+	for i := 0; i < iterations; i++ {
+		counts[topo.pickServer(suitable).Addr]++
+	}
+
+	// each secondary is expected to be picked a third of the time
+	for _, s := range suitable {
+		if n := counts[s.Addr]; n < iterations/4 || n > iterations*5/12 {
+			t.Errorf("Selection is not evenly distributed. %s was picked %d times out of %d", s.Addr, n, iterations)
+		}
+	}
+}
@@ -8,11 +8,15 @@ package wiremessage
 
 import (
 	"errors"
+	"hash/crc32"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
+// crc32c is the table for computing OP_MSG checksums, which use the Castagnoli polynomial.
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
 // Msg represents the OP_MSG message of the MongoDB wire protocol.
 type Msg struct {
 	MsgHeader Header
@@ -44,10 +48,14 @@ func (m Msg) ValidateWireMessage() error {
 // AppendWireMesssage will set the MessageLength property of the MsgHeader if it is zero. It will also set the Opcode
 // to OP_MSG if it is zero. If either of these properties are non-zero and not correct, this method will return both the
 // []byte with the wire message appended to it and an invalid header error.
+//
+// If the ChecksumPresent flag is set, the CRC-32C checksum of the message is computed and appended; the Checksum
+// property is ignored.
 func (m Msg) AppendWireMessage(b []byte) ([]byte, error) {
 	var err error
 	err = m.MsgHeader.SetDefaults(m.Len(), OpMsg)
 
+	start := len(b)
 	b = m.MsgHeader.AppendHeader(b)
 	b = appendInt32(b, int32(m.FlagBits))
 
@@ -58,6 +66,10 @@ func (m Msg) AppendWireMessage(b []byte) ([]byte, error) {
 		b = section.AppendSection(b)
 	}
 
+	if m.FlagBits&ChecksumPresent > 0 {
+		b = appendInt32(b, int32(crc32.Checksum(b[start:], crc32c)))
+	}
+
 	return b, err
 }
 
@@ -83,6 +95,9 @@ func (m Msg) Len() int {
 }
 
 // UnmarshalWireMessage implements the Unmarshaler interface.
+//
+// If the ChecksumPresent flag is set, the checksum is validated against the message and an error is returned if they
+// don't match.
 func (m *Msg) UnmarshalWireMessage(b []byte) error {
 	var err error
 
@@ -164,6 +179,12 @@ func (m *Msg) UnmarshalWireMessage(b []byte) error {
 
 	if hasChecksum {
 		m.Checksum = uint32(readInt32(b, int32(position)))
+		if crc32.Checksum(b[:position], crc32c) != m.Checksum {
+			return Error{
+				Type:    ErrOpMsg,
+				Message: "checksum does not match message",
+			}
+		}
 	}
 
 	return nil
@@ -8,6 +8,7 @@ package wiremessage
 
 import (
 	"bytes"
+	"hash/crc32"
 	"testing"
 
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
@@ -106,4 +107,33 @@ func TestMsg(t *testing.T) {
 			})
 		}
 	})
+	t.Run("Checksum", func(t *testing.T) {
+		m := Msg{
+			FlagBits: ChecksumPresent,
+			Sections: oneSection(t),
+		}
+		b, err := m.AppendWireMessage(nil)
+		if err != nil {
+			t.Fatalf("Unexpected error appending wire message: %v", err)
+		}
+		if len(b) != m.Len() {
+			t.Fatalf("Message length does not match. got %d; want %d", len(b), m.Len())
+		}
+
+		var got Msg
+		if err = got.UnmarshalWireMessage(b); err != nil {
+			t.Fatalf("Unexpected error unmarshaling wire message: %v", err)
+		}
+		if want := crc32.Checksum(b[:len(b)-4], crc32.MakeTable(crc32.Castagnoli)); got.Checksum != want {
+			t.Errorf("Checksums do not match. got %d; want %d", got.Checksum, want)
+		}
+
+		corrupted := make([]byte, len(b))
+		copy(corrupted, b)
+		corrupted[len(corrupted)-1] ^= 0xff
+		err = got.UnmarshalWireMessage(corrupted)
+		if werr, ok := err.(Error); !ok || werr.Type != ErrOpMsg {
+			t.Errorf("Expected a wire message error for a corrupted checksum. got %v", err)
+		}
+	})
 }
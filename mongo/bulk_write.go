@@ -237,3 +237,41 @@ func dispatchToMongoModel(model dispatch.WriteModel) WriteModel {
 
 	return nil
 }
+
+// withDefaultCollation returns model with its collation set to collation if model is a delete,
+// update, or replace model that does not specify a collation.
+func withDefaultCollation(model dispatch.WriteModel, collation *options.Collation) dispatch.WriteModel {
+	if collation == nil {
+		return model
+	}
+
+	switch conv := model.(type) {
+	case dispatch.DeleteOneModel:
+		if conv.Collation == nil {
+			conv.Collation = collation
+		}
+		return conv
+	case dispatch.DeleteManyModel:
+		if conv.Collation == nil {
+			conv.Collation = collation
+		}
+		return conv
+	case dispatch.ReplaceOneModel:
+		if conv.Collation == nil {
+			conv.Collation = collation
+		}
+		return conv
+	case dispatch.UpdateOneModel:
+		if conv.Collation == nil {
+			conv.Collation = collation
+		}
+		return conv
+	case dispatch.UpdateManyModel:
+		if conv.Collation == nil {
+			conv.Collation = collation
+		}
+		return conv
+	}
+
+	return model
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/event"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
+	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/options"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
@@ -506,3 +507,43 @@ func TestCausalConsistency(t *testing.T) {
 		testhelpers.RequireNil(t, err, "$clusterTime found for invalid topology")
 	})
 }
+
+func TestCausalConsistency_ReadFromSecondary(t *testing.T) {
+	// A read from a secondary in a causally consistent session must observe an earlier write in the same session.
+	if testing.Short() {
+		t.Skip()
+	}
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip("skipping for non-replica set topology")
+	}
+	skipIfBelow36(t)
+
+	client := createTestClient(t)
+	db := client.Database("CausalConsistencySecondaryDB")
+	writeColl := db.Collection("coll", options.Collection().SetWriteConcern(writeconcern.New(writeconcern.WMajority())))
+	readColl := db.Collection("coll", options.Collection().
+		SetReadConcern(readconcern.Majority()).
+		SetReadPreference(readpref.Secondary()))
+	err := writeColl.Drop(ctx)
+	testhelpers.RequireNil(t, err, "error dropping collection: %s", err)
+
+	sess, err := client.StartSession()
+	testhelpers.RequireNil(t, err, "error starting session: %s", err)
+	defer sess.EndSession(ctx)
+
+	err = WithSession(ctx, sess, func(sessCtx SessionContext) error {
+		if _, err := writeColl.InsertOne(sessCtx, bsonx.Doc{{"_id", bsonx.Int32(1)}, {"x", bsonx.String("written")}}); err != nil {
+			return err
+		}
+
+		var doc bsonx.Doc
+		if err := readColl.FindOne(sessCtx, bsonx.Doc{{"_id", bsonx.Int32(1)}}).Decode(&doc); err != nil {
+			return err
+		}
+		if x := doc.Lookup("x").StringValue(); x != "written" {
+			t.Errorf("unexpected document read from secondary. got %v", doc)
+		}
+		return nil
+	})
+	testhelpers.RequireNil(t, err, "error running session operations: %s", err)
+}
@@ -9,6 +9,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/core/command"
@@ -21,14 +22,23 @@ import (
 // contain a resume token.
 var ErrMissingResumeToken = errors.New("cannot provide resume functionality when the resume token is missing")
 
+// ErrChangeStreamInvalidated is returned from the Err method of a change stream that was closed by
+// the server after delivering an invalidate event, e.g. because the watched collection was dropped.
+// The resume token of the invalidate event can be passed to ChangeStreamOptions.SetStartAfter to
+// open a new change stream.
+var ErrChangeStreamInvalidated = errors.New("change stream was invalidated")
+
 type changeStream struct {
 	pipeline    bsonx.Arr
 	options     []bsonx.Elem
+	cursorOpts  []bsonx.Elem
 	coll        *Collection
 	cursor      Cursor
 	session     *session.Client
 	clock       *session.ClusterClock
 	resumeToken bsonx.Doc
+	startAfter  bool // whether the stream was opened with startAfter and may continue past an invalidate
+	invalidated bool
 	err         error
 }
 
@@ -46,12 +56,13 @@ func newChangeStream(ctx context.Context, coll *Collection, pipeline interface{}
 	csOpts := options.MergeChangeStreamOptions(opts...)
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
 
 	changeStreamOptions := make(bsonx.Doc, 0)
+	var cursorOpts []bsonx.Elem
 	aggOpts := options.Aggregate()
 
 	if csOpts.BatchSize != nil {
@@ -69,10 +80,17 @@ func newChangeStream(ctx context.Context, coll *Collection, pipeline interface{}
 	}
 	if csOpts.MaxAwaitTime != nil {
 		aggOpts.MaxAwaitTime = csOpts.MaxAwaitTime
+		// kept so that getMore commands sent after resuming also return periodically
+		cursorOpts = append(cursorOpts, bsonx.Elem{
+			"maxTimeMS", bsonx.Int64(int64(*csOpts.MaxAwaitTime / time.Millisecond)),
+		})
 	}
 	if csOpts.ResumeAfter != nil {
 		changeStreamOptions = append(changeStreamOptions, bsonx.Elem{"resumeAfter", bsonx.Document(csOpts.ResumeAfter)})
 	}
+	if csOpts.StartAfter != nil {
+		changeStreamOptions = append(changeStreamOptions, bsonx.Elem{"startAfter", bsonx.Document(csOpts.StartAfter)})
+	}
 
 	pipelineArr = append(pipelineArr, bsonx.Val{})
 	copy(pipelineArr[1:], pipelineArr)
@@ -84,12 +102,14 @@ func newChangeStream(ctx context.Context, coll *Collection, pipeline interface{}
 	}
 
 	cs := &changeStream{
-		pipeline: pipelineArr,
-		options:  changeStreamOptions,
-		coll:     coll,
-		cursor:   cursor,
-		session:  sess,
-		clock:    coll.client.clock,
+		pipeline:   pipelineArr,
+		options:    changeStreamOptions,
+		cursorOpts: cursorOpts,
+		coll:       coll,
+		cursor:     cursor,
+		session:    sess,
+		clock:      coll.client.clock,
+		startAfter: csOpts.StartAfter != nil,
 	}
 
 	return cs, nil
@@ -101,33 +121,119 @@ func (cs *changeStream) ID() int64 {
 
 func (cs *changeStream) Next(ctx context.Context) bool {
 	if cs.cursor.Next(ctx) {
-		return true
+		return cs.recordEvent()
+	}
+	cs.updatePostBatchResumeToken()
+	if !cs.resume(ctx) {
+		return false
+	}
+
+	if cs.cursor.Next(ctx) {
+		return cs.recordEvent()
+	}
+	return false
+}
+
+func (cs *changeStream) TryNext(ctx context.Context) bool {
+	if cs.cursor.TryNext(ctx) {
+		return cs.recordEvent()
+	}
+	cs.updatePostBatchResumeToken()
+	if !cs.resume(ctx) {
+		return false
+	}
+
+	if cs.cursor.TryNext(ctx) {
+		return cs.recordEvent()
+	}
+	return false
+}
+
+// recordEvent records the resume token of the current event and whether it is an invalidate event.
+// The server closes the cursor after sending an invalidate event, so its resume token is kept to
+// allow restarting the stream with startAfter. The stream is closed if the event has no resume
+// token, since it could not be resumed from this point.
+func (cs *changeStream) recordEvent() bool {
+	br, err := cs.cursor.DecodeBytes()
+	if err != nil {
+		cs.err = err
+		return false
+	}
+
+	id, err := br.LookupErr("_id")
+	if err != nil {
+		_ = cs.Close(context.Background())
+		cs.err = ErrMissingResumeToken
+		return false
+	}
+
+	cs.resumeToken, err = bsonx.ReadDoc(id.Document())
+	if err != nil {
+		_ = cs.Close(context.Background())
+		cs.err = ErrMissingResumeToken
+		return false
+	}
+
+	if opType, ok := br.Lookup("operationType").StringValueOK(); ok && opType == "invalidate" {
+		cs.invalidated = true
+	}
+	return true
+}
+
+// updatePostBatchResumeToken records the postBatchResumeToken of the cursor's last batch as the
+// resume token. It is called once the batch is exhausted, so the token is at least as recent as
+// every event in the batch, and a stream that saw no events can still resume from the point the
+// server reached instead of from the last event. The token of an invalidate event is kept so the
+// stream can be restarted with startAfter.
+func (cs *changeStream) updatePostBatchResumeToken() {
+	if cs.invalidated {
+		return
+	}
+
+	token := cs.PostBatchResumeToken()
+	if token == nil {
+		return
+	}
+	if doc, err := bsonx.ReadDoc(token); err == nil {
+		cs.resumeToken = doc
 	}
+}
 
-	err := cs.cursor.Err()
+// IsResumableChangeStreamError returns true if err is an error after which a change stream
+// automatically resumes. It can be used with the value returned by the Err method of a change
+// stream to determine whether the stream failed with a resumable error that could not be recovered
+// from, or with an error that would never be retried.
+func IsResumableChangeStreamError(err error) bool {
 	if err == nil {
 		return false
 	}
 
 	switch t := err.(type) {
 	case command.Error:
-		if t.Code != errorCodeNotMaster && t.Code != errorCodeCursorNotFound {
-			return false
-		}
+		return t.Code == errorCodeNotMaster || t.Code == errorCodeCursorNotFound
 	}
+	return err != ErrChangeStreamInvalidated && err != ErrMissingResumeToken
+}
 
-	found := false
-
-	for i, opt := range cs.options {
-		if opt.Key == "resumeAfter" {
-			cs.options[i] = bsonx.Elem{"resumeAfter", bsonx.Document(cs.resumeToken)}
-			found = true
-			break
+// resume re-establishes the change stream after the underlying cursor failed with a resumable error.
+// It returns false if the cursor did not fail, the error is not resumable, or the stream could not be
+// re-established. After an invalidate event, the stream is only re-established if it was opened
+// with startAfter.
+func (cs *changeStream) resume(ctx context.Context) bool {
+	if cs.invalidated {
+		if !cs.startAfter {
+			cs.err = ErrChangeStreamInvalidated
+			return false
+		}
+		cs.setResumeOption("startAfter", "resumeAfter")
+		cs.invalidated = false
+	} else {
+		if !IsResumableChangeStreamError(cs.cursor.Err()) {
+			return false
+		}
+		if cs.resumeToken != nil {
+			cs.setResumeOption("resumeAfter", "startAfter")
 		}
-	}
-
-	if !found && cs.resumeToken != nil {
-		cs.options = append(cs.options, bsonx.Elem{"resumeAfter", bsonx.Document(cs.resumeToken)})
 	}
 
 	oldns := cs.coll.namespace()
@@ -155,21 +261,30 @@ func (cs *changeStream) Next(ctx context.Context) bool {
 
 	oldns = cs.coll.namespace()
 	aggCmd := command.Aggregate{
-		NS:       command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
-		Pipeline: cs.pipeline,
-		Session:  cs.session,
-		Clock:    cs.coll.client.clock,
+		NS:         command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+		Pipeline:   cs.pipeline,
+		CursorOpts: cs.cursorOpts,
+		Session:    cs.session,
+		Clock:      cs.coll.client.clock,
 	}
 
 	cur, err := aggCmd.RoundTrip(ctx, ss.Description(), ss, conn)
 	cs.cursor = cur
 	cs.err = err
 
-	if cs.err != nil {
-		return false
-	}
+	return cs.err == nil
+}
 
-	return cs.cursor.Next(ctx)
+// setResumeOption sets the key option of the $changeStream stage to the current resume token and
+// removes the other option, since the server rejects a stage that has both.
+func (cs *changeStream) setResumeOption(key, other string) {
+	opts := cs.options[:0]
+	for _, opt := range cs.options {
+		if opt.Key != key && opt.Key != other {
+			opts = append(opts, opt)
+		}
+	}
+	cs.options = append(opts, bsonx.Elem{key, bsonx.Document(cs.resumeToken)})
 }
 
 func (cs *changeStream) Decode(out interface{}) error {
@@ -181,25 +296,24 @@ func (cs *changeStream) Decode(out interface{}) error {
 	return bson.UnmarshalWithRegistry(cs.coll.registry, br, out)
 }
 
-func (cs *changeStream) DecodeBytes() (bson.Raw, error) {
-	br, err := cs.cursor.DecodeBytes()
-	if err != nil {
-		return nil, err
-	}
-
-	id, err := br.LookupErr("_id")
-	if err != nil {
-		_ = cs.Close(context.Background())
-		return nil, ErrMissingResumeToken
+func (cs *changeStream) Current() bson.Raw {
+	if cc, ok := cs.cursor.(command.CurrentCursor); ok {
+		return cc.Current()
 	}
+	return nil
+}
 
-	cs.resumeToken, err = bsonx.ReadDoc(id.Document())
-	if err != nil {
-		_ = cs.Close(context.Background())
-		return nil, ErrMissingResumeToken
+// PostBatchResumeToken returns the postBatchResumeToken of the cursor's most recent batch, or nil if
+// the server did not include one.
+func (cs *changeStream) PostBatchResumeToken() bson.Raw {
+	if rc, ok := cs.cursor.(command.ResumeTokenCursor); ok {
+		return rc.PostBatchResumeToken()
 	}
+	return nil
+}
 
-	return br, nil
+func (cs *changeStream) DecodeBytes() (bson.Raw, error) {
+	return cs.cursor.DecodeBytes()
 }
 
 func (cs *changeStream) Err() error {
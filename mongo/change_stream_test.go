@@ -8,13 +8,18 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/event"
+	"github.com/mongodb/mongo-go-driver/internal/testutil"
+	"github.com/mongodb/mongo-go-driver/options"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 	"github.com/stretchr/testify/require"
 )
@@ -165,8 +170,8 @@ func TestChangeStream_errorMissingResponseToken(t *testing.T) {
 	_, err = coll.InsertOne(context.Background(), bsonx.Doc{{"x", bsonx.Int32(1)}})
 	require.NoError(t, err)
 
-	getNextChange(changes)
-	require.Error(t, changes.Decode(&bsonx.Doc{}))
+	require.False(t, changes.Next(context.Background()))
+	require.Equal(t, ErrMissingResumeToken, changes.Err())
 }
 
 func TestChangeStream_resumableError(t *testing.T) {
@@ -267,3 +272,185 @@ func TestChangeStream_resumeAfterKillCursors(t *testing.T) {
 	var doc bsonx.Doc
 	require.NoError(t, changes.Decode(&doc))
 }
+
+func TestChangeStream_maxAwaitTime(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip()
+	}
+	skipIfBelow36(t)
+
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip()
+	}
+
+	var mu sync.Mutex
+	var getMores []bsonx.Doc
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName == "getMore" {
+				mu.Lock()
+				getMores = append(getMores, cse.Command)
+				mu.Unlock()
+			}
+		},
+	}
+
+	client := createSessionsMonitoredClient(t, monitor)
+	coll := client.Database(testutil.DBName(t)).Collection(testutil.ColName(t))
+	defer func() { _ = coll.Drop(context.Background()) }()
+
+	// Ensure the database is created.
+	_, err := coll.InsertOne(context.Background(), bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	changes, err := coll.Watch(context.Background(), nil, options.ChangeStream().SetMaxAwaitTime(100*time.Millisecond))
+	require.NoError(t, err)
+	defer func() { _ = changes.Close(context.Background()) }()
+
+	// each getMore on a quiet stream waits at most maxAwaitTime before returning an empty batch
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.False(t, changes.Next(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, getMores)
+	for _, cmd := range getMores {
+		maxTime, err := cmd.LookupErr("maxTimeMS")
+		require.NoError(t, err)
+		require.Equal(t, int64(100), maxTime.Int64())
+	}
+}
+
+func TestChangeStream_invalidate(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip()
+	}
+	skipIfBelow36(t)
+
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip()
+	}
+
+	coll := createTestCollection(t, nil, nil)
+
+	// Ensure the database is created.
+	_, err := coll.InsertOne(context.Background(), bsonx.Doc{{"y", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	changes, err := coll.Watch(context.Background(), nil)
+	require.NoError(t, err)
+	defer func() { _ = changes.Close(context.Background()) }()
+
+	require.NoError(t, coll.Drop(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// servers 4.0+ send a drop event before the invalidate event
+	var opType string
+	for opType != "invalidate" {
+		require.True(t, changes.Next(ctx), "expected an invalidate event, got error %v", changes.Err())
+
+		br, err := changes.DecodeBytes()
+		require.NoError(t, err)
+		opType = br.Lookup("operationType").StringValue()
+	}
+
+	require.False(t, changes.Next(ctx))
+	require.Equal(t, ErrChangeStreamInvalidated, changes.Err())
+	require.False(t, IsResumableChangeStreamError(changes.Err()))
+}
+
+func TestChangeStream_postBatchResumeToken(t *testing.T) {
+	t.Parallel()
+
+	token := bsonx.Doc{{"_data", bsonx.String("token")}}
+	raw, err := token.MarshalBSON()
+	require.NoError(t, err)
+
+	t.Run("empty batch updates the resume token", func(t *testing.T) {
+		cs := &changeStream{
+			cursor:      &mockCursor{postBatchResumeToken: raw},
+			resumeToken: bsonx.Doc{{"_data", bsonx.String("event")}},
+		}
+
+		require.False(t, cs.TryNext(context.Background()))
+		require.NoError(t, cs.Err())
+		require.Equal(t, token, cs.resumeToken)
+	})
+	t.Run("invalidate token is kept", func(t *testing.T) {
+		invalidate := bsonx.Doc{{"_data", bsonx.String("invalidate")}}
+		cs := &changeStream{
+			cursor:      &mockCursor{postBatchResumeToken: raw},
+			resumeToken: invalidate,
+			invalidated: true,
+		}
+
+		require.False(t, cs.TryNext(context.Background()))
+		require.Equal(t, invalidate, cs.resumeToken)
+	})
+}
+
+func TestChangeStream_nextRecordsResumeToken(t *testing.T) {
+	t.Parallel()
+
+	token := bsonx.Doc{{"_data", bsonx.String("event")}}
+
+	t.Run("event updates the resume token", func(t *testing.T) {
+		event, err := bsonx.Doc{{"_id", bsonx.Document(token)}, {"operationType", bsonx.String("insert")}}.MarshalBSON()
+		require.NoError(t, err)
+		cs := &changeStream{cursor: &mockCursor{numDocs: 1, current: event}}
+
+		require.True(t, cs.Next(context.Background()))
+		require.Equal(t, token, cs.resumeToken)
+		require.False(t, cs.invalidated)
+	})
+	t.Run("invalidate event is recorded", func(t *testing.T) {
+		event, err := bsonx.Doc{{"_id", bsonx.Document(token)}, {"operationType", bsonx.String("invalidate")}}.MarshalBSON()
+		require.NoError(t, err)
+		cs := &changeStream{cursor: &mockCursor{numDocs: 1, current: event}}
+
+		require.True(t, cs.TryNext(context.Background()))
+		require.Equal(t, token, cs.resumeToken)
+		require.True(t, cs.invalidated)
+	})
+	t.Run("missing resume token closes the stream", func(t *testing.T) {
+		event, err := bsonx.Doc{{"operationType", bsonx.String("insert")}}.MarshalBSON()
+		require.NoError(t, err)
+		mc := &mockCursor{numDocs: 1, current: event}
+		cs := &changeStream{cursor: mc}
+
+		require.False(t, cs.Next(context.Background()))
+		require.Equal(t, ErrMissingResumeToken, cs.Err())
+		require.Equal(t, 1, mc.closed)
+	})
+}
+
+func TestIsResumableChangeStreamError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		err       error
+		resumable bool
+	}{
+		{"nil", nil, false},
+		{"network error", errors.New("connection reset"), true},
+		{"not master", command.Error{Code: errorCodeNotMaster}, true},
+		{"cursor not found", command.Error{Code: errorCodeCursorNotFound}, true},
+		{"other server error", command.Error{Code: 2}, false},
+		{"invalidated", ErrChangeStreamInvalidated, false},
+		{"missing resume token", ErrMissingResumeToken, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.resumable, IsResumableChangeStreamError(tc.err))
+		})
+	}
+}
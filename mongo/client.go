@@ -24,6 +24,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
 	"github.com/mongodb/mongo-go-driver/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
 const defaultLocalThreshold = 15 * time.Millisecond
@@ -35,6 +36,7 @@ type Client struct {
 	topology        *topology.Topology
 	connString      connstring.ConnString
 	localThreshold  time.Duration
+	retryReads      bool
 	retryWrites     bool
 	clock           *session.ClusterClock
 	readPreference  *readpref.ReadPref
@@ -42,6 +44,7 @@ type Client struct {
 	writeConcern    *writeconcern.WriteConcern
 	registry        *bsoncodec.Registry
 	marshaller      BSONAppender
+	timeout         time.Duration
 }
 
 // Connect creates a new Client and then initializes it using the Connect method.
@@ -85,8 +88,10 @@ func NewClientFromConnString(cs connstring.ConnString) (*Client, error) {
 	return newClient(cs)
 }
 
-// Connect initializes the Client by starting background monitoring goroutines.
-// This method must be called before a Client can be used.
+// Connect initializes the Client by starting background monitoring goroutines
+// and opening the minimum number of connections configured for each server's
+// pool. This method must be called before a Client can be used; operations
+// attempted beforehand return ErrClientNotConnected.
 func (c *Client) Connect(ctx context.Context) error {
 	err := c.topology.Connect(ctx)
 	if err != nil {
@@ -94,42 +99,47 @@ func (c *Client) Connect(ctx context.Context) error {
 	}
 
 	return nil
-
 }
 
 // Disconnect closes sockets to the topology referenced by this Client. It will
-// shut down any monitoring goroutines, close the idle connection pool, and will
-// wait until all the in use connections have been returned to the connection
-// pool and closed before returning. If the context expires via cancellation,
-// deadline, or timeout before the in use connections have returned, the in use
-// connections will be closed, resulting in the failure of any in flight read
-// or write operations. If this method returns with no errors, all connections
-// associated with this Client have been closed.
+// end the Client's implicit sessions, shut down any monitoring goroutines,
+// close the idle connection pool, and will wait until all the in use
+// connections have been returned to the connection pool and closed before
+// returning. If the context expires via cancellation, deadline, or timeout
+// before the in use connections have returned, the in use connections will be
+// closed, resulting in the failure of any in flight read or write operations.
+// If this method returns with no errors, all connections associated with this
+// Client have been closed. Operations attempted after Disconnect return
+// ErrClientDisconnected.
 func (c *Client) Disconnect(ctx context.Context) error {
 	c.endSessions(ctx)
 	return replaceTopologyErr(c.topology.Disconnect(ctx))
 }
 
-// Ping verifies that the client can connect to the topology.
-// If readPreference is nil then will use the client's default read
-// preference.
+// Ping verifies that the client can connect to the topology by selecting a
+// server that matches the read preference and running the ping command on it.
+// If readPreference is nil then the nearest server will be used.
 func (c *Client) Ping(ctx context.Context, rp *readpref.ReadPref) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
 	if rp == nil {
-		rp = c.readPreference
+		rp = readpref.Nearest()
 	}
 
-	_, err := c.topology.SelectServer(ctx, description.ReadPrefSelector(rp))
-	return replaceTopologyErr(err)
+	_, err := c.Database("admin").RunCommand(
+		ctx,
+		bsonx.Doc{{"ping", bsonx.Int32(1)}},
+		options.RunCmd().SetReadPreference(rp),
+	)
+	return err
 }
 
 // StartSession starts a new session.
 func (c *Client) StartSession(opts ...*options.SessionOptions) (Session, error) {
 	if c.topology.SessionPool == nil {
-		return nil, ErrClientDisconnected
+		return nil, ErrClientNotConnected
 	}
 
 	sopts := options.MergeSessionOptions(opts...)
@@ -183,8 +193,24 @@ func newClient(cs connstring.ConnString, opts ...*options.ClientOptions) (*Clien
 		topologyOptions: clientOpt.TopologyOptions,
 		connString:      clientOpt.ConnString,
 		localThreshold:  defaultLocalThreshold,
+		retryReads:      true,
 		registry:        clientOpt.Registry,
 	}
+	if err := command.ValidateAppName(client.connString.AppName); err != nil {
+		return nil, err
+	}
+	if client.connString.LocalThresholdSet {
+		client.localThreshold = client.connString.LocalThreshold
+	}
+	if client.connString.RetryReadsSet {
+		client.retryReads = client.connString.RetryReads
+	}
+	if clientOpt.RetryReads != nil {
+		client.retryReads = *clientOpt.RetryReads
+	}
+	if clientOpt.Timeout != nil {
+		client.timeout = *clientOpt.Timeout
+	}
 
 	clientID, err := uuid.New()
 	if err != nil {
@@ -336,6 +362,9 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := c.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
 	err := c.ValidSession(sess)
@@ -363,7 +392,7 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 		opts...,
 	)
 	if err != nil {
-		return ListDatabasesResult{}, replaceTopologyErr(err)
+		return ListDatabasesResult{}, replaceTopologyErr(timeoutErr(err, deadline))
 	}
 
 	return (ListDatabasesResult{}).fromResult(res), nil
@@ -386,6 +415,32 @@ func (c *Client) ListDatabaseNames(ctx context.Context, filter interface{}, opts
 	return names, nil
 }
 
+// operationDeadline returns the deadline of an operation started now with the given timeout, which
+// overrides the client's timeout. A zero time is returned if the operation has no timeout.
+func (c *Client) operationDeadline(timeout *time.Duration) time.Time {
+	d := c.timeout
+	if timeout != nil {
+		d = *timeout
+	}
+	if d <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(d)
+}
+
+// withTimeout returns a copy of ctx bounded by the deadline of an operation started now with the
+// given timeout, along with that deadline. The commands sent with the returned context carry a
+// maxTimeMS for the time remaining. If the operation has no timeout, ctx is returned unchanged with a
+// zero deadline.
+func (c *Client) withTimeout(ctx context.Context, timeout *time.Duration) (context.Context, context.CancelFunc, time.Time) {
+	deadline := c.operationDeadline(timeout)
+	if deadline.IsZero() {
+		return ctx, func() {}, deadline
+	}
+	ctx, cancel := command.WithTimeoutDeadline(ctx, deadline)
+	return ctx, cancel, deadline
+}
+
 // WithSession allows a user to start a session themselves and manage
 // its lifetime. The only way to provide a session to a CRUD method is
 // to invoke that CRUD method with the mongo.SessionContext within the
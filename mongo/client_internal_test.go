@@ -10,6 +10,8 @@ import (
 	"context"
 	"os"
 	"path"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"fmt"
@@ -22,6 +24,10 @@ import (
 
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/event"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
@@ -72,6 +78,68 @@ func TestClientOptions(t *testing.T) {
 	require.Equal(t, "test", c.connString.ReplicaSet)
 }
 
+func TestClient_LocalThreshold(t *testing.T) {
+	t.Parallel()
+
+	servers := []description.Server{
+		{Addr: address.Address("localhost:27017"), Kind: description.RSSecondary, AverageRTT: 5 * time.Millisecond, AverageRTTSet: true},
+		{Addr: address.Address("localhost:27018"), Kind: description.RSSecondary, AverageRTT: 18 * time.Millisecond, AverageRTTSet: true},
+		{Addr: address.Address("localhost:27019"), Kind: description.RSSecondary, AverageRTT: 30 * time.Millisecond, AverageRTTSet: true},
+		{Addr: address.Address("localhost:27020"), Kind: description.RSSecondary, AverageRTT: 40 * time.Millisecond, AverageRTTSet: true},
+	}
+	topo := description.Topology{Kind: description.ReplicaSetNoPrimary, Servers: servers}
+
+	testCases := []struct {
+		name     string
+		uri      string
+		opts     *options.ClientOptions
+		expected []description.Server
+	}{
+		{"default", "mongodb://localhost", nil, servers[:2]},
+		{"option", "mongodb://localhost", options.Client().SetLocalThreshold(30 * time.Millisecond), servers[:3]},
+		{"connection string", "mongodb://localhost/?localThresholdMS=0", nil, servers[:1]},
+		{"option overrides connection string", "mongodb://localhost/?localThresholdMS=0",
+			options.Client().SetLocalThreshold(time.Second), servers},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClientWithOptions(tc.uri, tc.opts)
+			require.NoError(t, err)
+
+			coll := c.Database("foo").Collection("bar", options.Collection().SetReadPreference(readpref.Nearest()))
+			selected, err := coll.readSelector.SelectServer(topo, servers)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, selected)
+		})
+	}
+}
+
+func TestClient_RetryReads(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		uri      string
+		opts     *options.ClientOptions
+		expected bool
+	}{
+		{"default", "mongodb://localhost", nil, true},
+		{"option", "mongodb://localhost", options.Client().SetRetryReads(false), false},
+		{"connection string", "mongodb://localhost/?retryReads=false", nil, false},
+		{"option overrides connection string", "mongodb://localhost/?retryReads=false",
+			options.Client().SetRetryReads(true), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClientWithOptions(tc.uri, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, c.retryReads)
+		})
+	}
+}
+
 func TestClient_TLSConnection(t *testing.T) {
 	t.Parallel()
 
@@ -104,6 +172,16 @@ func TestClient_TLSConnection(t *testing.T) {
 
 }
 
+func TestClient_NilTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	opts := options.Client().SetTLSConfig(nil)
+	require.Empty(t, opts.TopologyOptions)
+	require.False(t, opts.ConnString.SSLSet)
+	_, err := NewClientWithOptions("mongodb://localhost", opts)
+	require.NoError(t, err)
+}
+
 func TestClient_X509Auth(t *testing.T) {
 	t.Parallel()
 
@@ -199,19 +277,124 @@ func TestClient_ReplaceTopologyError(t *testing.T) {
 	require.NotNil(t, c)
 
 	_, err = c.StartSession()
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = c.ListDatabases(ctx, nil)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	err = c.Ping(ctx, nil)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	err = c.Disconnect(ctx)
 	require.Equal(t, err, ErrClientDisconnected)
 
 }
 
+func TestClient_DisconnectClosesConnections(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	var created, closed int64
+	monitor := &event.PoolMonitor{Event: func(evt *event.PoolEvent) {
+		switch evt.Type {
+		case event.ConnectionCreated:
+			atomic.AddInt64(&created, 1)
+		case event.ConnectionClosed:
+			atomic.AddInt64(&closed, 1)
+		}
+	}}
+
+	cs := testutil.ConnString(t)
+	c, err := NewClientWithOptions(cs.String(), options.Client().SetPoolMonitor(monitor).SetMinPoolSize(2))
+	require.NoError(t, err)
+	require.NoError(t, c.Connect(ctx))
+
+	coll := c.Database("TestClient_DisconnectClosesConnections").Collection("test")
+	_, err = coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Disconnect(ctx))
+	require.NotZero(t, atomic.LoadInt64(&created))
+	require.Equal(t, atomic.LoadInt64(&created), atomic.LoadInt64(&closed))
+
+	_, err = coll.InsertOne(ctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+	require.Equal(t, ErrClientDisconnected, err)
+
+	err = c.Ping(ctx, nil)
+	require.Equal(t, ErrClientDisconnected, err)
+}
+
+func TestClient_ServerAPI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil options", func(t *testing.T) {
+		opts := options.Client().SetServerAPIOptions(nil)
+		require.Empty(t, opts.TopologyOptions)
+		_, err := NewClientWithOptions("mongodb://localhost", opts)
+		require.NoError(t, err)
+	})
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	serverVersion, err := getServerVersion(createTestDatabase(t, nil))
+	require.NoError(t, err)
+	if compareVersions(t, serverVersion, "5.0") < 0 {
+		t.Skip()
+	}
+
+	t.Run("fields attached to find", func(t *testing.T) {
+		var mu sync.Mutex
+		var finds []bsonx.Doc
+		monitor := &event.CommandMonitor{
+			Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+				if cse.CommandName == "find" {
+					mu.Lock()
+					finds = append(finds, cse.Command)
+					mu.Unlock()
+				}
+			},
+		}
+
+		serverAPI := options.ServerAPI(options.ServerAPIVersion1).SetStrict(true).SetDeprecationErrors(true)
+		cs := testutil.ConnString(t)
+		c, err := NewClientWithOptions(cs.String(),
+			options.Client().SetMonitor(monitor).SetServerAPIOptions(serverAPI))
+		require.NoError(t, err)
+		require.NoError(t, c.Connect(ctx))
+		defer func() { _ = c.Disconnect(ctx) }()
+
+		cur, err := c.Database("TestClient_ServerAPI").Collection("test").Find(ctx, bsonx.Doc{})
+		require.NoError(t, err)
+		require.NoError(t, cur.Close(ctx))
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, finds, 1)
+		require.Equal(t, "1", finds[0].Lookup("apiVersion").StringValue())
+		require.True(t, finds[0].Lookup("apiStrict").Boolean())
+		require.True(t, finds[0].Lookup("apiDeprecationErrors").Boolean())
+	})
+	t.Run("strict rejects commands outside the API", func(t *testing.T) {
+		serverAPI := options.ServerAPI(options.ServerAPIVersion1).SetStrict(true)
+		cs := testutil.ConnString(t)
+		c, err := NewClientWithOptions(cs.String(), options.Client().SetServerAPIOptions(serverAPI))
+		require.NoError(t, err)
+		require.NoError(t, c.Connect(ctx))
+		defer func() { _ = c.Disconnect(ctx) }()
+
+		_, err = c.Database("admin").RunCommand(ctx, bsonx.Doc{{"buildInfo", bsonx.Int32(1)}})
+		require.Error(t, err)
+		cmdErr, ok := err.(command.Error)
+		require.True(t, ok, "expected a command.Error, got %T: %v", err, err)
+		require.Equal(t, "APIStrictError", cmdErr.Name)
+	})
+}
+
 func TestClient_ListDatabases_noFilter(t *testing.T) {
 	t.Parallel()
 
@@ -438,3 +621,25 @@ func TestClient_Ping_InvalidHost(t *testing.T) {
 	err = c.Ping(ctx, nil)
 	require.NotNil(t, err)
 }
+
+func TestClient_Ping_ContextDeadline(t *testing.T) {
+	c, err := NewClient("mongodb://nohost:27017")
+	require.NoError(t, err)
+
+	err = c.Connect(ctx)
+	require.NoError(t, err)
+	defer func() {
+		// endSessions waits for server selection, which cannot succeed against nohost.
+		disconnectCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_ = c.Disconnect(disconnectCtx)
+	}()
+
+	pingCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = c.Ping(pingCtx, readpref.Primary())
+	require.Error(t, err)
+	require.True(t, time.Since(start) < 5*time.Second, "ping did not respect the context deadline")
+}
@@ -9,11 +9,14 @@ package mongo
 import (
 	"context"
 	"net"
+	"strings"
 	"sync/atomic"
 	"testing"
 
 	"time"
 
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
 	"github.com/mongodb/mongo-go-driver/core/tag"
 	"github.com/mongodb/mongo-go-driver/core/topology"
@@ -70,6 +73,29 @@ func TestClientOptions_doesNotAlterConnectionString(t *testing.T) {
 	}
 }
 
+func TestClientOptions_appNameTooLong(t *testing.T) {
+	t.Parallel()
+
+	_, err := newClient(connstring.ConnString{}, options.Client().SetAppName(strings.Repeat("a", 129)))
+	require.Equal(t, command.ErrAppNameTooLong, err)
+
+	_, err = NewClientWithOptions("mongodb://localhost/?appName=" + strings.Repeat("a", 129))
+	require.Equal(t, command.ErrAppNameTooLong, err)
+}
+
+func TestClientOptions_minPoolSizeTooLarge(t *testing.T) {
+	t.Parallel()
+
+	_, err := newClient(connstring.ConnString{}, options.Client().SetMinPoolSize(10).SetMaxPoolSize(5))
+	require.Error(t, err)
+
+	_, err = newClient(connstring.ConnString{}, options.Client().SetMinPoolSize(10).SetMaxPoolSize(0))
+	require.NoError(t, err)
+
+	_, err = newClient(connstring.ConnString{}, options.Client().SetMinPoolSize(150))
+	require.Equal(t, connection.ErrMinSizeLargerThanCapacity, err)
+}
+
 func TestClientOptions_chainAll(t *testing.T) {
 	t.Parallel()
 	readPrefMode, err := readpref.ModeFromString("secondary")
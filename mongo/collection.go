@@ -9,8 +9,8 @@ package mongo
 import (
 	"context"
 	"errors"
-	"strings"
 
+	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/description"
@@ -34,6 +34,8 @@ type Collection struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+	collation      *options.Collation // the default collation for operations that don't specify one
+	err            error              // the error returned by operations if the name is invalid
 }
 
 func newCollection(db *Database, name string, opts ...*options.CollectionOptions) *Collection {
@@ -79,6 +81,11 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		readSelector:   readSelector,
 		writeSelector:  writeSelector,
 		registry:       reg,
+		collation:      collOpt.Collation,
+		err:            db.err,
+	}
+	if coll.err == nil {
+		coll.err = validateCollectionName(db.name, name)
 	}
 
 	return coll
@@ -95,9 +102,20 @@ func (coll *Collection) copy() *Collection {
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		registry:       coll.registry,
+		collation:      coll.collation,
+		err:            coll.err,
 	}
 }
 
+// validate returns an error if the collection's name is invalid or if sess belongs to a different
+// client.
+func (coll *Collection) validate(sess *session.Client) error {
+	if coll.err != nil {
+		return coll.err
+	}
+	return coll.client.ValidSession(sess)
+}
+
 // Clone creates a copy of this collection with updated options, if any are given.
 func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection, error) {
 	copyColl := coll.copy()
@@ -119,6 +137,10 @@ func (coll *Collection) Clone(opts ...*options.CollectionOptions) (*Collection,
 		copyColl.registry = optsColl.Registry
 	}
 
+	if optsColl.Collation != nil {
+		copyColl.collation = optsColl.Collation
+	}
+
 	copyColl.readSelector = description.CompositeSelector([]description.ServerSelector{
 		description.ReadPrefSelector(copyColl.readPreference),
 		description.LatencySelector(copyColl.client.localThreshold),
@@ -155,16 +177,19 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := coll.client.ValidSession(sess)
+	err := coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
 
 	dispatchModels := make([]dispatch.WriteModel, len(models))
 	for i, model := range models {
-		dispatchModels[i] = model.convertModel()
+		dispatchModels[i] = withDefaultCollation(model.convertModel(), coll.collation)
 	}
 
 	res, err := dispatch.BulkWrite(
@@ -172,7 +197,7 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 		coll.namespace(),
 		dispatchModels,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
@@ -191,7 +216,7 @@ func (coll *Collection) BulkWrite(ctx context.Context, models []WriteModel,
 			}
 		}
 
-		return &BulkWriteResult{}, replaceTopologyErr(err)
+		return &BulkWriteResult{}, replaceTopologyErr(timeoutErr(err, deadline))
 	}
 
 	return &BulkWriteResult{
@@ -220,6 +245,9 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	doc, err := transformDocument(coll.registry, document)
 	if err != nil {
 		return nil, err
@@ -229,7 +257,7 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -257,14 +285,14 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 	res, err := dispatch.Insert(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
 		insertOpts...,
 	)
 
-	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, err)
+	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, timeoutErr(err, deadline))
 	if rr&rrOne == 0 {
 		return nil, err
 	}
@@ -275,9 +303,11 @@ func (coll *Collection) InsertOne(ctx context.Context, document interface{},
 // InsertMany inserts the provided documents. A user can supply a custom context to this
 // method.
 //
-// Currently, batching is not implemented for this operation. Because of this, extremely large
-// sets of documents will not fit into a single BSON document to be sent to the server, so the
-// operation will fail.
+// Documents are split into batches that fit within the server's limits. If some documents
+// fail to be inserted, a BulkWriteException is returned alongside the result. The Index of each
+// write error is the position of the failed document in documents, and the InsertedIDs entries of
+// documents that were not inserted are nil. An unordered insert continues past failures, while an
+// ordered insert stops at the first one.
 //
 // This method uses TransformDocument to turn the documents parameter into a
 // *bsonx.Document. See TransformDocument for the list of valid types for
@@ -289,6 +319,9 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	result := make([]interface{}, len(documents))
 	docs := make([]bsonx.Doc, len(documents))
 
@@ -305,7 +338,7 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 
 	sess := sessionFromContext(ctx)
 
-	err := coll.client.ValidSession(sess)
+	err := coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -327,7 +360,7 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 	res, err := dispatch.Insert(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
@@ -339,7 +372,7 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 	case command.ErrUnacknowledgedWrite:
 		return &InsertManyResult{InsertedIDs: result}, ErrUnacknowledgedWrite
 	default:
-		return nil, replaceTopologyErr(err)
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
 	}
 	if len(res.WriteErrors) > 0 || res.WriteConcernError != nil {
 		bwErrors := make([]BulkWriteError, 0, len(res.WriteErrors))
@@ -354,6 +387,25 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 			})
 		}
 
+		// Clear the IDs of the documents that were not inserted. An ordered insert stops at the
+		// first failure, so none of the documents after it were inserted either.
+		ordered := true
+		if insertOpts := options.MergeInsertManyOptions(opts...); insertOpts.Ordered != nil {
+			ordered = *insertOpts.Ordered
+		}
+		for _, we := range res.WriteErrors {
+			if we.Index < 0 || we.Index >= len(result) {
+				continue
+			}
+			if ordered {
+				for i := we.Index; i < len(result); i++ {
+					result[i] = nil
+				}
+				break
+			}
+			result[we.Index] = nil
+		}
+
 		err = BulkWriteException{
 			WriteErrors:       bwErrors,
 			WriteConcernError: convertWriteConcernError(res.WriteConcernError),
@@ -376,6 +428,9 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
 		return nil, err
@@ -389,7 +444,7 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -399,6 +454,10 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 		wc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.DeleteOptions{options.Delete().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Delete{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -411,14 +470,14 @@ func (coll *Collection) DeleteOne(ctx context.Context, filter interface{},
 	res, err := dispatch.Delete(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
 		opts...,
 	)
 
-	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, err)
+	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, timeoutErr(err, deadline))
 	if rr&rrOne == 0 {
 		return nil, err
 	}
@@ -439,6 +498,9 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
 		return nil, err
@@ -447,7 +509,7 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -457,6 +519,10 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 		wc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.DeleteOptions{options.Delete().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Delete{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -469,14 +535,14 @@ func (coll *Collection) DeleteMany(ctx context.Context, filter interface{},
 	res, err := dispatch.Delete(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		false,
 		opts...,
 	)
 
-	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, err)
+	rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, timeoutErr(err, deadline))
 	if rr&rrMany == 0 {
 		return nil, err
 	}
@@ -491,6 +557,9 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	updateDocs := []bsonx.Doc{
 		{
 			{"q", bsonx.Document(filter)},
@@ -504,6 +573,10 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 		wc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.UpdateOptions{options.Update().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Update{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -516,14 +589,15 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 	r, err := dispatch.Update(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
+		coll.registry,
 		coll.client.retryWrites,
 		opts...,
 	)
 	if err != nil && err != command.ErrUnacknowledgedWrite {
-		return nil, replaceTopologyErr(err)
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
 	}
 
 	res := &UpdateResult{
@@ -535,7 +609,7 @@ func (coll *Collection) updateOrReplaceOne(ctx context.Context, filter,
 		res.MatchedCount--
 	}
 
-	rr, err := processWriteError(r.WriteConcernError, r.WriteErrors, err)
+	rr, err := processWriteError(r.WriteConcernError, r.WriteErrors, timeoutErr(err, deadline))
 	if rr&rrOne == 0 {
 		return nil, err
 	}
@@ -565,13 +639,13 @@ func (coll *Collection) UpdateOne(ctx context.Context, filter interface{}, updat
 		return nil, err
 	}
 
-	if err := ensureDollarKey(u); err != nil {
+	if err = ensureDollarKey(u); err != nil {
 		return nil, err
 	}
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -592,6 +666,9 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
 		return nil, err
@@ -616,7 +693,7 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -626,6 +703,10 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		wc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.UpdateOptions{options.Update().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Update{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -638,14 +719,15 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 	r, err := dispatch.Update(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
+		coll.registry,
 		false,
 		opts...,
 	)
 	if err != nil && err != command.ErrUnacknowledgedWrite {
-		return nil, replaceTopologyErr(err)
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
 	}
 	res := &UpdateResult{
 		MatchedCount:  r.MatchedCount,
@@ -657,7 +739,7 @@ func (coll *Collection) UpdateMany(ctx context.Context, filter interface{}, upda
 		res.MatchedCount--
 	}
 
-	rr, err := processWriteError(r.WriteConcernError, r.WriteErrors, err)
+	rr, err := processWriteError(r.WriteConcernError, r.WriteErrors, timeoutErr(err, deadline))
 	if rr&rrMany == 0 {
 		return nil, err
 	}
@@ -687,13 +769,13 @@ func (coll *Collection) ReplaceOne(ctx context.Context, filter interface{},
 		return nil, err
 	}
 
-	if len(r) > 0 && strings.HasPrefix(r[0].Key, "$") {
-		return nil, errors.New("replacement document cannot contains keys beginning with '$")
+	if err = ensureNoDollarKey(r); err != nil {
+		return nil, err
 	}
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -731,10 +813,15 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	}
 
 	aggOpts := options.MergeAggregateOptions(opts...)
+	if aggOpts.Collation == nil {
+		aggOpts.Collation = coll.collation
+	}
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, aggOpts.Timeout)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -763,15 +850,160 @@ func (coll *Collection) Aggregate(ctx context.Context, pipeline interface{},
 	cursor, err := dispatch.Aggregate(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.readSelector),
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
+		coll.client.retryReads,
 		aggOpts,
 	)
+	if err != nil {
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
+	}
+
+	return wrapCursor(newTimeoutCursor(cursor, deadline, aggOpts.MaxAwaitTime != nil), aggOpts.AutoCloseOnError, aggOpts.KillCursorOnCancel), nil
+}
+
+// AggregateOne runs an aggregation framework pipeline that is expected to yield a single document,
+// such as one ending in a $group or $count stage. Decoding the returned DocumentResult reads the
+// first document and closes the cursor. If the pipeline yields no documents, Decode returns
+// ErrNoDocuments. A user can supply a custom context to this method, or nil to default to
+// context.Background().
+//
+// See Aggregate for the list of valid types for pipeline.
+func (coll *Collection) AggregateOne(ctx context.Context, pipeline interface{},
+	opts ...*options.AggregateOptions) *DocumentResult {
+
+	cursor, err := coll.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return &DocumentResult{err: err}
+	}
+
+	return &DocumentResult{cur: cursor, reg: coll.registry}
+}
+
+// AggregateOut runs an aggregation framework pipeline whose results are written to the outColl
+// collection in the same database by an appended $out stage, and returns a handle for that collection
+// with the same options as this one. A user can supply a custom context to this method, or nil to
+// default to context.Background().
+//
+// The pipeline must not already contain a $out or $merge stage. See Aggregate for the list of valid
+// types for pipeline.
+func (coll *Collection) AggregateOut(ctx context.Context, pipeline interface{}, outColl string,
+	opts ...*options.AggregateOptions) (*Collection, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	pipelineArr, err := transformAggregatePipeline(coll.registry, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stage := range pipelineArr {
+		doc, ok := stage.DocumentOK()
+		if !ok {
+			continue
+		}
+		if _, err := doc.LookupErr("$out"); err == nil {
+			return nil, ErrPipelineHasWriteStage
+		}
+		if _, err := doc.LookupErr("$merge"); err == nil {
+			return nil, ErrPipelineHasWriteStage
+		}
+	}
+	pipelineArr = append(pipelineArr, bsonx.Document(bsonx.Doc{{"$out", bsonx.String(outColl)}}))
 
-	return cursor, replaceTopologyErr(err)
+	// the $out stage routes the aggregation to a writable server and the cursor it returns is empty,
+	// but it's iterated to the end and closed so that a cursor error is reported and the server
+	// cursor is never left open
+	cursor, err := coll.Aggregate(ctx, pipelineArr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = forEach(ctx, cursor, func(DecodeFunc) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+
+	out := coll.copy()
+	out.name = outColl
+	return out, nil
+}
+
+// MapReduce runs a map-reduce aggregation over the collection using the given JavaScript map and
+// reduce functions. A user can supply a custom context to this method, or nil to default to
+// context.Background().
+//
+// If no output collection is specified the results are returned inline and the cursor iterates over
+// them. Otherwise the results are written to the output collection and the cursor iterates over the
+// documents in that collection.
+//
+// See https://docs.mongodb.com/manual/core/map-reduce/.
+func (coll *Collection) MapReduce(ctx context.Context, mapFn, reduceFn string,
+	opts ...*options.MapReduceOptions) (Cursor, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
+	mrOpts := options.MergeMapReduceOptions(opts...)
+	if mrOpts.Collation == nil {
+		mrOpts.Collation = coll.collation
+	}
+
+	sess := sessionFromContext(ctx)
+
+	err := coll.validate(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	wc := coll.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	rc := coll.readConcern
+	if sess != nil && (sess.TransactionInProgress()) {
+		rc = nil
+	}
+
+	oldns := coll.namespace()
+	cmd := command.MapReduce{
+		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+		Map:          mapFn,
+		Reduce:       reduceFn,
+		ReadPref:     coll.readPreference,
+		WriteConcern: wc,
+		ReadConcern:  rc,
+		Session:      sess,
+		Clock:        coll.client.clock,
+	}
+
+	cursor, err := dispatch.MapReduce(
+		ctx, cmd,
+		coll.client.topology,
+		makePinnedSelector(sess, coll.readSelector),
+		makePinnedSelector(sess, coll.writeSelector),
+		coll.client.id,
+		coll.client.topology.SessionPool,
+		coll.registry,
+		mrOpts,
+	)
+	if err != nil {
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
+	}
+
+	if mrOpts.Out == nil {
+		return newTimeoutCursor(cursor, deadline, false), nil
+	}
+	return coll.db.Collection(*mrOpts.Out).Find(ctx, bsonx.Doc{})
 }
 
 // Count gets the number of documents matching the filter. A user can supply a
@@ -787,6 +1019,9 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
 		return 0, err
@@ -794,7 +1029,7 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return 0, err
 	}
@@ -804,6 +1039,10 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 		rc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.CountOptions{options.Count().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Count{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -817,14 +1056,15 @@ func (coll *Collection) Count(ctx context.Context, filter interface{},
 	count, err := dispatch.Count(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		makePinnedSelector(sess, coll.readSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
+		coll.client.retryReads,
 		opts...,
 	)
 
-	return count, replaceTopologyErr(err)
+	return count, replaceTopologyErr(timeoutErr(err, deadline))
 }
 
 // CountDocuments gets the number of documents matching the filter. A user can supply a
@@ -839,7 +1079,13 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	countOpts := options.MergeCountOptions(opts...)
+	if countOpts.Collation == nil {
+		countOpts.Collation = coll.collation
+	}
 
 	pipelineArr, err := countDocumentsAggregatePipeline(coll.registry, filter, countOpts)
 	if err != nil {
@@ -848,7 +1094,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return 0, err
 	}
@@ -871,14 +1117,14 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	count, err := dispatch.CountDocuments(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		makePinnedSelector(sess, coll.readSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
 		countOpts,
 	)
 
-	return count, replaceTopologyErr(err)
+	return count, replaceTopologyErr(timeoutErr(err, deadline))
 }
 
 // EstimatedDocumentCount gets an estimate of the count of documents in a collection using collection metadata.
@@ -889,9 +1135,12 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := coll.client.ValidSession(sess)
+	err := coll.validate(sess)
 	if err != nil {
 		return 0, err
 	}
@@ -919,14 +1168,15 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	count, err := dispatch.Count(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		makePinnedSelector(sess, coll.readSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
+		coll.client.retryReads,
 		countOpts,
 	)
 
-	return count, replaceTopologyErr(err)
+	return count, replaceTopologyErr(timeoutErr(err, deadline))
 }
 
 // Distinct finds the distinct values for a specified field across a single
@@ -943,6 +1193,9 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	var f bsonx.Doc
 	var err error
 	if filter != nil {
@@ -954,7 +1207,7 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -964,6 +1217,10 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		rc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.DistinctOptions{options.Distinct().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.Distinct{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -978,13 +1235,14 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	res, err := dispatch.Distinct(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		makePinnedSelector(sess, coll.readSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
+		coll.client.retryReads,
 		opts...,
 	)
 	if err != nil {
-		return nil, replaceTopologyErr(err)
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
 	}
 
 	return res.Values, nil
@@ -1003,18 +1261,32 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
+	findOpts := options.MergeFindOptions(opts...)
+	if findOpts.Collation == nil {
+		findOpts.Collation = coll.collation
+	}
+
 	var f bsonx.Doc
+	var rawFilter bson.Raw
 	var err error
-	if filter != nil {
-		f, err = transformDocument(coll.registry, filter)
+	switch {
+	case findOpts.FilterAppender != nil:
+		rawFilter, err = appendDocument(findOpts.FilterAppender, filter)
+		if err != nil {
+			return nil, err
+		}
+	case filter != nil:
+		f, rawFilter, err = transformFilter(coll.registry, filter)
 		if err != nil {
 			return nil, err
 		}
 	}
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, findOpts.Timeout)
+	defer cancel()
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -1028,6 +1300,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	cmd := command.Find{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Filter:      f,
+		RawFilter:   rawFilter,
 		ReadPref:    coll.readPreference,
 		ReadConcern: rc,
 		Session:     sess,
@@ -1037,14 +1310,44 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	cursor, err := dispatch.Find(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		makePinnedSelector(sess, coll.readSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
-		opts...,
+		coll.client.retryReads,
+		findOpts,
 	)
+	if err != nil {
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
+	}
 
-	return cursor, replaceTopologyErr(err)
+	return wrapCursor(
+		newTimeoutCursor(cursor, deadline, findOpts.CursorType != nil && *findOpts.CursorType == options.TailableAwait),
+		findOpts.AutoCloseOnError, findOpts.KillCursorOnCancel), nil
+}
+
+// ForEach finds the documents matching a model and calls fn once for each of them, in order. fn
+// is passed a DecodeFunc that decodes the current document into a value provided by the caller.
+// Iteration stops when the cursor is exhausted or when fn returns an error, which is returned
+// by ForEach. The cursor is closed before ForEach returns, even if ctx is cancelled or expires
+// during iteration.
+//
+// This method uses TransformDocument to turn the filter parameter into a
+// *bsonx.Document. See TransformDocument for the list of valid types for
+// filter.
+func (coll *Collection) ForEach(ctx context.Context, filter interface{}, fn func(DecodeFunc) error,
+	opts ...*options.FindOptions) error {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	cursor, err := coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return err
+	}
+
+	return forEach(ctx, cursor, fn)
 }
 
 // FindOne returns up to one document that matches the model. A user can
@@ -1061,10 +1364,14 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	var f bsonx.Doc
+	var rawFilter bson.Raw
 	var err error
 	if filter != nil {
-		f, err = transformDocument(coll.registry, filter)
+		f, rawFilter, err = transformFilter(coll.registry, filter)
 		if err != nil {
 			return &DocumentResult{err: err}
 		}
@@ -1072,7 +1379,7 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return &DocumentResult{err: err}
 	}
@@ -1086,6 +1393,7 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 	cmd := command.Find{
 		NS:          command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
 		Filter:      f,
+		RawFilter:   rawFilter,
 		ReadPref:    coll.readPreference,
 		ReadConcern: rc,
 		Session:     sess,
@@ -1101,6 +1409,7 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 			Comment:             opt.Comment,
 			CursorType:          opt.CursorType,
 			Hint:                opt.Hint,
+			Let:                 opt.Let,
 			Max:                 opt.Max,
 			MaxAwaitTime:        opt.MaxAwaitTime,
 			Min:                 opt.Min,
@@ -1115,17 +1424,22 @@ func (coll *Collection) FindOne(ctx context.Context, filter interface{},
 		}
 	}
 
+	if coll.collation != nil {
+		findOpts = append([]*options.FindOptions{options.Find().SetCollation(coll.collation)}, findOpts...)
+	}
+
 	cursor, err := dispatch.Find(
 		ctx, cmd,
 		coll.client.topology,
-		coll.readSelector,
+		makePinnedSelector(sess, coll.readSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.registry,
+		coll.client.retryReads,
 		findOpts...,
 	)
 	if err != nil {
-		return &DocumentResult{err: replaceTopologyErr(err)}
+		return &DocumentResult{err: replaceTopologyErr(timeoutErr(err, deadline))}
 	}
 
 	return &DocumentResult{cur: cursor, reg: coll.registry}
@@ -1147,6 +1461,9 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	var f bsonx.Doc
 	var err error
 	if filter != nil {
@@ -1158,11 +1475,15 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return &DocumentResult{err: err}
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.FindOneAndDeleteOptions{options.FindOneAndDelete().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	wc := coll.writeConcern
 	if sess != nil && sess.TransactionRunning() {
@@ -1180,7 +1501,7 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 	res, err := dispatch.FindOneAndDelete(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
@@ -1188,7 +1509,7 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		opts...,
 	)
 	if err != nil {
-		return &DocumentResult{err: replaceTopologyErr(err)}
+		return &DocumentResult{err: replaceTopologyErr(timeoutErr(err, deadline))}
 	}
 
 	return &DocumentResult{rdr: res.Value, reg: coll.registry}
@@ -1210,6 +1531,9 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
 		return &DocumentResult{err: err}
@@ -1220,13 +1544,13 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		return &DocumentResult{err: err}
 	}
 
-	if len(r) > 0 && strings.HasPrefix(r[0].Key, "$") {
-		return &DocumentResult{err: errors.New("replacement document cannot contains keys beginning with '$")}
+	if err = ensureNoDollarKey(r); err != nil {
+		return &DocumentResult{err: err}
 	}
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return &DocumentResult{err: err}
 	}
@@ -1236,6 +1560,10 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		wc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.FindOneAndReplaceOptions{options.FindOneAndReplace().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.FindOneAndReplace{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -1249,7 +1577,7 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 	res, err := dispatch.FindOneAndReplace(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
@@ -1257,7 +1585,7 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 		opts...,
 	)
 	if err != nil {
-		return &DocumentResult{err: replaceTopologyErr(err)}
+		return &DocumentResult{err: replaceTopologyErr(timeoutErr(err, deadline))}
 	}
 
 	return &DocumentResult{rdr: res.Value, reg: coll.registry}
@@ -1279,6 +1607,9 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	f, err := transformDocument(coll.registry, filter)
 	if err != nil {
 		return &DocumentResult{err: err}
@@ -1289,13 +1620,13 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		return &DocumentResult{err: err}
 	}
 
-	if len(u) > 0 && !strings.HasPrefix(u[0].Key, "$") {
-		return &DocumentResult{err: errors.New("update document must contain key beginning with '$")}
+	if err = ensureDollarKey(u); err != nil {
+		return &DocumentResult{err: err}
 	}
 
 	sess := sessionFromContext(ctx)
 
-	err = coll.client.ValidSession(sess)
+	err = coll.validate(sess)
 	if err != nil {
 		return &DocumentResult{err: err}
 	}
@@ -1305,6 +1636,10 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		wc = nil
 	}
 
+	if coll.collation != nil {
+		opts = append([]*options.FindOneAndUpdateOptions{options.FindOneAndUpdate().SetCollation(coll.collation)}, opts...)
+	}
+
 	oldns := coll.namespace()
 	cmd := command.FindOneAndUpdate{
 		NS:           command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
@@ -1318,7 +1653,7 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	res, err := dispatch.FindOneAndUpdate(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 		coll.client.retryWrites,
@@ -1326,12 +1661,165 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 		opts...,
 	)
 	if err != nil {
-		return &DocumentResult{err: replaceTopologyErr(err)}
+		return &DocumentResult{err: replaceTopologyErr(timeoutErr(err, deadline))}
 	}
 
 	return &DocumentResult{rdr: res.Value, reg: coll.registry}
 }
 
+// ExplainFind returns the output of the explain command for the find that Find would run with the
+// same filter and options. A user can supply a custom context to this method, or nil to default to
+// context.Background().
+//
+// See https://docs.mongodb.com/manual/reference/command/explain/.
+func (coll *Collection) ExplainFind(ctx context.Context, filter interface{}, verbosity options.ExplainVerbosity,
+	opts ...*options.FindOptions) (bson.Raw, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
+	var f bsonx.Doc
+	var rawFilter bson.Raw
+	var err error
+	if filter != nil {
+		f, rawFilter, err = transformFilter(coll.registry, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sess := sessionFromContext(ctx)
+
+	err = coll.validate(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	oldns := coll.namespace()
+	cmd := command.Find{
+		NS:        command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+		Filter:    f,
+		RawFilter: rawFilter,
+		ReadPref:  coll.readPreference,
+		Session:   sess,
+		Clock:     coll.client.clock,
+	}
+
+	res, err := dispatch.ExplainFind(
+		ctx, cmd,
+		coll.client.topology,
+		makePinnedSelector(sess, coll.readSelector),
+		coll.client.id,
+		coll.client.topology.SessionPool,
+		coll.registry,
+		string(verbosity),
+		opts...,
+	)
+	return res, replaceTopologyErr(timeoutErr(err, deadline))
+}
+
+// ExplainAggregate returns the output of the explain command for the aggregation that Aggregate
+// would run with the same pipeline and options. A user can supply a custom context to this method, or
+// nil to default to context.Background().
+//
+// See https://docs.mongodb.com/manual/reference/command/explain/.
+func (coll *Collection) ExplainAggregate(ctx context.Context, pipeline interface{},
+	verbosity options.ExplainVerbosity, opts ...*options.AggregateOptions) (bson.Raw, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
+	pipelineArr, err := transformAggregatePipeline(coll.registry, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := sessionFromContext(ctx)
+
+	err = coll.validate(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	oldns := coll.namespace()
+	cmd := command.Aggregate{
+		NS:       command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+		Pipeline: pipelineArr,
+		ReadPref: coll.readPreference,
+		Session:  sess,
+		Clock:    coll.client.clock,
+	}
+
+	res, err := dispatch.ExplainAggregate(
+		ctx, cmd,
+		coll.client.topology,
+		makePinnedSelector(sess, coll.readSelector),
+		coll.client.id,
+		coll.client.topology.SessionPool,
+		coll.registry,
+		string(verbosity),
+		opts...,
+	)
+	return res, replaceTopologyErr(timeoutErr(err, deadline))
+}
+
+// ExplainCount returns the output of the explain command for the count that Count would run with
+// the same filter and options. A user can supply a custom context to this method, or nil to default
+// to context.Background().
+//
+// See https://docs.mongodb.com/manual/reference/command/explain/.
+func (coll *Collection) ExplainCount(ctx context.Context, filter interface{}, verbosity options.ExplainVerbosity,
+	opts ...*options.CountOptions) (bson.Raw, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
+	f, err := transformDocument(coll.registry, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := sessionFromContext(ctx)
+
+	err = coll.validate(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	oldns := coll.namespace()
+	cmd := command.Count{
+		NS:       command.Namespace{DB: oldns.DB, Collection: oldns.Collection},
+		Query:    f,
+		ReadPref: coll.readPreference,
+		Session:  sess,
+		Clock:    coll.client.clock,
+	}
+
+	res, err := dispatch.ExplainCount(
+		ctx, cmd,
+		coll.client.topology,
+		makePinnedSelector(sess, coll.readSelector),
+		coll.client.id,
+		coll.client.topology.SessionPool,
+		coll.registry,
+		string(verbosity),
+		opts...,
+	)
+	return res, replaceTopologyErr(timeoutErr(err, deadline))
+}
+
 // Watch returns a change stream cursor used to receive notifications of changes to the collection.
 // This method is preferred to running a raw aggregation with a $changeStream stage because it
 // supports resumability in the case of some errors.
@@ -1345,15 +1833,72 @@ func (coll *Collection) Indexes() IndexView {
 	return IndexView{coll: coll}
 }
 
+// Rename renames this collection to newName within the same database and returns a handle for the
+// renamed collection with the same options as this one. A user can supply a custom context to this
+// method, or nil to default to context.Background().
+//
+// The rename fails if a collection named newName already exists, unless the DropTarget option is set.
+func (coll *Collection) Rename(ctx context.Context, newName string,
+	opts ...*options.RenameCollectionOptions) (*Collection, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
+	sess := sessionFromContext(ctx)
+
+	err := coll.validate(sess)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateCollectionName(coll.db.name, newName); err != nil {
+		return nil, err
+	}
+
+	wc := coll.writeConcern
+	if sess != nil && sess.TransactionRunning() {
+		wc = nil
+	}
+
+	cmd := command.RenameCollection{
+		From:         command.NewNamespace(coll.db.name, coll.name),
+		To:           command.NewNamespace(coll.db.name, newName),
+		WriteConcern: wc,
+		Session:      sess,
+		Clock:        coll.client.clock,
+	}
+	_, err = dispatch.RenameCollection(
+		ctx, cmd,
+		coll.client.topology,
+		makePinnedSelector(sess, coll.writeSelector),
+		coll.client.id,
+		coll.client.topology.SessionPool,
+		opts...,
+	)
+	if err != nil {
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
+	}
+
+	renamed := coll.copy()
+	renamed.name = newName
+	return renamed, nil
+}
+
 // Drop drops this collection from database.
 func (coll *Collection) Drop(ctx context.Context) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := coll.client.ValidSession(sess)
+	err := coll.validate(sess)
 	if err != nil {
 		return err
 	}
@@ -1373,12 +1918,12 @@ func (coll *Collection) Drop(ctx context.Context) error {
 	_, err = dispatch.DropCollection(
 		ctx, cmd,
 		coll.client.topology,
-		coll.writeSelector,
+		makePinnedSelector(sess, coll.writeSelector),
 		coll.client.id,
 		coll.client.topology.SessionPool,
 	)
 	if err != nil && !command.IsNotFound(err) {
-		return replaceTopologyErr(err)
+		return replaceTopologyErr(timeoutErr(err, deadline))
 	}
 	return nil
 }
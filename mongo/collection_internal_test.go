@@ -8,9 +8,12 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/options"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
@@ -18,6 +21,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/event"
 	"github.com/mongodb/mongo-go-driver/internal/testutil"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
@@ -175,25 +181,25 @@ func TestCollection_ReplaceTopologyError(t *testing.T) {
 	}
 
 	_, err = coll.InsertOne(context.Background(), doc1)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.InsertMany(context.Background(), docs)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.DeleteOne(context.Background(), doc1)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.DeleteMany(context.Background(), doc1)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.UpdateOne(context.Background(), doc1, update)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.UpdateMany(context.Background(), doc1, update)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.ReplaceOne(context.Background(), doc1, doc2)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	pipeline := bsonx.Arr{
 		bsonx.Document(
@@ -210,34 +216,34 @@ func TestCollection_ReplaceTopologyError(t *testing.T) {
 		)}
 
 	_, err = coll.Aggregate(context.Background(), pipeline, options.Aggregate())
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.Count(context.Background(), nil)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.CountDocuments(context.Background(), nil)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.EstimatedDocumentCount(context.Background())
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.Distinct(context.Background(), "x", nil)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = coll.Find(context.Background(), doc1)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	result := coll.FindOne(context.Background(), doc1)
-	require.Equal(t, result.err, ErrClientDisconnected)
+	require.Equal(t, result.err, ErrClientNotConnected)
 
 	result = coll.FindOneAndDelete(context.Background(), doc1)
-	require.Equal(t, result.err, ErrClientDisconnected)
+	require.Equal(t, result.err, ErrClientNotConnected)
 
 	result = coll.FindOneAndReplace(context.Background(), doc1, doc2)
-	require.Equal(t, result.err, ErrClientDisconnected)
+	require.Equal(t, result.err, ErrClientNotConnected)
 
 	result = coll.FindOneAndUpdate(context.Background(), doc1, update)
-	require.Equal(t, result.err, ErrClientDisconnected)
+	require.Equal(t, result.err, ErrClientNotConnected)
 }
 
 func TestCollection_namespace(t *testing.T) {
@@ -511,6 +517,51 @@ func TestCollection_InsertMany_ErrorCases(t *testing.T) {
 
 }
 
+func TestCollection_InsertMany_PartialFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+
+	existing := bsonx.Elem{"_id", bsonx.ObjectID(objectid.New())}
+	_, err := coll.InsertOne(context.Background(), bsonx.Doc{existing})
+	require.NoError(t, err)
+
+	for _, ordered := range []bool{false, true} {
+		ordered := ordered
+		t.Run(fmt.Sprintf("ordered=%t", ordered), func(t *testing.T) {
+			first := bsonx.Elem{"_id", bsonx.ObjectID(objectid.New())}
+			last := bsonx.Elem{"_id", bsonx.ObjectID(objectid.New())}
+			docs := []interface{}{bsonx.Doc{first}, bsonx.Doc{existing}, bsonx.Doc{last}}
+
+			res, err := coll.InsertMany(context.Background(), docs, options.InsertMany().SetOrdered(ordered))
+			bwe, ok := err.(BulkWriteException)
+			require.True(t, ok, "expected a BulkWriteException, got %T: %v", err, err)
+			require.Len(t, bwe.WriteErrors, 1)
+			require.Equal(t, 1, bwe.WriteErrors[0].Index)
+			require.Equal(t, 11000, bwe.WriteErrors[0].Code)
+
+			require.NotNil(t, res)
+			require.Len(t, res.InsertedIDs, 3)
+			require.Equal(t, first, res.InsertedIDs[0])
+			require.Nil(t, res.InsertedIDs[1])
+
+			count, err := coll.CountDocuments(context.Background(), bsonx.Doc{{"_id", last.Value}})
+			require.NoError(t, err)
+			if ordered {
+				require.Nil(t, res.InsertedIDs[2])
+				require.Equal(t, int64(0), count)
+			} else {
+				require.Equal(t, last, res.InsertedIDs[2])
+				require.Equal(t, int64(1), count)
+			}
+		})
+	}
+}
+
 func TestCollection_InsertMany_WriteConcernError(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -1206,6 +1257,107 @@ func TestCollection_Aggregate(t *testing.T) {
 
 }
 
+func TestCollection_AggregateOne(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	t.Run("count", func(t *testing.T) {
+		pipeline := bsonx.Arr{
+			bsonx.Document(
+				bsonx.Doc{{"$match", bsonx.Document(bsonx.Doc{{"x", bsonx.Document(bsonx.Doc{{"$gte", bsonx.Int32(2)}})}})}},
+			),
+			bsonx.Document(
+				bsonx.Doc{{
+					"$group",
+					bsonx.Document(bsonx.Doc{
+						{"_id", bsonx.Null()},
+						{"count", bsonx.Document(bsonx.Doc{{"$sum", bsonx.Int32(1)}})},
+					}),
+				}},
+			),
+		}
+
+		var result struct {
+			Count int32 `bson:"count"`
+		}
+		err := coll.AggregateOne(context.Background(), pipeline).Decode(&result)
+		require.NoError(t, err)
+		require.Equal(t, int32(4), result.Count)
+	})
+	t.Run("no documents", func(t *testing.T) {
+		pipeline := bsonx.Arr{
+			bsonx.Document(
+				bsonx.Doc{{"$match", bsonx.Document(bsonx.Doc{{"x", bsonx.Int32(100)}})}},
+			),
+		}
+
+		var doc bsonx.Doc
+		err := coll.AggregateOne(context.Background(), pipeline).Decode(&doc)
+		require.Equal(t, ErrNoDocuments, err)
+	})
+}
+
+func TestCollection_Let(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	serverVersion, err := getServerVersion(coll.db)
+	require.NoError(t, err)
+	if compareVersions(t, serverVersion, "5.0") < 0 {
+		t.Skip("skipping let test for server version < 5.0")
+	}
+	initCollection(t, coll)
+
+	let := bson.D{{"target", 3}}
+	filter := bson.D{{"$expr", bson.D{{"$eq", bson.A{"$x", "$$target"}}}}}
+
+	t.Run("find", func(t *testing.T) {
+		cursor, err := coll.Find(context.Background(), filter, options.Find().SetLet(let))
+		require.NoError(t, err)
+		defer cursor.Close(context.Background())
+
+		var docs []bsonx.Doc
+		for cursor.Next(context.Background()) {
+			var doc bsonx.Doc
+			require.NoError(t, cursor.Decode(&doc))
+			docs = append(docs, doc)
+		}
+		require.NoError(t, cursor.Err())
+		require.Len(t, docs, 1)
+		require.Equal(t, int32(3), docs[0].Lookup("x").Int32())
+	})
+	t.Run("aggregate", func(t *testing.T) {
+		pipeline := bson.A{
+			bson.D{{"$match", filter}},
+			bson.D{{"$project", bson.D{{"_id", 0}, {"y", "$$target"}}}},
+		}
+		cursor, err := coll.Aggregate(context.Background(), pipeline, options.Aggregate().SetLet(let))
+		require.NoError(t, err)
+		defer cursor.Close(context.Background())
+
+		require.True(t, cursor.Next(context.Background()))
+		var doc bsonx.Doc
+		require.NoError(t, cursor.Decode(&doc))
+		require.Equal(t, int32(3), doc.Lookup("y").Int32())
+	})
+	t.Run("update", func(t *testing.T) {
+		update := bson.D{{"$set", bson.D{{"matched", true}}}}
+		res, err := coll.UpdateMany(context.Background(), filter, update, options.Update().SetLet(let))
+		require.NoError(t, err)
+		require.Equal(t, int64(1), res.ModifiedCount)
+	})
+}
+
 func testAggregateWithOptions(t *testing.T, createIndex bool, opts *options.AggregateOptions) error {
 	coll := createTestCollection(t, nil, nil)
 	initCollection(t, coll)
@@ -1261,6 +1413,52 @@ func testAggregateWithOptions(t *testing.T, createIndex bool, opts *options.Aggr
 	return nil
 }
 
+func TestCollection_AggregateOut(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+	outName := coll.Name() + "_out"
+	defer func() { _ = coll.Database().Collection(outName).Drop(context.Background()) }()
+
+	pipeline := NewPipelineBuilder().
+		Match(bson.D{{"x", bson.D{{"$gte", int32(3)}}}}).
+		Project(bson.D{{"_id", 0}, {"x", 1}}).
+		Build()
+	out, err := coll.AggregateOut(context.Background(), pipeline, outName)
+	require.NoError(t, err)
+	require.Equal(t, outName, out.Name())
+
+	cursor, err := out.Find(context.Background(), nil, options.Find().SetSort(bson.D{{"x", 1}}))
+	require.NoError(t, err)
+	var xs []int32
+	for cursor.Next(context.Background()) {
+		var doc struct{ X int32 }
+		require.NoError(t, cursor.Decode(&doc))
+		xs = append(xs, doc.X)
+	}
+	require.NoError(t, cursor.Err())
+	require.Equal(t, []int32{3, 4, 5}, xs)
+}
+
+func TestCollection_AggregateOut_writeStage(t *testing.T) {
+	t.Parallel()
+
+	coll := &Collection{}
+	for _, stage := range []string{"$out", "$merge"} {
+		pipeline := Pipeline{
+			{{stage, "other"}},
+			{{"$match", bson.D{}}},
+		}
+		_, err := coll.AggregateOut(context.Background(), pipeline, "out")
+		require.Equal(t, ErrPipelineHasWriteStage, err, stage)
+	}
+}
+
 func TestCollection_Aggregate_IndexHint(t *testing.T) {
 	skipIfBelow36(t)
 
@@ -1290,6 +1488,49 @@ func TestCollection_Aggregate_withOptions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCollection_Comment(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	var started *event.CommandStartedEvent
+	commentMonitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			started = cse
+		},
+	}
+
+	client := createSessionsMonitoredClient(t, commentMonitor)
+	coll := client.Database(testutil.DBName(t)).Collection(testutil.ColName(t))
+	defer func() { _ = coll.Drop(context.Background()) }()
+
+	checkComment := func(t *testing.T, expected bsonx.Val) {
+		require.NotNil(t, started)
+		comment, err := started.Command.LookupErr("comment")
+		require.NoError(t, err)
+		require.True(t, comment.Equal(expected), "expected comment %v, got %v", expected, comment)
+	}
+
+	t.Run("Aggregate", func(t *testing.T) {
+		cursor, err := coll.Aggregate(context.Background(), Pipeline{}, options.Aggregate().SetComment("aggregate comment"))
+		require.NoError(t, err)
+		_ = cursor.Close(context.Background())
+		checkComment(t, bsonx.String("aggregate comment"))
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		_, err := coll.Count(context.Background(), nil, options.Count().SetComment("count comment"))
+		require.NoError(t, err)
+		checkComment(t, bsonx.String("count comment"))
+	})
+
+	t.Run("CountDocuments", func(t *testing.T) {
+		_, err := coll.CountDocuments(context.Background(), bsonx.Doc{}, options.Count().SetComment("countDocuments comment"))
+		require.NoError(t, err)
+		checkComment(t, bsonx.String("countDocuments comment"))
+	})
+}
+
 func TestCollection_Count(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -1478,6 +1719,88 @@ func TestCollection_Distinct_withOption(t *testing.T) {
 	require.Equal(t, results, []interface{}{int32(1), int32(2), int32(3), int32(4), int32(5)})
 }
 
+const (
+	wordCountMap    = "function() { this.words.forEach(function(w) { emit(w, 1); }); }"
+	wordCountReduce = "function(key, values) { return Array.sum(values); }"
+)
+
+func initWordCountCollection(t *testing.T, coll *Collection) {
+	_, err := coll.InsertMany(context.Background(), []interface{}{
+		bson.D{{"words", bson.A{"a", "b", "a"}}},
+		bson.D{{"words", bson.A{"b", "c"}}},
+		bson.D{{"words", bson.A{"a"}}},
+	})
+	require.Nil(t, err)
+}
+
+func decodeWordCounts(t *testing.T, cursor Cursor) map[string]float64 {
+	counts := make(map[string]float64)
+	for cursor.Next(context.Background()) {
+		var result struct {
+			ID    string  `bson:"_id"`
+			Value float64 `bson:"value"`
+		}
+		require.NoError(t, cursor.Decode(&result))
+		counts[result.ID] = result.Value
+	}
+	require.NoError(t, cursor.Err())
+	require.NoError(t, cursor.Close(context.Background()))
+	return counts
+}
+
+func TestCollection_MapReduce_inline(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initWordCountCollection(t, coll)
+
+	cursor, err := coll.MapReduce(context.Background(), wordCountMap, wordCountReduce)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"a": 3, "b": 2, "c": 1}, decodeWordCounts(t, cursor))
+}
+
+func TestCollection_MapReduce_withOptions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initWordCountCollection(t, coll)
+
+	opts := options.MapReduce().
+		SetQuery(bson.D{{"words", "b"}}).
+		SetSort(bson.D{{"_id", 1}}).
+		SetLimit(1).
+		SetScope(bson.D{{"factor", 10}}).
+		SetFinalize("function(key, value) { return value * factor; }")
+	cursor, err := coll.MapReduce(context.Background(), wordCountMap, wordCountReduce, opts)
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"a": 20, "b": 10}, decodeWordCounts(t, cursor))
+}
+
+func TestCollection_MapReduce_out(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initWordCountCollection(t, coll)
+	out := coll.Name() + "_out"
+	defer func() { _ = coll.Database().Collection(out).Drop(context.Background()) }()
+
+	cursor, err := coll.MapReduce(context.Background(), wordCountMap, wordCountReduce, options.MapReduce().SetOut(out))
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"a": 3, "b": 2, "c": 1}, decodeWordCounts(t, cursor))
+}
+
 func TestCollection_Find_found(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -1532,6 +1855,179 @@ func TestCollection_Find_notFound(t *testing.T) {
 	require.False(t, cursor.Next(context.Background()))
 }
 
+func TestCollection_Find_defaultCollation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	caseInsensitive := &options.Collation{Locale: "en_US", Strength: 2}
+	coll := createTestCollection(t, nil, nil, options.Collection().SetCollation(caseInsensitive))
+	skipIfBelow34(t, coll.db)
+
+	_, err := coll.InsertOne(context.Background(), bsonx.Doc{{"name", bsonx.String("foo")}})
+	require.NoError(t, err)
+
+	filter := bsonx.Doc{{"name", bsonx.String("FOO")}}
+
+	t.Run("applied when not specified", func(t *testing.T) {
+		cursor, err := coll.Find(context.Background(), filter)
+		require.NoError(t, err)
+		require.True(t, cursor.Next(context.Background()))
+		require.NoError(t, cursor.Close(context.Background()))
+	})
+	t.Run("overridden by the operation", func(t *testing.T) {
+		caseSensitive := &options.Collation{Locale: "en_US", Strength: 3}
+		cursor, err := coll.Find(context.Background(), filter, options.Find().SetCollation(caseSensitive))
+		require.NoError(t, err)
+		require.False(t, cursor.Next(context.Background()))
+	})
+}
+
+func TestCollection_BulkWrite_defaultCollation(t *testing.T) {
+	t.Parallel()
+
+	caseInsensitive := &options.Collation{Locale: "en_US", Strength: 2}
+	caseSensitive := &options.Collation{Locale: "en_US", Strength: 3}
+
+	models := []WriteModel{
+		NewInsertOneModel().Document(bsonx.Doc{{"x", bsonx.Int32(1)}}),
+		NewDeleteOneModel().Filter(bsonx.Doc{}),
+		NewDeleteManyModel().Filter(bsonx.Doc{}).Collation(caseSensitive),
+		NewReplaceOneModel().Filter(bsonx.Doc{}).Replacement(bsonx.Doc{}),
+		NewUpdateOneModel().Filter(bsonx.Doc{}).Update(bsonx.Doc{}),
+		NewUpdateManyModel().Filter(bsonx.Doc{}).Update(bsonx.Doc{}).Collation(caseSensitive),
+	}
+	var got []*options.Collation
+	for _, model := range models {
+		switch conv := withDefaultCollation(model.convertModel(), caseInsensitive).(type) {
+		case dispatch.InsertOneModel:
+		case dispatch.DeleteOneModel:
+			got = append(got, conv.Collation)
+		case dispatch.DeleteManyModel:
+			got = append(got, conv.Collation)
+		case dispatch.ReplaceOneModel:
+			got = append(got, conv.Collation)
+		case dispatch.UpdateOneModel:
+			got = append(got, conv.Collation)
+		case dispatch.UpdateManyModel:
+			got = append(got, conv.Collation)
+		default:
+			t.Fatalf("unexpected model type %T", conv)
+		}
+	}
+	require.Equal(t, []*options.Collation{caseInsensitive, caseSensitive, caseInsensitive, caseInsensitive, caseSensitive}, got)
+
+	// the caller's models are not modified
+	require.Nil(t, models[1].(*DeleteOneModel).DeleteOneModel.Collation)
+}
+
+func TestCollection_Find_tryNext(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	db := createTestDatabase(t, nil)
+	_, err := db.RunCommand(
+		context.Background(),
+		bsonx.Doc{
+			{"create", bsonx.String(testutil.ColName(t))},
+			{"capped", bsonx.Boolean(true)},
+			{"size", bsonx.Int32(64 * 1024)},
+		},
+	)
+	require.NoError(t, err)
+	coll := db.Collection(testutil.ColName(t))
+
+	cursor, err := coll.Find(context.Background(), bsonx.Doc{}, options.Find().SetCursorType(options.Tailable))
+	require.NoError(t, err)
+	defer cursor.Close(context.Background())
+
+	start := time.Now()
+	require.False(t, cursor.TryNext(context.Background()))
+	require.NoError(t, cursor.Err())
+	require.True(t, time.Since(start) < time.Second, "expected TryNext to return promptly")
+}
+
+func TestCollection_ExplainFind(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	res, err := coll.ExplainFind(context.Background(), bsonx.Doc{{"x", bsonx.Int32(1)}}, options.QueryPlanner,
+		options.Find().SetLimit(2))
+	require.NoError(t, err)
+
+	planner, err := res.LookupErr("queryPlanner")
+	require.NoError(t, err)
+	ns, err := planner.Document().LookupErr("namespace")
+	require.NoError(t, err)
+	require.Equal(t, coll.db.Name()+"."+coll.Name(), ns.StringValue())
+}
+
+func TestCollection_ExplainCount_Hint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	indexName, err := coll.Indexes().CreateOne(context.Background(), IndexModel{Keys: bsonx.Doc{{"x", bsonx.Int32(1)}}})
+	require.NoError(t, err)
+
+	res, err := coll.ExplainCount(context.Background(), bsonx.Doc{{"y", bsonx.Int32(1)}}, options.QueryPlanner,
+		options.Count().SetHint(indexName))
+	require.NoError(t, err)
+
+	winningPlan, err := res.LookupErr("queryPlanner", "winningPlan")
+	require.NoError(t, err)
+	require.True(t, strings.Contains(winningPlan.String(), indexName),
+		"expected the winning plan to use index %s, got %v", indexName, winningPlan)
+}
+
+func TestCollection_ForEach(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+
+	var results []int
+	err := coll.ForEach(context.Background(), nil, func(decode DecodeFunc) error {
+		var doc struct{ X int }
+		if err := decode(&doc); err != nil {
+			return err
+		}
+		results = append(results, doc.X)
+		return nil
+	}, options.Find().SetSort(bsonx.Doc{{"x", bsonx.Int32(1)}}))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, results)
+
+	stop := errors.New("stop")
+	results = results[:0]
+	err = coll.ForEach(context.Background(), nil, func(decode DecodeFunc) error {
+		results = append(results, 0)
+		return stop
+	})
+	require.Equal(t, stop, err)
+	require.Len(t, results, 1)
+}
+
 func TestCollection_FindOne_found(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
@@ -1826,3 +2322,55 @@ func TestCollection_FindOneAndUpdate_notFound_ignoreResult(t *testing.T) {
 	err := coll.FindOneAndUpdate(context.Background(), filter, update).Decode(nil)
 	require.Equal(t, err, ErrNoDocuments)
 }
+
+func TestCollection_Rename(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+	newName := coll.Name() + "_renamed"
+	defer func() { _ = coll.Database().Collection(newName).Drop(context.Background()) }()
+
+	renamed, err := coll.Rename(context.Background(), newName)
+	require.NoError(t, err)
+	require.Equal(t, newName, renamed.Name())
+
+	count, err := renamed.CountDocuments(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+
+	count, err = coll.CountDocuments(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
+
+func TestCollection_Rename_targetExists(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	t.Parallel()
+
+	coll := createTestCollection(t, nil, nil)
+	initCollection(t, coll)
+	target := coll.Database().Collection(coll.Name() + "_target")
+	defer func() { _ = target.Drop(context.Background()) }()
+	_, err := target.InsertOne(context.Background(), bsonx.Doc{{"y", bsonx.Int32(1)}})
+	require.NoError(t, err)
+
+	_, err = coll.Rename(context.Background(), target.Name())
+	cerr, ok := err.(command.Error)
+	require.True(t, ok, "expected a command.Error but got %v", err)
+	require.Equal(t, int32(48), cerr.Code)
+
+	renamed, err := coll.Rename(context.Background(), target.Name(), options.RenameCollection().SetDropTarget(true))
+	require.NoError(t, err)
+
+	count, err := renamed.CountDocuments(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+}
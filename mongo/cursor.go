@@ -8,8 +8,10 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
 )
 
 // Cursor instances iterate a stream of documents. Each document is
@@ -44,6 +46,11 @@ type Cursor interface {
 	// Returns true if there were no errors and there is a next result.
 	Next(context.Context) bool
 
+	// Get the next result from the cursor without blocking for more than a single getMore.
+	// Returns true if a document is available. Unlike Next, a false result does not mean the
+	// cursor is exhausted; check Err and ID to tell the cases apart.
+	TryNext(context.Context) bool
+
 	Decode(interface{}) error
 
 	DecodeBytes() (bson.Raw, error)
@@ -54,3 +61,241 @@ type Cursor interface {
 	// Close the cursor.
 	Close(context.Context) error
 }
+
+// CurrentCursor is implemented by Cursors that can return the raw bytes of their current document.
+// The Cursors returned by this package implement it.
+type CurrentCursor interface {
+	// Returns the raw bytes of the document Next or TryNext most recently positioned the cursor
+	// on, or nil if there is no such document. Unlike Decode, nothing is unmarshaled. The bytes
+	// are only valid until the next call to Next or TryNext; copy them to retain them.
+	Current() bson.Raw
+}
+
+// current returns the current document of cursor, or nil if cursor does not implement
+// CurrentCursor.
+func current(cursor Cursor) bson.Raw {
+	if cc, ok := cursor.(CurrentCursor); ok {
+		return cc.Current()
+	}
+	return nil
+}
+
+// DecodeFunc decodes the current document of an iteration into val.
+type DecodeFunc func(val interface{}) error
+
+// forEach calls fn with the cursor's Decode method once for each document until the cursor is
+// exhausted or fn returns an error. The cursor is always closed before forEach returns, with a new
+// context so that it's closed even if ctx is done.
+func forEach(ctx context.Context, cursor Cursor, fn func(DecodeFunc) error) (err error) {
+	defer func() {
+		// An error from iteration or fn is more important than any error from closing the cursor.
+		if closeErr := closeWithTimeout(cursor); err == nil {
+			err = closeErr
+		}
+	}()
+
+	for cursor.Next(ctx) {
+		if err = fn(cursor.Decode); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// DecodeAll decodes each remaining document of cursor into a value allocated by newElem and sends it
+// on out, so that documents can be streamed to workers as typed values. out is closed before
+// DecodeAll returns. The returned error is the decoding, context or cursor error that stopped the
+// iteration, if any. The cursor is not closed.
+func DecodeAll(ctx context.Context, cursor Cursor, out chan<- interface{}, newElem func() interface{}) error {
+	defer close(out)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for cursor.Next(ctx) {
+		elem := newElem()
+		if err := cursor.Decode(elem); err != nil {
+			return err
+		}
+
+		select {
+		case out <- elem:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return cursor.Err()
+}
+
+// closeCursorTimeout is the deadline used to close a cursor once iteration has stopped. The cursor is
+// closed with a new context, since the context passed to Next may be the reason iteration stopped.
+var closeCursorTimeout = 5 * time.Second
+
+// closeWithTimeout closes cursor with a new context that expires after closeCursorTimeout.
+func closeWithTimeout(cursor Cursor) error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeCursorTimeout)
+	defer cancel()
+	return cursor.Close(ctx)
+}
+
+// killCursorTimeout is the deadline used to kill a cursor when the context passed to Next is cancelled.
+// It bounds the connection checkout as well as the killCursors command, so that Next returns promptly
+// even if every connection in the pool is in use.
+var killCursorTimeout = time.Second
+
+// wrapCursor applies the cursor behaviors enabled by the autoCloseOnError and killCursorOnCancel options.
+func wrapCursor(cursor Cursor, autoCloseOnError, killCursorOnCancel *bool) Cursor {
+	if killCursorOnCancel != nil && *killCursorOnCancel {
+		cursor = &killOnCancelCursor{Cursor: cursor}
+	}
+	if autoCloseOnError != nil && *autoCloseOnError {
+		cursor = &autoCloseCursor{Cursor: cursor}
+	}
+	return cursor
+}
+
+// timeoutCursor wraps a Cursor returned by an operation with a timeout. Iteration is bounded by the
+// operation's deadline. Only the getMores of tailable await cursors carry a maxTimeMS, computed from
+// the time remaining; for other cursors the server's maxTimeMS would bound the whole cursor rather
+// than a single batch, so the deadline is enforced by the context alone.
+type timeoutCursor struct {
+	Cursor
+	deadline  time.Time
+	awaitData bool
+}
+
+// newTimeoutCursor wraps cursor in a timeoutCursor if deadline is set. awaitData reports whether
+// cursor is a tailable await cursor.
+func newTimeoutCursor(cursor Cursor, deadline time.Time, awaitData bool) Cursor {
+	if deadline.IsZero() {
+		return cursor
+	}
+	return &timeoutCursor{Cursor: cursor, deadline: deadline, awaitData: awaitData}
+}
+
+func (c *timeoutCursor) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.awaitData {
+		return command.WithTimeoutDeadline(ctx, c.deadline)
+	}
+	return context.WithDeadline(ctx, c.deadline)
+}
+
+func (c *timeoutCursor) Next(ctx context.Context) bool {
+	ctx, cancel := c.withDeadline(ctx)
+	defer cancel()
+	return c.Cursor.Next(ctx)
+}
+
+func (c *timeoutCursor) TryNext(ctx context.Context) bool {
+	ctx, cancel := c.withDeadline(ctx)
+	defer cancel()
+	return c.Cursor.TryNext(ctx)
+}
+
+func (c *timeoutCursor) Current() bson.Raw { return current(c.Cursor) }
+
+func (c *timeoutCursor) Err() error {
+	return timeoutErr(c.Cursor.Err(), c.deadline)
+}
+
+// timeoutErr converts err into a command.TimeoutError if it occurred after deadline.
+func timeoutErr(err error, deadline time.Time) error {
+	if err == nil || deadline.IsZero() || time.Now().Before(deadline) {
+		return err
+	}
+	if _, ok := err.(command.TimeoutError); ok {
+		return err
+	}
+	return command.TimeoutError{Wrapped: err}
+}
+
+// autoCloseCursor wraps a Cursor and closes it as soon as Next returns false because of an error.
+// Since the error may come from a cancelled context, the cursor is closed with a new context that has
+// its own deadline.
+type autoCloseCursor struct {
+	Cursor
+	closed bool
+}
+
+func (c *autoCloseCursor) Next(ctx context.Context) bool {
+	if c.Cursor.Next(ctx) {
+		return true
+	}
+
+	c.closeOnError()
+	return false
+}
+
+func (c *autoCloseCursor) TryNext(ctx context.Context) bool {
+	if c.Cursor.TryNext(ctx) {
+		return true
+	}
+
+	c.closeOnError()
+	return false
+}
+
+func (c *autoCloseCursor) Current() bson.Raw { return current(c.Cursor) }
+
+func (c *autoCloseCursor) closeOnError() {
+	if c.Cursor.Err() != nil && !c.closed {
+		// The cursor error is more important than any error from closing the cursor.
+		_ = closeWithTimeout(c)
+	}
+}
+
+func (c *autoCloseCursor) Close(ctx context.Context) error {
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	return c.Cursor.Close(ctx)
+}
+
+// killOnCancelCursor wraps a Cursor and kills the server cursor when Next returns false because the
+// context was cancelled. Since the cancelled context cannot be used, the cursor is closed with a new
+// context that expires after killCursorTimeout.
+type killOnCancelCursor struct {
+	Cursor
+	closed bool
+}
+
+func (c *killOnCancelCursor) Next(ctx context.Context) bool {
+	if c.Cursor.Next(ctx) {
+		return true
+	}
+
+	c.killOnCancel(ctx)
+	return false
+}
+
+func (c *killOnCancelCursor) TryNext(ctx context.Context) bool {
+	if c.Cursor.TryNext(ctx) {
+		return true
+	}
+
+	c.killOnCancel(ctx)
+	return false
+}
+
+func (c *killOnCancelCursor) Current() bson.Raw { return current(c.Cursor) }
+
+func (c *killOnCancelCursor) killOnCancel(ctx context.Context) {
+	if ctx != nil && ctx.Err() != nil && c.Cursor.ID() != 0 && !c.closed {
+		killCtx, cancel := context.WithTimeout(context.Background(), killCursorTimeout)
+		// Killing the cursor is best-effort, so the cancellation error is the one reported.
+		_ = c.Close(killCtx)
+		cancel()
+	}
+}
+
+func (c *killOnCancelCursor) Close(ctx context.Context) error {
+	if c.closed {
+		return nil
+	}
+
+	c.closed = true
+	return c.Cursor.Close(ctx)
+}
@@ -0,0 +1,358 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCursor is a Cursor that returns numDocs documents and then either finishes or fails with err.
+type mockCursor struct {
+	numDocs              int
+	err                  error
+	postBatchResumeToken bson.Raw
+	current              bson.Raw
+
+	returned    int
+	closed      int
+	closeCtxErr error
+	closeDL     time.Time
+	curErr      error
+	nextCtx     context.Context
+}
+
+func (mc *mockCursor) ID() int64 { return 1 }
+
+func (mc *mockCursor) Next(ctx context.Context) bool {
+	mc.nextCtx = ctx
+	if mc.returned < mc.numDocs {
+		mc.returned++
+		return true
+	}
+
+	mc.curErr = mc.err
+	return false
+}
+
+func (mc *mockCursor) TryNext(ctx context.Context) bool { return mc.Next(ctx) }
+
+func (mc *mockCursor) Decode(interface{}) error       { return nil }
+func (mc *mockCursor) DecodeBytes() (bson.Raw, error) { return mc.current, nil }
+func (mc *mockCursor) Current() bson.Raw              { return mc.current }
+func (mc *mockCursor) PostBatchResumeToken() bson.Raw { return mc.postBatchResumeToken }
+func (mc *mockCursor) Err() error                     { return mc.curErr }
+
+func (mc *mockCursor) Close(ctx context.Context) error {
+	mc.closed++
+	mc.closeCtxErr = ctx.Err()
+	mc.closeDL, _ = ctx.Deadline()
+	return nil
+}
+
+func TestAutoCloseCursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ClosesOnError", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 2, err: errors.New("cursor error")}
+		cur := &autoCloseCursor{Cursor: mc}
+
+		for cur.Next(context.Background()) {
+		}
+
+		require.Error(t, cur.Err())
+		require.Equal(t, 1, mc.closed)
+
+		// the cursor should not be closed a second time
+		require.NoError(t, cur.Close(context.Background()))
+		require.Equal(t, 1, mc.closed)
+	})
+
+	t.Run("ClosesWithNewContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mc := &mockCursor{err: context.Canceled}
+		cur := &autoCloseCursor{Cursor: mc}
+
+		require.False(t, cur.Next(ctx))
+		require.Equal(t, 1, mc.closed)
+		require.NoError(t, mc.closeCtxErr)
+	})
+
+	t.Run("ClosesOnDecodeAllError", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 2, err: errors.New("cursor error")}
+		cur := &autoCloseCursor{Cursor: mc}
+
+		out := make(chan interface{})
+		errc := make(chan error, 1)
+		go func() { errc <- DecodeAll(context.Background(), cur, out, func() interface{} { return new(bson.D) }) }()
+
+		var n int
+		for range out {
+			n++
+		}
+
+		// the cursor is closed before out is
+		require.Equal(t, 2, n)
+		require.Equal(t, 1, mc.closed)
+		require.Error(t, <-errc)
+	})
+
+	t.Run("DoesNotCloseOnExhaustion", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 2}
+		cur := &autoCloseCursor{Cursor: mc}
+
+		for cur.Next(context.Background()) {
+		}
+
+		require.NoError(t, cur.Err())
+		require.Equal(t, 0, mc.closed)
+
+		require.NoError(t, cur.Close(context.Background()))
+		require.Equal(t, 1, mc.closed)
+	})
+}
+
+func TestKillOnCancelCursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("KillsOnCancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mc := &mockCursor{err: context.Canceled}
+		cur := wrapCursor(mc, nil, &[]bool{true}[0])
+
+		require.False(t, cur.Next(ctx))
+		killed := time.Now()
+		require.Equal(t, context.Canceled, cur.Err())
+		require.Equal(t, 1, mc.closed)
+		require.NoError(t, mc.closeCtxErr)
+		require.False(t, mc.closeDL.IsZero(), "the cursor should be killed with a deadline")
+		require.False(t, mc.closeDL.After(killed.Add(killCursorTimeout)))
+
+		// the cursor should not be killed a second time
+		require.NoError(t, cur.Close(context.Background()))
+		require.Equal(t, 1, mc.closed)
+	})
+
+	t.Run("KillsOnDecodeAllCancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mc := &mockCursor{err: context.Canceled}
+		cur := wrapCursor(mc, nil, &[]bool{true}[0])
+
+		out := make(chan interface{})
+		errc := make(chan error, 1)
+		go func() { errc <- DecodeAll(ctx, cur, out, func() interface{} { return new(bson.D) }) }()
+		for range out {
+		}
+
+		// the cursor is killed before out is closed
+		require.Equal(t, 1, mc.closed)
+		require.Equal(t, context.Canceled, <-errc)
+	})
+
+	t.Run("DoesNotKillWithoutCancel", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 1, err: errors.New("cursor error")}
+		cur := wrapCursor(mc, nil, &[]bool{true}[0])
+
+		for cur.Next(context.Background()) {
+		}
+
+		require.Error(t, cur.Err())
+		require.Equal(t, 0, mc.closed)
+	})
+
+	t.Run("WithAutoClose", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mc := &mockCursor{err: context.Canceled}
+		cur := wrapCursor(mc, &[]bool{true}[0], &[]bool{true}[0])
+
+		require.False(t, cur.Next(ctx))
+		require.Equal(t, 1, mc.closed)
+		require.NoError(t, cur.Close(context.Background()))
+		require.Equal(t, 1, mc.closed)
+	})
+}
+
+func TestDecodeAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SendsEachDocument", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 3}
+
+		out := make(chan interface{})
+		errc := make(chan error, 1)
+		go func() { errc <- DecodeAll(context.Background(), mc, out, func() interface{} { return new(bson.D) }) }()
+
+		var n int
+		for range out {
+			n++
+		}
+
+		require.Equal(t, 3, n)
+		require.NoError(t, <-errc)
+		require.Equal(t, 0, mc.closed)
+	})
+
+	t.Run("ReturnsCursorError", func(t *testing.T) {
+		cursorErr := errors.New("cursor error")
+		mc := &mockCursor{numDocs: 1, err: cursorErr}
+
+		out := make(chan interface{}, 1)
+		err := DecodeAll(context.Background(), mc, out, func() interface{} { return new(bson.D) })
+
+		require.Equal(t, cursorErr, err)
+		require.Len(t, out, 1)
+	})
+
+	t.Run("StopsWhenContextCancelled", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 3}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// the unbuffered channel is never read, so sending blocks until the context is done
+		out := make(chan interface{})
+		err := DecodeAll(ctx, mc, out, func() interface{} { return new(bson.D) })
+
+		require.Equal(t, context.Canceled, err)
+		_, open := <-out
+		require.False(t, open)
+	})
+}
+
+func TestForEach(t *testing.T) {
+	t.Parallel()
+
+	t.Run("IteratesUntilExhausted", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 3}
+
+		var calls int
+		err := forEach(context.Background(), mc, func(decode DecodeFunc) error {
+			calls++
+			return decode(&bson.D{})
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+		require.Equal(t, 1, mc.closed)
+	})
+
+	t.Run("EarlyReturnClosesCursor", func(t *testing.T) {
+		mc := &mockCursor{numDocs: 3}
+		stop := errors.New("stop")
+
+		var calls int
+		err := forEach(context.Background(), mc, func(DecodeFunc) error {
+			calls++
+			return stop
+		})
+
+		require.Equal(t, stop, err)
+		require.Equal(t, 1, calls)
+		require.Equal(t, 1, mc.closed)
+	})
+
+	t.Run("ReturnsCursorError", func(t *testing.T) {
+		cursorErr := errors.New("cursor error")
+		mc := &mockCursor{numDocs: 1, err: cursorErr}
+
+		err := forEach(context.Background(), mc, func(DecodeFunc) error { return nil })
+
+		require.Equal(t, cursorErr, err)
+		require.Equal(t, 1, mc.closed)
+	})
+
+	t.Run("ClosesWithNewContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		mc := &mockCursor{numDocs: 3, err: context.Canceled}
+
+		err := forEach(ctx, mc, func(DecodeFunc) error {
+			cancel()
+			return nil
+		})
+
+		require.Equal(t, context.Canceled, err)
+		require.Equal(t, 1, mc.closed)
+		require.NoError(t, mc.closeCtxErr)
+	})
+}
+
+func TestTimeoutCursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NoDeadline", func(t *testing.T) {
+		mc := &mockCursor{}
+		require.Equal(t, Cursor(mc), newTimeoutCursor(mc, time.Time{}, false))
+	})
+
+	t.Run("ErrorAfterDeadline", func(t *testing.T) {
+		cursorErr := errors.New("cursor error")
+		mc := &mockCursor{err: cursorErr}
+		cur := newTimeoutCursor(mc, time.Now().Add(-time.Second), false)
+
+		require.False(t, cur.Next(context.Background()))
+		require.Equal(t, command.TimeoutError{Wrapped: cursorErr}, cur.Err())
+	})
+
+	t.Run("ErrorBeforeDeadline", func(t *testing.T) {
+		cursorErr := errors.New("cursor error")
+		mc := &mockCursor{err: cursorErr}
+		cur := newTimeoutCursor(mc, time.Now().Add(time.Minute), false)
+
+		require.False(t, cur.Next(context.Background()))
+		require.Equal(t, cursorErr, cur.Err())
+	})
+
+	t.Run("NoMaxTimeMS", func(t *testing.T) {
+		deadline := time.Now().Add(time.Minute)
+		mc := &mockCursor{}
+		cur := newTimeoutCursor(mc, deadline, false)
+
+		require.False(t, cur.Next(context.Background()))
+		d, ok := mc.nextCtx.Deadline()
+		require.True(t, ok)
+		require.Equal(t, deadline, d)
+		_, ok, _ = command.MaxTimeMS(mc.nextCtx, 0)
+		require.False(t, ok)
+	})
+
+	t.Run("AwaitDataMaxTimeMS", func(t *testing.T) {
+		mc := &mockCursor{}
+		cur := newTimeoutCursor(mc, time.Now().Add(time.Minute), true)
+
+		require.False(t, cur.Next(context.Background()))
+		_, ok, err := command.MaxTimeMS(mc.nextCtx, 0)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+}
+
+func TestOperationDeadline(t *testing.T) {
+	t.Parallel()
+
+	second, zero := time.Second, time.Duration(0)
+
+	require.True(t, (&Client{}).operationDeadline(nil).IsZero())
+	require.False(t, (&Client{}).operationDeadline(&second).IsZero())
+
+	client := &Client{timeout: time.Minute}
+	require.WithinDuration(t, time.Now().Add(time.Minute), client.operationDeadline(nil), 5*time.Second)
+	require.WithinDuration(t, time.Now().Add(time.Second), client.operationDeadline(&second), 5*time.Second)
+	require.True(t, client.operationDeadline(&zero).IsZero())
+}
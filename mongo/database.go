@@ -8,12 +8,14 @@ package mongo
 
 import (
 	"context"
+	"strings"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/bsoncodec"
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/core/dispatch"
+	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
 	"github.com/mongodb/mongo-go-driver/mongo/writeconcern"
@@ -31,6 +33,7 @@ type Database struct {
 	readSelector   description.ServerSelector
 	writeSelector  description.ServerSelector
 	registry       *bsoncodec.Registry
+	err            error // the error returned by operations if the name is invalid
 }
 
 func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions) *Database {
@@ -58,6 +61,7 @@ func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions)
 		readConcern:    rc,
 		writeConcern:   wc,
 		registry:       client.registry,
+		err:            validateDatabaseName(name),
 	}
 
 	db.readSelector = description.CompositeSelector([]description.ServerSelector{
@@ -73,6 +77,15 @@ func newDatabase(client *Client, name string, opts ...*options.DatabaseOptions)
 	return db
 }
 
+// validate returns an error if the database's name is invalid or if sess belongs to a different
+// client.
+func (db *Database) validate(sess *session.Client) error {
+	if db.err != nil {
+		return db.err
+	}
+	return db.client.ValidSession(sess)
+}
+
 // Client returns the Client the database was created from.
 func (db *Database) Client() *Client {
 	return db.client
@@ -88,13 +101,42 @@ func (db *Database) Collection(name string, opts ...*options.CollectionOptions)
 	return newCollection(db, name, opts...)
 }
 
+// primaryOnlyCommands are the lowercased names of commands that can only run on the primary.
+var primaryOnlyCommands = map[string]struct{}{
+	"insert":           {},
+	"update":           {},
+	"delete":           {},
+	"findandmodify":    {},
+	"create":           {},
+	"drop":             {},
+	"dropdatabase":     {},
+	"createindexes":    {},
+	"dropindexes":      {},
+	"renamecollection": {},
+	"collmod":          {},
+	"createuser":       {},
+	"dropuser":         {},
+	"updateuser":       {},
+}
+
 // RunCommand runs a command on the database. A user can supply a custom
 // context to this method, or nil to default to context.Background().
+//
+// The command is sent to a server matching the read preference given in opts, which defaults
+// to primary. Commands that can only run on the primary, such as write commands, return
+// ErrPrimaryRequired if given a non-primary read preference.
 func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts ...*options.RunCmdOptions) (bson.Raw, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := db.client.withTimeout(ctx, nil)
+	defer cancel()
+
+	if db.err != nil {
+		return nil, db.err
+	}
+
 	sess := sessionFromContext(ctx)
 
 	runCmd := options.MergeRunCmdOptions(opts...)
@@ -117,6 +159,11 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 	if err != nil {
 		return nil, err
 	}
+	if rp.Mode() != readpref.PrimaryMode && len(runCmdDoc) > 0 {
+		if _, ok := primaryOnlyCommands[strings.ToLower(runCmdDoc[0].Key)]; ok {
+			return nil, ErrPrimaryRequired
+		}
+	}
 	result, err := dispatch.Read(ctx,
 		command.Read{
 			DB:       db.Name(),
@@ -126,12 +173,12 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 			Clock:    db.client.clock,
 		},
 		db.client.topology,
-		readSelect,
+		makePinnedSelector(sess, readSelect),
 		db.client.id,
 		db.client.topology.SessionPool,
 	)
 
-	return result, replaceTopologyErr(err)
+	return result, replaceTopologyErr(timeoutErr(err, deadline))
 }
 
 // Drop drops this database from mongodb.
@@ -140,9 +187,12 @@ func (db *Database) Drop(ctx context.Context) error {
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := db.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := db.client.ValidSession(sess)
+	err := db.validate(sess)
 	if err != nil {
 		return err
 	}
@@ -155,12 +205,12 @@ func (db *Database) Drop(ctx context.Context) error {
 	_, err = dispatch.DropDatabase(
 		ctx, cmd,
 		db.client.topology,
-		db.writeSelector,
+		makePinnedSelector(sess, db.writeSelector),
 		db.client.id,
 		db.client.topology.SessionPool,
 	)
 	if err != nil && !command.IsNotFound(err) {
-		return replaceTopologyErr(err)
+		return replaceTopologyErr(timeoutErr(err, deadline))
 	}
 	return nil
 }
@@ -171,9 +221,12 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 		ctx = context.Background()
 	}
 
+	ctx, cancel, deadline := db.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := db.client.ValidSession(sess)
+	err := db.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -197,17 +250,19 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 	cursor, err := dispatch.ListCollections(
 		ctx, cmd,
 		db.client.topology,
-		db.readSelector,
+		makePinnedSelector(sess, db.readSelector),
 		db.client.id,
 		db.client.topology.SessionPool,
 		opts...,
 	)
-	if err != nil && !command.IsNotFound(err) {
-		return nil, replaceTopologyErr(err)
+	if err != nil {
+		if command.IsNotFound(err) {
+			return cursor, nil
+		}
+		return nil, replaceTopologyErr(timeoutErr(err, deadline))
 	}
 
-	return cursor, nil
-
+	return newTimeoutCursor(cursor, deadline, false), nil
 }
 
 // ReadConcern returns the read concern of this database.
@@ -12,9 +12,14 @@ import (
 
 	"fmt"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/objectid"
+	"github.com/mongodb/mongo-go-driver/core/address"
+	"github.com/mongodb/mongo-go-driver/core/description"
+	"github.com/mongodb/mongo-go-driver/core/event"
 	"github.com/mongodb/mongo-go-driver/internal/testutil"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
 	"github.com/mongodb/mongo-go-driver/mongo/readpref"
@@ -118,13 +123,13 @@ func TestDatabase_ReplaceTopologyError(t *testing.T) {
 	db := c.Database("TestDatabase_ReplaceTopologyError")
 
 	_, err = db.RunCommand(context.Background(), bsonx.Doc{{"ismaster", bsonx.Int32(1)}})
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	err = db.Drop(ctx)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 
 	_, err = db.ListCollections(ctx, nil)
-	require.Equal(t, err, ErrClientDisconnected)
+	require.Equal(t, err, ErrClientNotConnected)
 }
 
 func TestDatabase_RunCommand(t *testing.T) {
@@ -146,6 +151,63 @@ func TestDatabase_RunCommand(t *testing.T) {
 	require.Equal(t, ok.Double(), 1.0)
 }
 
+func TestDatabase_RunCommandPrimaryOnly(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient("mongodb://localhost")
+	require.NoError(t, err)
+	db := c.Database("TestDatabase_RunCommandPrimaryOnly")
+
+	cmd := bsonx.Doc{{"insert", bsonx.String("coll")}, {"documents", bsonx.Array(bsonx.Arr{})}}
+	_, err = db.RunCommand(ctx, cmd, options.RunCmd().SetReadPreference(readpref.SecondaryPreferred()))
+	require.Equal(t, ErrPrimaryRequired, err)
+}
+
+func TestDatabase_RunCommandReadPreference(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	if os.Getenv("TOPOLOGY") != "replica_set" {
+		t.Skip()
+	}
+
+	var mu sync.Mutex
+	var connIDs []string
+	monitor := &event.CommandMonitor{
+		Started: func(ctx context.Context, cse *event.CommandStartedEvent) {
+			if cse.CommandName == "dbStats" {
+				mu.Lock()
+				connIDs = append(connIDs, cse.ConnectionID)
+				mu.Unlock()
+			}
+		},
+	}
+
+	cs := testutil.ConnString(t)
+	c, err := NewClientWithOptions(cs.String(), options.Client().SetMonitor(monitor))
+	require.NoError(t, err)
+	require.NoError(t, c.Connect(ctx))
+	defer func() { _ = c.Disconnect(ctx) }()
+
+	db := c.Database("TestDatabase_RunCommandReadPreference")
+	_, err = db.RunCommand(ctx, bsonx.Doc{{"dbStats", bsonx.Int32(1)}},
+		options.RunCmd().SetReadPreference(readpref.SecondaryPreferred()))
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, connIDs, 1)
+
+	// connection IDs have the form "host:port[-n]"
+	addr := connIDs[0][:strings.Index(connIDs[0], "[")]
+	server, ok := c.topology.Description().Server(address.Address(addr))
+	require.True(t, ok, "no server with address %s", addr)
+	require.Equal(t, description.RSSecondary, server.Kind)
+}
+
 func TestDatabase_Drop(t *testing.T) {
 	t.Parallel()
 
@@ -26,13 +26,57 @@ var ErrUnacknowledgedWrite = errors.New("unacknowledged write")
 // disconnected client
 var ErrClientDisconnected = errors.New("client is disconnected")
 
+// ErrClientNotConnected is returned when a user attempts to call a method on a
+// client before calling Connect.
+var ErrClientNotConnected = errors.New("client is not connected")
+
+// ErrPipelineHasWriteStage is returned from AggregateOut when the pipeline
+// already contains a $out or $merge stage.
+var ErrPipelineHasWriteStage = errors.New("pipeline already contains a $out or $merge stage")
+
+// ErrMixedProjection is returned from ProjectionBuilder.Build when the projection both includes
+// and excludes fields other than _id.
+var ErrMixedProjection = errors.New("projection cannot both include and exclude fields other than _id")
+
+// ErrPrimaryRequired is returned from RunCommand when a command that can only run on the primary,
+// such as a write command, is given a non-primary read preference.
+var ErrPrimaryRequired = errors.New("command can only run on the primary but a non-primary read preference was given")
+
 func replaceTopologyErr(err error) error {
-	if err == topology.ErrTopologyClosed {
+	switch err {
+	case topology.ErrTopologyClosed:
 		return ErrClientDisconnected
+	case topology.ErrTopologyNotConnected:
+		return ErrClientNotConnected
 	}
 	return err
 }
 
+// InvalidNamespaceError is returned by the operations of a Database or Collection whose name
+// violates MongoDB's naming restrictions. It's returned before any command is sent to the server.
+type InvalidNamespaceError struct {
+	Name   string // The invalid name, or the full namespace if it's too long.
+	Reason string // The naming rule that was violated.
+}
+
+func (ine InvalidNamespaceError) Error() string {
+	return fmt.Sprintf("invalid namespace %q: %s", ine.Name, ine.Reason)
+}
+
+// UpdateConflictError is returned when building an update document that targets the same field
+// with different operators, or a field and one of its subfields.
+type UpdateConflictError struct {
+	Path            string // The path targeted by an operator.
+	ConflictingPath string // The path that conflicts with Path.
+}
+
+func (uce UpdateConflictError) Error() string {
+	if uce.Path == uce.ConflictingPath {
+		return fmt.Sprintf("update targets %q with more than one operator", uce.Path)
+	}
+	return fmt.Sprintf("update paths %q and %q conflict", uce.Path, uce.ConflictingPath)
+}
+
 // WriteError is a non-write concern failure that occurred as a result of a write
 // operation.
 type WriteError struct {
@@ -69,11 +113,13 @@ func writeErrorsFromResult(rwes []result.WriteError) WriteErrors {
 }
 
 // WriteConcernError is a write concern failure that occurred as a result of a
-// write operation.
+// write operation. WTimeout is true when the write was applied but could not be
+// replicated within the write concern's wtimeout.
 type WriteConcernError struct {
-	Code    int
-	Message string
-	Details bson.Raw
+	Code     int
+	Message  string
+	Details  bson.Raw
+	WTimeout bool
 }
 
 func (wce WriteConcernError) Error() string { return wce.Message }
@@ -99,7 +145,8 @@ func convertWriteConcernError(wce *result.WriteConcernError) *WriteConcernError
 		return nil
 	}
 
-	return &WriteConcernError{Code: wce.Code, Message: wce.ErrMsg, Details: wce.ErrInfo}
+	wtimeout, _ := wce.ErrInfo.Lookup("wtimeout").BooleanOK()
+	return &WriteConcernError{Code: wce.Code, Message: wce.ErrMsg, Details: wce.ErrInfo, WTimeout: wtimeout}
 }
 
 // BulkWriteError is an error for one operation in a bulk write.
@@ -154,7 +201,7 @@ func processWriteError(wce *result.WriteConcernError, wes []result.WriteError, e
 	case err != nil:
 		return rrNone, replaceTopologyErr(err)
 	case wce != nil:
-		return rrMany, WriteConcernError{Code: wce.Code, Message: wce.ErrMsg}
+		return rrMany, *convertWriteConcernError(wce)
 	case len(wes) > 0:
 		return rrMany, writeErrorsFromResult(wes)
 	default:
@@ -0,0 +1,63 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/result"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessWriteError_WriteConcernError(t *testing.T) {
+	t.Parallel()
+
+	decodeReply := func(t *testing.T, wce bsonx.Doc) result.Insert {
+		reply, err := bsonx.Doc{
+			{"ok", bsonx.Int32(1)},
+			{"n", bsonx.Int32(1)},
+			{"writeConcernError", bsonx.Document(wce)},
+		}.MarshalBSON()
+		require.NoError(t, err)
+
+		var res result.Insert
+		require.NoError(t, bson.Unmarshal(reply, &res))
+		return res
+	}
+
+	t.Run("wtimeout", func(t *testing.T) {
+		res := decodeReply(t, bsonx.Doc{
+			{"code", bsonx.Int32(64)},
+			{"errmsg", bsonx.String("waiting for replication timed out")},
+			{"errInfo", bsonx.Document(bsonx.Doc{{"wtimeout", bsonx.Boolean(true)}})},
+		})
+
+		rr, err := processWriteError(res.WriteConcernError, res.WriteErrors, nil)
+		require.Equal(t, rrMany, rr)
+		wce, ok := err.(WriteConcernError)
+		require.True(t, ok, "expected a WriteConcernError but got %T", err)
+		require.Equal(t, 64, wce.Code)
+		require.Equal(t, "waiting for replication timed out", wce.Message)
+		require.True(t, wce.WTimeout)
+		require.NotNil(t, wce.Details)
+	})
+
+	t.Run("other failure", func(t *testing.T) {
+		res := decodeReply(t, bsonx.Doc{
+			{"code", bsonx.Int32(100)},
+			{"errmsg", bsonx.String("Not enough data-bearing nodes")},
+		})
+
+		_, err := processWriteError(res.WriteConcernError, res.WriteErrors, nil)
+		wce, ok := err.(WriteConcernError)
+		require.True(t, ok, "expected a WriteConcernError but got %T", err)
+		require.Equal(t, 100, wce.Code)
+		require.False(t, wce.WTimeout)
+	})
+}
@@ -0,0 +1,173 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package filter provides helpers for building query filters.
+package filter
+
+import (
+	"reflect"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
+
+// Filter is a query filter built from the filter helpers, such as Eq and And. Filters can be
+// combined with And, Or and Nor, and can be passed anywhere a filter document is accepted.
+//
+// Example usage:
+//
+//	f := filter.And(
+//		filter.Eq("status", "A"),
+//		filter.Or(filter.Lt("qty", 30), filter.Regex("item", "^p", "")),
+//	).Build()
+type Filter struct {
+	doc bson.D
+}
+
+func fieldFilter(field string, value interface{}) Filter {
+	return Filter{doc: bson.D{{Key: field, Value: value}}}
+}
+
+func operatorFilter(field, operator string, value interface{}) Filter {
+	return fieldFilter(field, bson.D{{Key: operator, Value: value}})
+}
+
+// Eq matches documents where the value of field equals value.
+func Eq(field string, value interface{}) Filter {
+	return fieldFilter(field, value)
+}
+
+// Ne matches documents where the value of field does not equal value.
+func Ne(field string, value interface{}) Filter {
+	return operatorFilter(field, "$ne", value)
+}
+
+// Gt matches documents where the value of field is greater than value.
+func Gt(field string, value interface{}) Filter {
+	return operatorFilter(field, "$gt", value)
+}
+
+// Gte matches documents where the value of field is greater than or equal to value.
+func Gte(field string, value interface{}) Filter {
+	return operatorFilter(field, "$gte", value)
+}
+
+// Lt matches documents where the value of field is less than value.
+func Lt(field string, value interface{}) Filter {
+	return operatorFilter(field, "$lt", value)
+}
+
+// Lte matches documents where the value of field is less than or equal to value.
+func Lte(field string, value interface{}) Filter {
+	return operatorFilter(field, "$lte", value)
+}
+
+// In matches documents where the value of field equals any of values. A single slice or array
+// argument is treated as the list of values, so In("status", []string{"A", "D"}) is the same as
+// In("status", "A", "D").
+func In(field string, values ...interface{}) Filter {
+	return operatorFilter(field, "$in", valueList(values))
+}
+
+// Nin matches documents where the value of field equals none of values, or where field does not
+// exist. Like In, a single slice or array argument is treated as the list of values.
+func Nin(field string, values ...interface{}) Filter {
+	return operatorFilter(field, "$nin", valueList(values))
+}
+
+// valueList returns values as an array, expanding a single slice or array argument. Byte slices
+// and documents are single values, so they aren't expanded.
+func valueList(values []interface{}) bson.A {
+	if len(values) != 1 {
+		return bson.A(values)
+	}
+
+	switch values[0].(type) {
+	case []byte, bson.D:
+		return bson.A(values)
+	}
+
+	val := reflect.ValueOf(values[0])
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return bson.A(values)
+	}
+
+	list := make(bson.A, val.Len())
+	for i := range list {
+		list[i] = val.Index(i).Interface()
+	}
+	return list
+}
+
+// Exists matches documents that contain field if exists is true, or that do not contain it if
+// exists is false.
+func Exists(field string, exists bool) Filter {
+	return operatorFilter(field, "$exists", exists)
+}
+
+// Regex matches documents where the value of field matches the regular expression pattern with the
+// given options, such as "i" for case insensitive matching.
+func Regex(field, pattern, options string) Filter {
+	return fieldFilter(field, primitive.Regex{Pattern: pattern, Options: options})
+}
+
+// logical combines filters with the logical operator. Clauses that are themselves combined with
+// the same operator are merged into the new filter, since the operator is associative. The server
+// rejects a logical operator with no clauses, so an empty filter is returned if there are none.
+func logical(operator string, merge bool, filters []Filter) Filter {
+	if len(filters) == 0 {
+		return Filter{}
+	}
+
+	clauses := make(bson.A, 0, len(filters))
+	for _, f := range filters {
+		if nested, ok := f.clauses(operator); ok && merge {
+			clauses = append(clauses, nested...)
+			continue
+		}
+		clauses = append(clauses, f.Build())
+	}
+	return fieldFilter(operator, clauses)
+}
+
+// clauses returns the clauses of the filter if it's a single logical operator.
+func (f Filter) clauses(operator string) (bson.A, bool) {
+	if len(f.doc) != 1 || f.doc[0].Key != operator {
+		return nil, false
+	}
+	clauses, ok := f.doc[0].Value.(bson.A)
+	return clauses, ok
+}
+
+// And matches documents that match all of filters. With no filters, And returns an empty filter,
+// which matches every document.
+func And(filters ...Filter) Filter {
+	return logical("$and", true, filters)
+}
+
+// Or matches documents that match any of filters. With no filters, Or returns an empty filter,
+// which matches every document.
+func Or(filters ...Filter) Filter {
+	return logical("$or", true, filters)
+}
+
+// Nor matches documents that match none of filters. Unlike And and Or, nested Nor filters are not
+// merged. With no filters, Nor returns an empty filter, which matches every document.
+func Nor(filters ...Filter) Filter {
+	return logical("$nor", false, filters)
+}
+
+// Build returns the filter document.
+func (f Filter) Build() bson.D {
+	doc := make(bson.D, len(f.doc))
+	copy(doc, f.doc)
+	return doc
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (f Filter) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(f.Build())
+}
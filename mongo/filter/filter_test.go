@@ -0,0 +1,135 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		filter Filter
+		want   bson.D
+	}{
+		{"empty", Filter{}, bson.D{}},
+		{"eq", Eq("status", "A"), bson.D{{"status", "A"}}},
+		{"ne", Ne("status", "A"), bson.D{{"status", bson.D{{"$ne", "A"}}}}},
+		{"gt", Gt("qty", 20), bson.D{{"qty", bson.D{{"$gt", 20}}}}},
+		{"gte", Gte("qty", 20), bson.D{{"qty", bson.D{{"$gte", 20}}}}},
+		{"lt", Lt("qty", 20), bson.D{{"qty", bson.D{{"$lt", 20}}}}},
+		{"lte", Lte("qty", 20), bson.D{{"qty", bson.D{{"$lte", 20}}}}},
+		{"in", In("status", "A", "D"), bson.D{{"status", bson.D{{"$in", bson.A{"A", "D"}}}}}},
+		{"in slice", In("status", []string{"A", "D"}), bson.D{{"status", bson.D{{"$in", bson.A{"A", "D"}}}}}},
+		{"in array", In("qty", [2]int{5, 15}), bson.D{{"qty", bson.D{{"$in", bson.A{5, 15}}}}}},
+		{"in bson.A", In("qty", bson.A{5, 15}), bson.D{{"qty", bson.D{{"$in", bson.A{5, 15}}}}}},
+		{"in single value", In("status", "A"), bson.D{{"status", bson.D{{"$in", bson.A{"A"}}}}}},
+		{"in bytes", In("data", []byte{1, 2}), bson.D{{"data", bson.D{{"$in", bson.A{[]byte{1, 2}}}}}}},
+		{
+			"in document",
+			In("size", bson.D{{"h", 14}}),
+			bson.D{{"size", bson.D{{"$in", bson.A{bson.D{{"h", 14}}}}}}},
+		},
+		{
+			"in several slices",
+			In("tags", []string{"a"}, []string{"b"}),
+			bson.D{{"tags", bson.D{{"$in", bson.A{[]string{"a"}, []string{"b"}}}}}},
+		},
+		{"nin", Nin("status", "A", "D"), bson.D{{"status", bson.D{{"$nin", bson.A{"A", "D"}}}}}},
+		{"nin slice", Nin("status", []string{"A", "D"}), bson.D{{"status", bson.D{{"$nin", bson.A{"A", "D"}}}}}},
+		{"exists", Exists("qty", true), bson.D{{"qty", bson.D{{"$exists", true}}}}},
+		{"not exists", Exists("qty", false), bson.D{{"qty", bson.D{{"$exists", false}}}}},
+		{"regex", Regex("item", "^p", "i"), bson.D{{"item", primitive.Regex{Pattern: "^p", Options: "i"}}}},
+		{
+			"and",
+			And(Eq("status", "A"), Lt("qty", 30)),
+			bson.D{{"$and", bson.A{bson.D{{"status", "A"}}, bson.D{{"qty", bson.D{{"$lt", 30}}}}}}},
+		},
+		{
+			"or",
+			Or(Eq("status", "A"), Lt("qty", 30)),
+			bson.D{{"$or", bson.A{bson.D{{"status", "A"}}, bson.D{{"qty", bson.D{{"$lt", 30}}}}}}},
+		},
+		{
+			"nor",
+			Nor(Eq("price", 1.99), Eq("sale", true)),
+			bson.D{{"$nor", bson.A{bson.D{{"price", 1.99}}, bson.D{{"sale", true}}}}},
+		},
+		{"empty and", And(), bson.D{}},
+		{"empty or", Or(), bson.D{}},
+		{"empty nor", Nor(), bson.D{}},
+		{
+			"nested empty and",
+			And(Eq("a", 1), And()),
+			bson.D{{"$and", bson.A{bson.D{{"a", 1}}, bson.D{}}}},
+		},
+		{
+			"nested and is merged",
+			And(And(Eq("a", 1), Eq("b", 2)), Eq("c", 3), And(Eq("d", 4))),
+			bson.D{{"$and", bson.A{
+				bson.D{{"a", 1}}, bson.D{{"b", 2}}, bson.D{{"c", 3}}, bson.D{{"d", 4}},
+			}}},
+		},
+		{
+			"nested or is merged",
+			Or(Eq("a", 1), Or(Eq("b", 2), Eq("c", 3))),
+			bson.D{{"$or", bson.A{bson.D{{"a", 1}}, bson.D{{"b", 2}}, bson.D{{"c", 3}}}}},
+		},
+		{
+			"or within and",
+			And(Eq("status", "A"), Or(Lt("qty", 30), Regex("item", "^p", ""))),
+			bson.D{{"$and", bson.A{
+				bson.D{{"status", "A"}},
+				bson.D{{"$or", bson.A{
+					bson.D{{"qty", bson.D{{"$lt", 30}}}},
+					bson.D{{"item", primitive.Regex{Pattern: "^p"}}},
+				}}},
+			}}},
+		},
+		{
+			"and within or",
+			Or(And(Eq("a", 1), Eq("b", 2)), Eq("c", 3)),
+			bson.D{{"$or", bson.A{
+				bson.D{{"$and", bson.A{bson.D{{"a", 1}}, bson.D{{"b", 2}}}}},
+				bson.D{{"c", 3}},
+			}}},
+		},
+		{
+			"nested nor is not merged",
+			Nor(Nor(Eq("a", 1)), Eq("b", 2)),
+			bson.D{{"$nor", bson.A{
+				bson.D{{"$nor", bson.A{bson.D{{"a", 1}}}}},
+				bson.D{{"b", 2}},
+			}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.filter.Build())
+		})
+	}
+
+	t.Run("marshal", func(t *testing.T) {
+		filter := And(Eq("status", "A"), Gt("qty", int32(10)))
+
+		got, err := bson.Marshal(filter)
+		require.NoError(t, err)
+		want, err := bson.Marshal(bson.D{{"$and", bson.A{
+			bson.D{{"status", "A"}},
+			bson.D{{"qty", bson.D{{"$gt", int32(10)}}}},
+		}}})
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+}
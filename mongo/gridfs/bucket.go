@@ -15,6 +15,10 @@ import (
 
 	"time"
 
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
 	"github.com/mongodb/mongo-go-driver/bson/objectid"
 	"github.com/mongodb/mongo-go-driver/mongo"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
@@ -232,13 +236,14 @@ func (b *Bucket) Delete(fileID objectid.ObjectID) error {
 	}
 
 	res, err := b.filesColl.DeleteOne(ctx, bsonx.Doc{{"_id", bsonx.ObjectID(fileID)}})
-	if err == nil && res.DeletedCount == 0 {
-		err = ErrFileNotFound
-	}
 	if err != nil {
-		_ = b.deleteChunks(ctx, fileID) // can attempt to delete chunks even if no docs in files collection matched
+		// the file may still exist, so its chunks must be kept
 		return err
 	}
+	if res.DeletedCount == 0 {
+		_ = b.deleteChunks(ctx, fileID) // remove chunks orphaned by an earlier failed upload or delete
+		return ErrFileNotFound
+	}
 
 	return b.deleteChunks(ctx, fileID)
 }
@@ -321,6 +326,9 @@ func (b *Bucket) openDownloadStream(filter interface{}, opts ...*options.FindOpt
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		_ = cursor.Close(ctx)
+	}()
 
 	fileRdr, err := cursor.DecodeBytes()
 	if err != nil {
@@ -336,16 +344,45 @@ func (b *Bucket) openDownloadStream(filter interface{}, opts ...*options.FindOpt
 		return nil, err
 	}
 
-	fileLen := fileLenElem.Int32()
+	fileLen, err := numberAsInt64(fileLenElem)
+	if err != nil {
+		return nil, err
+	}
+
+	// files may have been uploaded with a chunk size other than the bucket's
+	chunkSize := b.chunkSize
+	if chunkSizeElem, err := fileRdr.LookupErr("chunkSize"); err == nil {
+		size, err := numberAsInt64(chunkSizeElem)
+		if err != nil {
+			return nil, err
+		}
+		chunkSize = int32(size)
+	}
+
 	if fileLen == 0 {
-		return newDownloadStream(nil, b.chunkSize, 0), nil
+		return newDownloadStream(nil, chunkSize, 0), nil
 	}
 
 	chunksCursor, err := b.findChunks(ctx, fileIDElem.ObjectID())
 	if err != nil {
 		return nil, err
 	}
-	return newDownloadStream(chunksCursor, b.chunkSize, int64(fileLen)), nil
+	return newDownloadStream(chunksCursor, chunkSize, fileLen), nil
+}
+
+// numberAsInt64 returns the value of a numeric field of a files collection document. Drivers store
+// these fields with different numeric types.
+func numberAsInt64(val bson.RawValue) (int64, error) {
+	switch val.Type {
+	case bsontype.Int32:
+		return int64(val.Int32()), nil
+	case bsontype.Int64:
+		return val.Int64(), nil
+	case bsontype.Double:
+		return int64(val.Double()), nil
+	default:
+		return 0, fmt.Errorf("expected a number but got BSON type %s", val.Type)
+	}
 }
 
 func deadlineContext(deadline time.Time) (context.Context, context.CancelFunc) {
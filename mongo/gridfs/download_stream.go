@@ -36,6 +36,7 @@ type DownloadStream struct {
 	closed        bool
 	buffer        []byte // store up to 1 chunk if the user provided buffer isn't big enough
 	bufferStart   int
+	bufferEnd     int   // length of the data in buffer
 	expectedChunk int32 // index of next expected chunk
 	readDeadline  time.Time
 	fileLen       int64
@@ -93,11 +94,14 @@ func (ds *DownloadStream) Read(p []byte) (int, error) {
 	var err error
 
 	for bytesCopied < len(p) {
-		if ds.bufferStart == 0 {
+		if ds.bufferStart == ds.bufferEnd {
 			// buffer empty
 			err = ds.fillBuffer(ctx)
 			if err != nil {
 				if err == errNoMoreChunks {
+					if bytesCopied == 0 {
+						return 0, io.EOF
+					}
 					return bytesCopied, nil
 				}
 
@@ -105,9 +109,9 @@ func (ds *DownloadStream) Read(p []byte) (int, error) {
 			}
 		}
 
-		copied := copy(p, ds.buffer[ds.bufferStart:])
+		copied := copy(p[bytesCopied:], ds.buffer[ds.bufferStart:ds.bufferEnd])
 		bytesCopied += copied
-		ds.bufferStart = (ds.bufferStart + copied) % int(ds.chunkSize)
+		ds.bufferStart += copied
 	}
 
 	return len(p), nil
@@ -132,7 +136,7 @@ func (ds *DownloadStream) Skip(skip int64) (int64, error) {
 	var err error
 
 	for skipped < skip {
-		if ds.bufferStart == 0 {
+		if ds.bufferStart == ds.bufferEnd {
 			err = ds.fillBuffer(ctx)
 			if err != nil {
 				if err == errNoMoreChunks {
@@ -143,18 +147,15 @@ func (ds *DownloadStream) Skip(skip int64) (int64, error) {
 			}
 		}
 
-		// try to skip whole chunk if possible
-		toSkip := 0
-		if skip-skipped < int64(len(ds.buffer)) {
-			// can skip whole chunk
-			toSkip = len(ds.buffer)
-		} else {
+		// skip the rest of the buffered chunk if possible
+		toSkip := int64(ds.bufferEnd - ds.bufferStart)
+		if skip-skipped < toSkip {
 			// can only skip part of buffer
-			toSkip = int(skip - skipped)
+			toSkip = skip - skipped
 		}
 
-		skipped += int64(toSkip)
-		ds.bufferStart = (ds.bufferStart + toSkip) % (int(ds.chunkSize))
+		skipped += toSkip
+		ds.bufferStart += int(toSkip)
 	}
 
 	return skip, nil
@@ -204,5 +205,6 @@ func (ds *DownloadStream) fillBuffer(ctx context.Context) error {
 
 	copy(ds.buffer, dataBytes)
 	ds.bufferStart = 0
+	ds.bufferEnd = len(dataBytes)
 	return nil
 }
@@ -0,0 +1,131 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package gridfs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// chunksCursor is a mongo.Cursor over a fixed list of chunk documents.
+type chunksCursor struct {
+	docs []bson.Raw
+	cur  bson.Raw
+}
+
+func newChunksCursor(t *testing.T, data []byte, chunkSize int) *chunksCursor {
+	c := &chunksCursor{}
+	for n := 0; n*chunkSize < len(data); n++ {
+		end := (n + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		doc, err := bsonx.Doc{
+			{"n", bsonx.Int32(int32(n))},
+			{"data", bsonx.Binary(0x00, data[n*chunkSize:end])},
+		}.MarshalBSON()
+		if err != nil {
+			t.Fatalf("error creating chunk: %s", err)
+		}
+		c.docs = append(c.docs, doc)
+	}
+	return c
+}
+
+func (c *chunksCursor) ID() int64 { return 0 }
+
+func (c *chunksCursor) Next(context.Context) bool {
+	if len(c.docs) == 0 {
+		return false
+	}
+	c.cur, c.docs = c.docs[0], c.docs[1:]
+	return true
+}
+
+func (c *chunksCursor) TryNext(ctx context.Context) bool { return c.Next(ctx) }
+
+func (c *chunksCursor) Decode(v interface{}) error { return bson.Unmarshal(c.cur, v) }
+
+func (c *chunksCursor) DecodeBytes() (bson.Raw, error) { return c.cur, nil }
+
+func (c *chunksCursor) Err() error { return nil }
+
+func (c *chunksCursor) Close(context.Context) error { return nil }
+
+func TestDownloadStream(t *testing.T) {
+	data := make([]byte, 2*10+4) // final chunk is short
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	t.Run("Read", func(t *testing.T) {
+		ds := newDownloadStream(newChunksCursor(t, data, 10), 10, int64(len(data)))
+
+		// read with a buffer that is smaller than a chunk and does not divide it
+		var got []byte
+		buf := make([]byte, 3)
+		for {
+			n, err := ds.Read(buf)
+			got = append(got, buf[:n]...)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("error reading: %s", err)
+			}
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("unexpected data. got %v; want %v", got, data)
+		}
+	})
+	t.Run("ReadAll with large buffer", func(t *testing.T) {
+		ds := newDownloadStream(newChunksCursor(t, data, 10), 10, int64(len(data)))
+
+		got, err := ioutil.ReadAll(ds)
+		if err != nil {
+			t.Fatalf("error reading: %s", err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("unexpected data. got %v; want %v", got, data)
+		}
+	})
+	t.Run("Skip", func(t *testing.T) {
+		ds := newDownloadStream(newChunksCursor(t, data, 10), 10, int64(len(data)))
+
+		skipped, err := ds.Skip(13)
+		if err != nil {
+			t.Fatalf("error skipping: %s", err)
+		}
+		if skipped != 13 {
+			t.Errorf("unexpected number of bytes skipped. got %d; want %d", skipped, 13)
+		}
+		got, err := ioutil.ReadAll(ds)
+		if err != nil {
+			t.Fatalf("error reading: %s", err)
+		}
+		if !bytes.Equal(got, data[13:]) {
+			t.Errorf("unexpected data. got %v; want %v", got, data[13:])
+		}
+	})
+	t.Run("Skip past end", func(t *testing.T) {
+		ds := newDownloadStream(newChunksCursor(t, data, 10), 10, int64(len(data)))
+
+		skipped, err := ds.Skip(100)
+		if err != nil {
+			t.Fatalf("error skipping: %s", err)
+		}
+		if skipped != int64(len(data)) {
+			t.Errorf("unexpected number of bytes skipped. got %d; want %d", skipped, len(data))
+		}
+	})
+}
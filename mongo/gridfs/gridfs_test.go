@@ -8,6 +8,8 @@ package gridfs
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
@@ -694,3 +696,164 @@ func runDeleteTest(t *testing.T, test test, bucket *Bucket) {
 		}
 	}
 }
+
+// setupTestBucket creates a bucket with a chunk size of 1KB and returns a function that drops it and
+// disconnects its client.
+func setupTestBucket(t *testing.T, name string) (*mongo.Database, *Bucket, func()) {
+	client, err := mongo.NewClientFromConnString(testutil.ConnString(t))
+	testhelpers.RequireNil(t, err, "error creating client: %s", err)
+	err = client.Connect(ctx)
+	testhelpers.RequireNil(t, err, "error connecting client: %s", err)
+
+	db := client.Database("gridFSTestDB")
+	bucket, err := NewBucket(db, options.GridFSBucket().SetName(name).SetChunkSizeBytes(1024))
+	testhelpers.RequireNil(t, err, "error creating bucket: %s", err)
+
+	return db, bucket, func() {
+		_ = bucket.Drop()
+		_ = client.Disconnect(ctx)
+	}
+}
+
+func TestGridFSRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db, bucket, cleanup := setupTestBucket(t, "roundtrip")
+	defer cleanup()
+
+	// 5.5 chunks so the final chunk is short
+	data := make([]byte, 5*1024+512)
+	_, err := rand.Read(data)
+	testhelpers.RequireNil(t, err, "error generating data: %s", err)
+	want := sha256.Sum256(data)
+
+	checkDownload := func(t *testing.T, fileID objectid.ObjectID) {
+		var dst bytes.Buffer
+		n, err := bucket.DownloadToStream(fileID, &dst)
+		testhelpers.RequireNil(t, err, "error downloading file: %s", err)
+		if n != int64(len(data)) {
+			t.Errorf("unexpected number of bytes downloaded. got %d; want %d", n, len(data))
+		}
+		if got := sha256.Sum256(dst.Bytes()); got != want {
+			t.Errorf("checksum of downloaded file does not match. got %x; want %x", got, want)
+		}
+
+		// read through a download stream with a buffer smaller than a chunk
+		ds, err := bucket.OpenDownloadStream(fileID)
+		testhelpers.RequireNil(t, err, "error opening download stream: %s", err)
+		read, err := ioutil.ReadAll(ds)
+		testhelpers.RequireNil(t, err, "error reading download stream: %s", err)
+		if got := sha256.Sum256(read); got != want {
+			t.Errorf("checksum of download stream does not match. got %x; want %x", got, want)
+		}
+	}
+
+	t.Run("UploadFromStream", func(t *testing.T) {
+		fileID, err := bucket.UploadFromStream("stream.bin", bytes.NewReader(data))
+		testhelpers.RequireNil(t, err, "error uploading file: %s", err)
+
+		count, err := db.Collection("roundtrip.chunks").CountDocuments(ctx, bsonx.Doc{{"files_id", bsonx.ObjectID(fileID)}})
+		testhelpers.RequireNil(t, err, "error counting chunks: %s", err)
+		if count != 6 {
+			t.Errorf("unexpected number of chunks. got %d; want %d", count, 6)
+		}
+
+		checkDownload(t, fileID)
+	})
+	t.Run("OpenUploadStream", func(t *testing.T) {
+		us, err := bucket.OpenUploadStream("writes.bin")
+		testhelpers.RequireNil(t, err, "error opening upload stream: %s", err)
+		// write in pieces that do not line up with chunk boundaries
+		for start := 0; start < len(data); start += 700 {
+			end := start + 700
+			if end > len(data) {
+				end = len(data)
+			}
+			_, err = us.Write(data[start:end])
+			testhelpers.RequireNil(t, err, "error writing to upload stream: %s", err)
+		}
+		err = us.Close()
+		testhelpers.RequireNil(t, err, "error closing upload stream: %s", err)
+
+		checkDownload(t, us.FileID)
+	})
+}
+
+func TestGridFSFileOperations(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db, bucket, cleanup := setupTestBucket(t, "fileops")
+	defer cleanup()
+
+	data := make([]byte, 3*1024+10)
+	chunksColl := db.Collection("fileops.chunks")
+	countChunks := func(t *testing.T, fileID objectid.ObjectID) int64 {
+		count, err := chunksColl.CountDocuments(ctx, bsonx.Doc{{"files_id", bsonx.ObjectID(fileID)}})
+		testhelpers.RequireNil(t, err, "error counting chunks: %s", err)
+		return count
+	}
+
+	t.Run("Delete", func(t *testing.T) {
+		fileID, err := bucket.UploadFromStream("delete.bin", bytes.NewReader(data))
+		testhelpers.RequireNil(t, err, "error uploading file: %s", err)
+		if count := countChunks(t, fileID); count != 4 {
+			t.Fatalf("unexpected number of chunks. got %d; want %d", count, 4)
+		}
+
+		err = bucket.Delete(fileID)
+		testhelpers.RequireNil(t, err, "error deleting file: %s", err)
+		if count := countChunks(t, fileID); count != 0 {
+			t.Errorf("chunks remain after delete. got %d; want %d", count, 0)
+		}
+		if _, err = bucket.OpenDownloadStream(fileID); err != ErrFileNotFound {
+			t.Errorf("unexpected error opening deleted file. got %v; want %v", err, ErrFileNotFound)
+		}
+
+		if err = bucket.Delete(fileID); err != ErrFileNotFound {
+			t.Errorf("unexpected error deleting missing file. got %v; want %v", err, ErrFileNotFound)
+		}
+	})
+	t.Run("Delete removes orphaned chunks", func(t *testing.T) {
+		us, err := bucket.OpenUploadStream("orphan.bin")
+		testhelpers.RequireNil(t, err, "error opening upload stream: %s", err)
+		// upload the chunks without writing the files collection document
+		_, err = us.Write(data)
+		testhelpers.RequireNil(t, err, "error writing to upload stream: %s", err)
+		err = us.uploadChunks(ctx)
+		testhelpers.RequireNil(t, err, "error uploading chunks: %s", err)
+
+		if err = bucket.Delete(us.FileID); err != ErrFileNotFound {
+			t.Errorf("unexpected error deleting file without metadata. got %v; want %v", err, ErrFileNotFound)
+		}
+		if count := countChunks(t, us.FileID); count != 0 {
+			t.Errorf("orphaned chunks remain after delete. got %d; want %d", count, 0)
+		}
+	})
+	t.Run("Rename and Find", func(t *testing.T) {
+		fileID, err := bucket.UploadFromStream("before.bin", bytes.NewReader(data))
+		testhelpers.RequireNil(t, err, "error uploading file: %s", err)
+
+		err = bucket.Rename(fileID, "after.bin")
+		testhelpers.RequireNil(t, err, "error renaming file: %s", err)
+
+		cursor, err := bucket.Find(bsonx.Doc{{"filename", bsonx.String("after.bin")}})
+		testhelpers.RequireNil(t, err, "error finding file: %s", err)
+		defer func() { _ = cursor.Close(ctx) }()
+		if !cursor.Next(ctx) {
+			t.Fatalf("expected to find the renamed file")
+		}
+		doc, err := cursor.DecodeBytes()
+		testhelpers.RequireNil(t, err, "error decoding file: %s", err)
+		if id := doc.Lookup("_id").ObjectID(); id != fileID {
+			t.Errorf("unexpected file found. got %s; want %s", id.Hex(), fileID.Hex())
+		}
+
+		if err = bucket.Rename(objectid.New(), "missing.bin"); err != ErrFileNotFound {
+			t.Errorf("unexpected error renaming missing file. got %v; want %v", err, ErrFileNotFound)
+		}
+	})
+}
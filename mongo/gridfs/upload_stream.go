@@ -44,6 +44,12 @@ type UploadStream struct {
 
 // NewUploadStream creates a new upload stream.
 func newUploadStream(upload *Upload, fileID objectid.ObjectID, filename string, chunks *mongo.Collection, files *mongo.Collection) *UploadStream {
+	// The buffer holds a whole number of chunks so that only the final chunk of a file can be short.
+	numChunks := UploadBufferSize / int(upload.chunkSize)
+	if numChunks == 0 {
+		numChunks = 1
+	}
+
 	return &UploadStream{
 		Upload: upload,
 		FileID: fileID,
@@ -51,7 +57,7 @@ func newUploadStream(upload *Upload, fileID objectid.ObjectID, filename string,
 		chunksColl: chunks,
 		filename:   filename,
 		filesColl:  files,
-		buffer:     make([]byte, UploadBufferSize),
+		buffer:     make([]byte, numChunks*int(upload.chunkSize)),
 	}
 }
 
@@ -105,23 +111,18 @@ func (us *UploadStream) Write(p []byte) (int, error) {
 	}
 
 	origLen := len(p)
-	for {
-		if us.bufferIndex == UploadBufferSize {
+	for len(p) > 0 {
+		n := copy(us.buffer[us.bufferIndex:], p) // copy as much as possible
+		p = p[n:]
+		us.bufferIndex += n
+
+		if us.bufferIndex == len(us.buffer) {
 			err := us.uploadChunks(ctx)
 			if err != nil {
 				return 0, err
 			}
 			us.bufferIndex = 0
 		}
-
-		if len(p) == 0 {
-			break
-		}
-
-		n := copy(us.buffer[us.bufferIndex:], p) // copy as much as possible
-		p = p[n:]
-		us.bufferIndex += n
-		break
 	}
 
 	return origLen, nil
@@ -150,7 +151,7 @@ func (us *UploadStream) Abort() error {
 func (us *UploadStream) uploadChunks(ctx context.Context) error {
 	numChunks := math.Ceil(float64(us.bufferIndex) / float64(us.chunkSize))
 
-	docs := make([]interface{}, int(numChunks))
+	docs := make([]interface{}, 0, int(numChunks))
 
 	for i := 0; i < us.bufferIndex; i += int(us.chunkSize) {
 		var chunkData []byte
@@ -160,12 +161,12 @@ func (us *UploadStream) uploadChunks(ctx context.Context) error {
 			chunkData = us.buffer[i : i+int(us.chunkSize)]
 		}
 
-		docs[us.chunkIndex] = bsonx.Doc{
+		docs = append(docs, bsonx.Doc{
 			{"_id", bsonx.ObjectID(objectid.New())},
 			{"files_id", bsonx.ObjectID(us.FileID)},
 			{"n", bsonx.Int32(int32(us.chunkIndex))},
 			{"data", bsonx.Binary(0x00, chunkData)},
-		}
+		})
 
 		us.chunkIndex++
 		us.fileLen += int64(len(chunkData))
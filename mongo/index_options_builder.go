@@ -130,6 +130,20 @@ func (iob *IndexOptionsBuilder) Collation(collation bsonx.Doc) *IndexOptionsBuil
 	return iob
 }
 
+// WildcardProjection sets the wildcardProjection option, which selects the fields included in or
+// excluded from a wildcard index. It requires MongoDB 4.2 or later.
+func (iob *IndexOptionsBuilder) WildcardProjection(wildcardProjection bsonx.Doc) *IndexOptionsBuilder {
+	iob.document = append(iob.document, bsonx.Elem{"wildcardProjection", bsonx.Document(wildcardProjection)})
+	return iob
+}
+
+// Hidden sets the hidden option. A hidden index is maintained but is not used by the query planner,
+// so the effect of dropping it can be evaluated first. It requires MongoDB 4.4 or later.
+func (iob *IndexOptionsBuilder) Hidden(hidden bool) *IndexOptionsBuilder {
+	iob.document = append(iob.document, bsonx.Elem{"hidden", bsonx.Boolean(hidden)})
+	return iob
+}
+
 // Build returns the BSON document from the builder
 func (iob *IndexOptionsBuilder) Build() bsonx.Doc {
 	return iob.document
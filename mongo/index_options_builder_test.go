@@ -0,0 +1,88 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexOptionsBuilder_ExpireAfterSeconds(t *testing.T) {
+	got := NewIndexOptionsBuilder().ExpireAfterSeconds(3600).Build()
+	require.True(t, got.Equal(bsonx.Doc{{"expireAfterSeconds", bsonx.Int32(3600)}}),
+		"expected an expireAfterSeconds option, got %v", got)
+}
+
+func TestIndexOptionsBuilder_WildcardProjection(t *testing.T) {
+	projection := bsonx.Doc{{"a.b", bsonx.Int32(1)}, {"c", bsonx.Int32(1)}}
+	got := NewIndexOptionsBuilder().WildcardProjection(projection).Build()
+	require.True(t, got.Equal(bsonx.Doc{{"wildcardProjection", bsonx.Document(projection)}}),
+		"expected a wildcardProjection option, got %v", got)
+}
+
+func TestIndexOptionsBuilder_Hidden(t *testing.T) {
+	got := NewIndexOptionsBuilder().Hidden(true).Build()
+	require.True(t, got.Equal(bsonx.Doc{{"hidden", bsonx.Boolean(true)}}), "expected a hidden option, got %v", got)
+}
+
+func TestGetOrGenerateIndexName_Wildcard(t *testing.T) {
+	name, err := getOrGenerateIndexName(IndexModel{Keys: bsonx.Doc{{"$**", bsonx.Int32(1)}}})
+	require.NoError(t, err)
+	require.Equal(t, "$**_1", name)
+}
+
+func TestGetOrGenerateIndexName(t *testing.T) {
+	testCases := []struct {
+		name string
+		keys bsonx.Doc
+		want string
+	}{
+		{"single field", bsonx.Doc{{"a", bsonx.Int32(1)}}, "a_1"},
+		{"compound", bsonx.Doc{{"a", bsonx.Int32(1)}, {"b", bsonx.Int64(-1)}}, "a_1_b_-1"},
+		{"double direction", bsonx.Doc{{"a", bsonx.Double(1)}, {"b", bsonx.Double(-1)}}, "a_1_b_-1"},
+		{"text", bsonx.Doc{{"title", bsonx.String("text")}, {"body", bsonx.String("text")}}, "title_text_body_text"},
+		{"geo", bsonx.Doc{{"loc", bsonx.String("2dsphere")}, {"category", bsonx.Int32(1)}}, "loc_2dsphere_category_1"},
+		{"hashed", bsonx.Doc{{"_id", bsonx.String("hashed")}}, "_id_hashed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := getOrGenerateIndexName(IndexModel{Keys: tc.keys})
+			require.NoError(t, err)
+			require.Equal(t, tc.want, name)
+		})
+	}
+
+	t.Run("name option", func(t *testing.T) {
+		name, err := getOrGenerateIndexName(IndexModel{
+			Keys:    bsonx.Doc{{"a", bsonx.Int32(1)}},
+			Options: bsonx.Doc{{"name", bsonx.String("custom")}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "custom", name)
+	})
+	t.Run("too long", func(t *testing.T) {
+		_, err := getOrGenerateIndexName(IndexModel{Keys: bsonx.Doc{{strings.Repeat("a", 126), bsonx.Int32(1)}}})
+		require.Equal(t, ErrIndexNameTooLong, err)
+
+		// the limit does not apply to names given explicitly
+		long := strings.Repeat("a", 200)
+		name, err := getOrGenerateIndexName(IndexModel{
+			Keys:    bsonx.Doc{{strings.Repeat("a", 126), bsonx.Int32(1)}},
+			Options: bsonx.Doc{{"name", bsonx.String(long)}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, long, name)
+	})
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := getOrGenerateIndexName(IndexModel{Keys: bsonx.Doc{{"a", bsonx.Boolean(true)}}})
+		require.Equal(t, ErrInvalidIndexValue, err)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/mongodb/mongo-go-driver/bson"
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
@@ -26,6 +27,13 @@ var ErrInvalidIndexValue = errors.New("invalid index value")
 // ErrNonStringIndexName indicates that the index name specified in the options is not a string.
 var ErrNonStringIndexName = errors.New("index name must be a string")
 
+// ErrIndexNameTooLong indicates that the name generated for an index from its keys is longer than
+// maxIndexNameLength bytes. A shorter name can be given with the name index option.
+var ErrIndexNameTooLong = errors.New("generated index name is longer than 127 bytes")
+
+// maxIndexNameLength is the longest index name generated for an index model without a name.
+const maxIndexNameLength = 127
+
 // ErrMultipleIndexDrop indicates that multiple indexes would be dropped from a call to IndexView.DropOne.
 var ErrMultipleIndexDrop = errors.New("multiple indexes would be dropped")
 
@@ -42,9 +50,12 @@ type IndexModel struct {
 
 // List returns a cursor iterating over all the indexes in the collection.
 func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOptions) (Cursor, error) {
+	ctx, cancel, deadline := iv.coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := iv.coll.client.ValidSession(sess)
+	err := iv.coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -55,14 +66,19 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 		Clock:   iv.coll.client.clock,
 	}
 
-	return dispatch.ListIndexes(
+	cursor, err := dispatch.ListIndexes(
 		ctx, listCmd,
 		iv.coll.client.topology,
-		iv.coll.writeSelector,
+		makePinnedSelector(sess, iv.coll.writeSelector),
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
 		opts...,
 	)
+	if err != nil {
+		return nil, timeoutErr(err, deadline)
+	}
+
+	return newTimeoutCursor(cursor, deadline, false), nil
 }
 
 // CreateOne creates a single index in the collection specified by the model.
@@ -76,7 +92,8 @@ func (iv IndexView) CreateOne(ctx context.Context, model IndexModel, opts ...*op
 }
 
 // CreateMany creates multiple indexes in the collection specified by the models. The names of the
-// creates indexes are returned.
+// created indexes are returned in the same order as the models. A model without a name index option
+// is given a name generated from its keys, e.g. "a_1_b_-1" for the keys {a: 1, b: -1}.
 func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ...*options.CreateIndexesOptions) ([]string, error) {
 	names := make([]string, 0, len(models))
 	indexes := bsonx.Arr{}
@@ -102,9 +119,12 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 		indexes = append(indexes, bsonx.Document(index))
 	}
 
+	ctx, cancel, deadline := iv.coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := iv.coll.client.ValidSession(sess)
+	err := iv.coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -119,13 +139,13 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	_, err = dispatch.CreateIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
-		iv.coll.writeSelector,
+		makePinnedSelector(sess, iv.coll.writeSelector),
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
 		opts...,
 	)
 	if err != nil {
-		return nil, err
+		return nil, timeoutErr(err, deadline)
 	}
 
 	return names, nil
@@ -137,9 +157,12 @@ func (iv IndexView) DropOne(ctx context.Context, name string, opts ...*options.D
 		return nil, ErrMultipleIndexDrop
 	}
 
+	ctx, cancel, deadline := iv.coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := iv.coll.client.ValidSession(sess)
+	err := iv.coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -151,21 +174,25 @@ func (iv IndexView) DropOne(ctx context.Context, name string, opts ...*options.D
 		Clock:   iv.coll.client.clock,
 	}
 
-	return dispatch.DropIndexes(
+	res, err := dispatch.DropIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
-		iv.coll.writeSelector,
+		makePinnedSelector(sess, iv.coll.writeSelector),
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
 		opts...,
 	)
+	return res, timeoutErr(err, deadline)
 }
 
 // DropAll drops all indexes in the collection.
 func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOptions) (bson.Raw, error) {
+	ctx, cancel, deadline := iv.coll.client.withTimeout(ctx, nil)
+	defer cancel()
+
 	sess := sessionFromContext(ctx)
 
-	err := iv.coll.client.ValidSession(sess)
+	err := iv.coll.validate(sess)
 	if err != nil {
 		return nil, err
 	}
@@ -177,16 +204,21 @@ func (iv IndexView) DropAll(ctx context.Context, opts ...*options.DropIndexesOpt
 		Clock:   iv.coll.client.clock,
 	}
 
-	return dispatch.DropIndexes(
+	res, err := dispatch.DropIndexes(
 		ctx, cmd,
 		iv.coll.client.topology,
-		iv.coll.writeSelector,
+		makePinnedSelector(sess, iv.coll.writeSelector),
 		iv.coll.client.id,
 		iv.coll.client.topology.SessionPool,
 		opts...,
 	)
+	return res, timeoutErr(err, deadline)
 }
 
+// getOrGenerateIndexName returns the name index option of model if it has one. Otherwise it
+// generates a name from the keys in the form the server uses, joining each key and its value with
+// underscores, e.g. {a: 1, b: -1} is named "a_1_b_-1" and {loc: "2dsphere"} is named
+// "loc_2dsphere". ErrIndexNameTooLong is returned if the generated name is too long.
 func getOrGenerateIndexName(model IndexModel) (string, error) {
 	if model.Options != nil {
 		nameVal, err := model.Options.LookupErr("name")
@@ -233,6 +265,8 @@ func getOrGenerateIndexName(model IndexModel) (string, error) {
 			value = fmt.Sprintf("%d", elem.Value.Int32())
 		case bsontype.Int64:
 			value = fmt.Sprintf("%d", elem.Value.Int64())
+		case bsontype.Double:
+			value = strconv.FormatFloat(elem.Value.Double(), 'g', -1, 64)
 		case bsontype.String:
 			value = elem.Value.StringValue()
 		default:
@@ -247,5 +281,9 @@ func getOrGenerateIndexName(model IndexModel) (string, error) {
 		first = false
 	}
 
+	if name.Len() > maxIndexNameLength {
+		return "", ErrIndexNameTooLong
+	}
+
 	return name.String(), nil
 }
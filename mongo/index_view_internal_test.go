@@ -228,6 +228,86 @@ func TestIndexView_CreateOneWithCollationOption(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIndexView_CreateOneWithPartialFilterExpression(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	_, coll := getIndexableCollection(t)
+	initCollection(t, coll)
+	indexView := coll.Indexes()
+
+	partialFilter := bsonx.Doc{{"x", bsonx.Document(bsonx.Doc{{"$gte", bsonx.Int32(3)}})}}
+	name, err := indexView.CreateOne(
+		context.Background(),
+		IndexModel{
+			Keys: bsonx.Doc{{"x", bsonx.Int32(1)}},
+			Options: NewIndexOptionsBuilder().
+				PartialFilterExpression(partialFilter).
+				Build(),
+		},
+	)
+	require.NoError(t, err)
+
+	cursor, err := indexView.List(context.Background())
+	require.NoError(t, err)
+	var found bool
+	for cursor.Next(context.Background()) {
+		var idx bsonx.Doc
+		require.NoError(t, cursor.Decode(&idx))
+		if idx.Lookup("name").StringValue() != name {
+			continue
+		}
+		found = true
+		require.True(t, idx.Lookup("partialFilterExpression").Document().Equal(partialFilter))
+	}
+	require.NoError(t, cursor.Err())
+	require.True(t, found, "expected index %s to be listed", name)
+
+	// the partial index only contains the documents matching its filter, so a count that is forced to
+	// use it only sees those documents
+	count, err := coll.Count(context.Background(), partialFilter, options.Count().SetHint(name))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
+func TestIndexView_CreateOneWithExpireAfterSeconds(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	_, coll := getIndexableCollection(t)
+	indexView := coll.Indexes()
+
+	name, err := indexView.CreateOne(
+		context.Background(),
+		IndexModel{
+			Keys:    bsonx.Doc{{"createdAt", bsonx.Int32(1)}},
+			Options: NewIndexOptionsBuilder().ExpireAfterSeconds(3600).Build(),
+		},
+	)
+	require.NoError(t, err)
+
+	cursor, err := indexView.List(context.Background())
+	require.NoError(t, err)
+	var found bool
+	for cursor.Next(context.Background()) {
+		var idx bsonx.Doc
+		require.NoError(t, cursor.Decode(&idx))
+		if idx.Lookup("name").StringValue() != name {
+			continue
+		}
+		found = true
+		require.Equal(t, int32(3600), idx.Lookup("expireAfterSeconds").Int32())
+	}
+	require.NoError(t, cursor.Err())
+	require.True(t, found, "expected index %s to be listed", name)
+}
+
 func TestIndexView_CreateOneWithNilKeys(t *testing.T) {
 	t.Parallel()
 
@@ -13,6 +13,7 @@ import (
 	"net"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/mongodb/mongo-go-driver/options"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
@@ -31,9 +32,7 @@ type Dialer interface {
 // provided type into BSON bytes and append those bytes to the provided []byte.
 // The AppendBSON can return a non-nil error and non-nil []byte. The AppendBSON
 // method may also write incomplete BSON to the []byte.
-type BSONAppender interface {
-	AppendBSON([]byte, interface{}) ([]byte, error)
-}
+type BSONAppender = options.BSONAppender
 
 // BSONAppenderFunc is an adapter function that allows any function that
 // satisfies the AppendBSON method signature to be used where a BSONAppender is
@@ -79,18 +78,101 @@ func transformDocument(registry *bsoncodec.Registry, val interface{}) (bsonx.Doc
 	if doc, ok := val.(bsonx.Doc); ok {
 		return doc.Copy(), nil
 	}
-	if bs, ok := val.([]byte); ok {
-		// Slight optimization so we'll just use MarshalBSON and not go through the codec machinery.
-		val = bson.Raw(bs)
+	// Pre-serialized documents are read directly instead of going through the codec machinery,
+	// which preserves their contents byte for byte.
+	if raw, ok := rawDocument(val); ok {
+		doc, err := bsonx.ReadDoc(raw)
+		if err != nil {
+			return nil, MarshalError{Value: raw, Err: err}
+		}
+		return doc, nil
+	}
+	switch raw := val.(type) {
+	case bson.D:
+		// A bson.D is converted element by element instead of being marshaled and read back.
+		doc, err := bson.DocumentFromDWithRegistry(registry, raw)
+		if err != nil {
+			return nil, MarshalError{Value: val, Err: err}
+		}
+		return doc, nil
+	}
+
+	bufp := docBufPool.Get().(*[]byte)
+	b, err := bson.MarshalAppendWithRegistry(registry, (*bufp)[:0], val)
+	if err != nil {
+		putDocBuf(bufp, *bufp)
+		return nil, MarshalError{Value: val, Err: err}
 	}
+	// ReadDoc copies everything it keeps out of b, so the buffer can be reused once it returns.
+	doc, err := bsonx.ReadDoc(b)
+	putDocBuf(bufp, b)
+	return doc, err
+}
+
+// appendDocument serializes val with appender and returns a copy of the appended bytes, so that they
+// are sent as is without being read into a bsonx.Doc. The bytes are validated first because an
+// appender may write incomplete or invalid BSON.
+func appendDocument(appender BSONAppender, val interface{}) (bson.Raw, error) {
+	bufp := docBufPool.Get().(*[]byte)
+	// Only the buffer drawn from the pool is put back, since b may not have been grown from it.
+	defer putDocBuf(bufp, *bufp)
 
-	// TODO(skriptble): Use a pool of these instead.
-	buf := make([]byte, 0, 256)
-	b, err := bson.MarshalAppendWithRegistry(registry, buf[:0], val)
+	b, err := appender.AppendBSON((*bufp)[:0], val)
+	if err == nil {
+		err = bson.Raw(b).Validate()
+	}
 	if err != nil {
 		return nil, MarshalError{Value: val, Err: err}
 	}
-	return bsonx.ReadDoc(b)
+	return append(bson.Raw(nil), b...), nil
+}
+
+// maxPooledDocBufSize is the capacity above which a buffer is not returned to docBufPool, so that
+// marshaling a single large document does not pin that memory for the life of the pool.
+const maxPooledDocBufSize = 16 * 1024
+
+// docBufPool holds the buffers that transformDocument marshals values into.
+var docBufPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// putDocBuf returns b, which was grown from the buffer drawn as bufp, to docBufPool.
+func putDocBuf(bufp *[]byte, b []byte) {
+	if cap(b) > maxPooledDocBufSize {
+		return
+	}
+	*bufp = b[:0]
+	docBufPool.Put(bufp)
+}
+
+// transformFilter transforms the filter of a find command. A pre-serialized filter is validated and
+// returned as is, so that it is spliced into the command without being read and written again; any
+// other filter is transformed with transformDocument.
+func transformFilter(registry *bsoncodec.Registry, filter interface{}) (bsonx.Doc, bson.Raw, error) {
+	raw, ok := rawDocument(filter)
+	if !ok {
+		doc, err := transformDocument(registry, filter)
+		return doc, nil, err
+	}
+
+	if err := raw.Validate(); err != nil {
+		return nil, nil, MarshalError{Value: raw, Err: err}
+	}
+	return nil, raw, nil
+}
+
+// rawDocument returns val as a bson.Raw if it is a pre-serialized document.
+func rawDocument(val interface{}) (bson.Raw, bool) {
+	switch tt := val.(type) {
+	case bson.Raw:
+		return tt, true
+	case []byte:
+		return tt, true
+	}
+	return nil, false
 }
 
 func ensureID(d bsonx.Doc) (bsonx.Doc, interface{}) {
@@ -108,6 +190,7 @@ func ensureID(d bsonx.Doc) (bsonx.Doc, interface{}) {
 	return d, id
 }
 
+// ensureDollarKey checks that the first key of the transformed update document doc begins with '$'.
 func ensureDollarKey(doc bsonx.Doc) error {
 	if len(doc) > 0 && !strings.HasPrefix(doc[0].Key, "$") {
 		return errors.New("update document must contain key beginning with '$'")
@@ -115,6 +198,15 @@ func ensureDollarKey(doc bsonx.Doc) error {
 	return nil
 }
 
+// ensureNoDollarKey checks that the first key of the transformed replacement document doc does not
+// begin with '$'.
+func ensureNoDollarKey(doc bsonx.Doc) error {
+	if len(doc) > 0 && strings.HasPrefix(doc[0].Key, "$") {
+		return errors.New("replacement document cannot contains keys beginning with '$")
+	}
+	return nil
+}
+
 func transformAggregatePipeline(registry *bsoncodec.Registry, pipeline interface{}) (bsonx.Arr, error) {
 	pipelineArr := bsonx.Arr{}
 	switch t := pipeline.(type) {
@@ -7,11 +7,15 @@
 package mongo
 
 import (
+	"bytes"
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/core/command"
+	"github.com/mongodb/mongo-go-driver/core/description"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
@@ -28,6 +32,20 @@ func TestTransformDocument(t *testing.T) {
 			bsonx.Doc{{"foo", bsonx.String("bar")}},
 			nil,
 		},
+		{
+			"bson.D",
+			bson.D{
+				{"$set", bson.D{{"a", bson.A{int32(1), bson.D{{"b", nil}}}}}},
+				{"$inc", bson.D{{"n", 1}}},
+			},
+			bsonx.Doc{
+				{"$set", bsonx.Document(bsonx.Doc{{"a", bsonx.Array(bsonx.Arr{
+					bsonx.Int32(1), bsonx.Document(bsonx.Doc{{"b", bsonx.Null()}}),
+				})}})},
+				{"$inc", bsonx.Document(bsonx.Doc{{"n", bsonx.Int64(1)}})},
+			},
+			nil,
+		},
 		{
 			"reflection",
 			reflectStruct{Foo: "bar"},
@@ -55,8 +73,129 @@ func TestTransformDocument(t *testing.T) {
 				t.Errorf("Error does not match expected error. got %v; want %v", err, tc.err)
 			}
 
-			if diff := cmp.Diff(got, tc.want, cmp.AllowUnexported(bsonx.Elem{}, bsonx.Val{})); diff != "" {
-				t.Errorf("Returned documents differ: (-got +want)\n%s", diff)
+			if !got.Equal(tc.want) {
+				t.Errorf("Returned documents differ. got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTransformDocument_Raw(t *testing.T) {
+	raw, err := bsonx.Doc{
+		{"a", bsonx.Int64(1)},
+		{"b", bsonx.Double(2.5)},
+		{"c", bsonx.Regex("^foo", "i")},
+		{"d", bsonx.Document(bsonx.Doc{{"$gt", bsonx.Int32(3)}})},
+	}.MarshalBSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, filter := range []interface{}{bson.Raw(raw), raw} {
+		doc, err := transformDocument(nil, filter)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got, err := doc.MarshalBSON()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !bytes.Equal(got, raw) {
+			t.Errorf("Raw document was not preserved. got %v; want %v", got, raw)
+		}
+	}
+
+	_, err = transformDocument(nil, bson.Raw{0x05, 0x00})
+	if _, ok := err.(MarshalError); !ok {
+		t.Errorf("Expected a MarshalError for an invalid document. got %T", err)
+	}
+}
+
+func TestTransformFilter(t *testing.T) {
+	raw, err := bsonx.Doc{{"a", bsonx.Int64(1)}, {"b", bsonx.Regex("^foo", "i")}}.MarshalBSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, filter := range []interface{}{bson.Raw(raw), raw} {
+		doc, got, err := transformFilter(nil, filter)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if doc != nil {
+			t.Errorf("A raw filter should not be transformed. got %v", doc)
+		}
+		// the caller's bytes are used as is
+		if len(got) != len(raw) || &got[0] != &raw[0] {
+			t.Errorf("Raw filter was not returned untouched. got %v; want %v", got, raw)
+		}
+	}
+
+	doc, got, err := transformFilter(nil, bson.D{{"a", int64(1)}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != nil || !doc.Equal(bsonx.Doc{{"a", bsonx.Int64(1)}}) {
+		t.Errorf("Unexpected result for a bson.D filter. got %v and %v", doc, got)
+	}
+
+	_, _, err = transformFilter(nil, bson.Raw{0x05, 0x00})
+	if _, ok := err.(MarshalError); !ok {
+		t.Errorf("Expected a MarshalError for an invalid document. got %T", err)
+	}
+}
+
+func TestDollarKeyChecks(t *testing.T) {
+	emptyRaw, err := bsonx.Doc{}.MarshalBSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	setRaw, err := bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{{"a", bsonx.Int32(1)}})}}.MarshalBSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	type replacement struct {
+		Skipped string `bson:"-"`
+		Name    string `bson:"name"`
+	}
+
+	testCases := []struct {
+		name        string
+		val         interface{}
+		hasDollar   bool
+		noDollarErr bool
+	}{
+		{"nil", nil, false, false},
+		{"empty bsonx.Doc", bsonx.Doc{}, false, false},
+		{"bsonx.Doc", bsonx.Doc{{"$inc", bsonx.Document(bsonx.Doc{{"n", bsonx.Int32(1)}})}}, true, false},
+		{"*bsonx.Doc", &bsonx.Doc{{"a", bsonx.Int32(1)}}, false, true},
+		{"empty bson.D", bson.D{}, false, false},
+		{"bson.D", bson.D{{"a", int32(1)}, {"$set", bson.D{}}}, false, true},
+		{"empty bson.Raw", bson.Raw(emptyRaw), false, false},
+		{"bson.Raw", bson.Raw(setRaw), true, false},
+		{"[]byte", setRaw, true, false},
+		{"bson.M", bson.M{"$unset": bson.D{{"a", ""}}}, true, false},
+		{"struct", replacement{Skipped: "foo", Name: "bar"}, false, true},
+		{"bson.Marshaler", bMarsh{bsonx.Doc{{"$set", bsonx.Document(bsonx.Doc{})}}}, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := transformDocument(nil, tc.val)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// the update check only fails on a non-empty document without a leading '$' key
+			err = ensureDollarKey(doc)
+			if gotErr := err != nil; gotErr != tc.noDollarErr {
+				t.Errorf("Unexpected update check result. got %v; want error %v", err, tc.noDollarErr)
+			}
+
+			// the replacement check only fails on a document with a leading '$' key
+			err = ensureNoDollarKey(doc)
+			if gotErr := err != nil; gotErr != tc.hasDollar {
+				t.Errorf("Unexpected replacement check result. got %v; want error %v", err, tc.hasDollar)
 			}
 		})
 	}
@@ -91,3 +230,166 @@ func (b bMarsh) MarshalBSON() ([]byte, error) {
 type reflectStruct struct {
 	Foo string
 }
+
+func TestTransformDocument_PooledBuffers(t *testing.T) {
+	type smallDoc struct {
+		N    int
+		Data []byte
+	}
+
+	// Each document is checked after the others have reused the pooled buffers, so a result that
+	// aliased its marshal buffer would be overwritten.
+	var wg sync.WaitGroup
+	docs := make([]bsonx.Doc, 64)
+	for i := range docs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			doc, err := transformDocument(bson.DefaultRegistry, smallDoc{N: i, Data: bytes.Repeat([]byte{byte(i)}, 8)})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			docs[i] = doc
+		}(i)
+	}
+	wg.Wait()
+
+	for i, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		want := bsonx.Doc{{"n", bsonx.Int64(int64(i))}, {"data", bsonx.Binary(0x00, bytes.Repeat([]byte{byte(i)}, 8))}}
+		if !doc.Equal(want) {
+			t.Errorf("document %d was modified. got %v; want %v", i, doc, want)
+		}
+	}
+}
+
+func TestAppendDocument(t *testing.T) {
+	cached, err := bsonx.Doc{{"x", bsonx.Int32(1)}, {"name", bsonx.String("foo")}}.MarshalBSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := bson.Raw(cached)
+	truncated := cached[:len(cached)-1]
+	errTruncated := bson.Raw(truncated).Validate()
+	errAppend := errors.New("append failed")
+
+	testCases := []struct {
+		name     string
+		appender BSONAppenderFunc
+		want     bson.Raw
+		err      error
+	}{
+		{
+			"cached bytes",
+			func(dst []byte, _ interface{}) ([]byte, error) { return append(dst, cached...), nil },
+			want,
+			nil,
+		},
+		{
+			"truncated document",
+			func(dst []byte, _ interface{}) ([]byte, error) { return append(dst, truncated...), nil },
+			nil,
+			errTruncated,
+		},
+		{
+			"appender error",
+			func(dst []byte, _ interface{}) ([]byte, error) { return dst, errAppend },
+			nil,
+			errAppend,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := appendDocument(tc.appender, "filter")
+			if tc.err == nil {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if !bytes.Equal(got, tc.want) {
+					t.Errorf("Documents do not match. got %v; want %v", got, tc.want)
+				}
+				return
+			}
+
+			me, ok := err.(MarshalError)
+			if !ok {
+				t.Fatalf("Expected a MarshalError. got %v (%T)", err, err)
+			}
+			if me.Err == nil || me.Err.Error() != tc.err.Error() {
+				t.Errorf("Errors do not match. got %v; want %v", me.Err, tc.err)
+			}
+			if me.Value != "filter" {
+				t.Errorf("Expected the filter as the value. got %v", me.Value)
+			}
+		})
+	}
+}
+
+// BenchmarkFindFilter measures building the wire message of a find command, so that the cost of
+// serializing the filter includes encoding it into the command.
+func BenchmarkFindFilter(b *testing.B) {
+	type filter struct {
+		Status string
+		Age    bson.D
+		Tags   []string
+	}
+	f := filter{Status: "active", Age: bson.D{{"$gte", 21}}, Tags: []string{"a", "b", "c"}}
+	ns := command.Namespace{DB: "db", Collection: "coll"}
+
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			doc, err := transformDocument(bson.DefaultRegistry, f)
+			if err != nil {
+				b.Fatal(err)
+			}
+			cmd := command.Find{NS: ns, Filter: doc}
+			if _, err := cmd.Encode(description.SelectedServer{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached appender", func(b *testing.B) {
+		cached, err := bson.Marshal(f)
+		if err != nil {
+			b.Fatal(err)
+		}
+		appender := BSONAppenderFunc(func(dst []byte, _ interface{}) ([]byte, error) {
+			return append(dst, cached...), nil
+		})
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			raw, err := appendDocument(appender, f)
+			if err != nil {
+				b.Fatal(err)
+			}
+			cmd := command.Find{NS: ns, RawFilter: raw}
+			if _, err := cmd.Encode(description.SelectedServer{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTransformDocument(b *testing.B) {
+	type smallDoc struct {
+		X    int32
+		Name string
+	}
+	doc := smallDoc{X: 1, Name: "small"}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := transformDocument(bson.DefaultRegistry, doc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// maxDatabaseNameLen is the limit on the length of a database name, in bytes.
+	maxDatabaseNameLen = 64
+	// maxNamespaceLen is the limit on the length of a "<database>.<collection>" namespace, in bytes.
+	maxNamespaceLen = 255
+)
+
+// invalidDatabaseNameChars are the characters that can't appear in a database name.
+const invalidDatabaseNameChars = "/\\. \"$\x00"
+
+// validateDatabaseName returns an InvalidNamespaceError if name isn't a valid database name.
+func validateDatabaseName(name string) error {
+	if name == "" {
+		return InvalidNamespaceError{Name: name, Reason: "database name cannot be empty"}
+	}
+	if len(name) >= maxDatabaseNameLen {
+		return InvalidNamespaceError{
+			Name:   name,
+			Reason: fmt.Sprintf("database name must be fewer than %d bytes", maxDatabaseNameLen),
+		}
+	}
+	if i := strings.IndexAny(name, invalidDatabaseNameChars); i != -1 {
+		return InvalidNamespaceError{Name: name, Reason: fmt.Sprintf("database name cannot contain %q", name[i])}
+	}
+
+	return nil
+}
+
+// validateCollectionName returns an InvalidNamespaceError if name isn't a valid name for a
+// collection in the database db.
+func validateCollectionName(db, name string) error {
+	if name == "" {
+		return InvalidNamespaceError{Name: name, Reason: "collection name cannot be empty"}
+	}
+	if strings.Contains(name, "$") {
+		return InvalidNamespaceError{Name: name, Reason: `collection name cannot contain "$"`}
+	}
+	if strings.Contains(name, "\x00") {
+		return InvalidNamespaceError{Name: name, Reason: "collection name cannot contain the null character"}
+	}
+	if ns := db + "." + name; len(ns) > maxNamespaceLen {
+		return InvalidNamespaceError{
+			Name:   ns,
+			Reason: fmt.Sprintf("namespace cannot be longer than %d bytes", maxNamespaceLen),
+		}
+	}
+
+	return nil
+}
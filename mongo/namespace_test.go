@@ -0,0 +1,118 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDatabaseName(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		db     string
+		reason string
+	}{
+		{"valid", "test_db-1", ""},
+		{"empty", "", "database name cannot be empty"},
+		{"too long", strings.Repeat("a", 64), "database name must be fewer than 64 bytes"},
+		{"slash", "a/b", `database name cannot contain '/'`},
+		{"backslash", `a\b`, `database name cannot contain '\\'`},
+		{"dot", "a.b", `database name cannot contain '.'`},
+		{"space", "a b", `database name cannot contain ' '`},
+		{"quote", `a"b`, `database name cannot contain '"'`},
+		{"dollar", "a$b", `database name cannot contain '$'`},
+		{"null", "a\x00b", `database name cannot contain '\x00'`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDatabaseName(tc.db)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, InvalidNamespaceError{Name: tc.db, Reason: tc.reason}, err)
+		})
+	}
+}
+
+func TestValidateCollectionName(t *testing.T) {
+	t.Parallel()
+
+	longName := strings.Repeat("c", 255-len("db."))
+
+	testCases := []struct {
+		name string
+		coll string
+		err  error
+	}{
+		{"valid", "my.coll-1", nil},
+		{"longest", longName, nil},
+		{"empty", "", InvalidNamespaceError{Name: "", Reason: "collection name cannot be empty"}},
+		{"dollar", "a$b", InvalidNamespaceError{Name: "a$b", Reason: `collection name cannot contain "$"`}},
+		{"null", "a\x00b", InvalidNamespaceError{Name: "a\x00b", Reason: "collection name cannot contain the null character"}},
+		{
+			"too long",
+			longName + "c",
+			InvalidNamespaceError{Name: "db." + longName + "c", Reason: "namespace cannot be longer than 255 bytes"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.err, validateCollectionName("db", tc.coll))
+		})
+	}
+}
+
+func TestInvalidNamespaceReturnedByOperations(t *testing.T) {
+	t.Parallel()
+
+	// the client is never connected, so any attempt to reach a server would fail differently
+	client, err := NewClient("mongodb://localhost:27017")
+	require.NoError(t, err)
+
+	t.Run("database", func(t *testing.T) {
+		db := client.Database("bad$db")
+		want := InvalidNamespaceError{Name: "bad$db", Reason: `database name cannot contain '$'`}
+
+		_, err := db.RunCommand(context.Background(), bsonx.Doc{{"ping", bsonx.Int32(1)}})
+		require.Equal(t, want, err)
+		require.Equal(t, want, db.Drop(context.Background()))
+
+		// collections in an invalid database report the database's error
+		_, err = db.Collection("coll").InsertOne(context.Background(), bsonx.Doc{{"x", bsonx.Int32(1)}})
+		require.Equal(t, want, err)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		coll := client.Database("db").Collection("bad$coll")
+		want := InvalidNamespaceError{Name: "bad$coll", Reason: `collection name cannot contain "$"`}
+
+		_, err := coll.InsertOne(context.Background(), bsonx.Doc{{"x", bsonx.Int32(1)}})
+		require.Equal(t, want, err)
+		_, err = coll.Find(context.Background(), nil)
+		require.Equal(t, want, err)
+		require.Equal(t, want, coll.FindOne(context.Background(), nil).Decode(&bsonx.Doc{}))
+		_, err = coll.Indexes().List(context.Background())
+		require.Equal(t, want, err)
+	})
+
+	t.Run("rename target", func(t *testing.T) {
+		coll := client.Database("db").Collection("coll")
+
+		_, err := coll.Rename(context.Background(), "")
+		require.Equal(t, InvalidNamespaceError{Name: "", Reason: "collection name cannot be empty"}, err)
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
+
+// PipelineBuilder constructs an aggregation pipeline one stage at a time.
+//
+// Example usage:
+//
+//	pipeline := mongo.NewPipelineBuilder().
+//		Match(bson.D{{"status", "A"}}).
+//		Group("$cust_id", bson.D{{"total", bson.D{{"$sum", "$amount"}}}}).
+//		Sort(bson.D{{"total", -1}}).
+//		Build()
+type PipelineBuilder struct {
+	pipeline Pipeline
+}
+
+// NewPipelineBuilder creates a new instance of PipelineBuilder
+func NewPipelineBuilder() *PipelineBuilder {
+	var b PipelineBuilder
+	b.pipeline = Pipeline{}
+	return &b
+}
+
+func (pb *PipelineBuilder) stage(name string, value interface{}) *PipelineBuilder {
+	pb.pipeline = append(pb.pipeline, bson.D{{Key: name, Value: value}})
+	return pb
+}
+
+// Match appends a $match stage that filters documents using the given query
+func (pb *PipelineBuilder) Match(filter interface{}) *PipelineBuilder {
+	return pb.stage("$match", filter)
+}
+
+// Group appends a $group stage that groups documents by id. A nil id groups all documents together.
+// Each element of accumulators is an output field and the accumulator expression that computes it.
+func (pb *PipelineBuilder) Group(id interface{}, accumulators bson.D) *PipelineBuilder {
+	if id == nil {
+		id = primitive.Null{}
+	}
+	group := make(bson.D, 0, len(accumulators)+1)
+	group = append(group, bson.E{Key: "_id", Value: id})
+	group = append(group, accumulators...)
+	return pb.stage("$group", group)
+}
+
+// Project appends a $project stage with the given specification
+func (pb *PipelineBuilder) Project(projection interface{}) *PipelineBuilder {
+	return pb.stage("$project", projection)
+}
+
+// Sort appends a $sort stage with the given sort order
+func (pb *PipelineBuilder) Sort(sort interface{}) *PipelineBuilder {
+	return pb.stage("$sort", sort)
+}
+
+// Limit appends a $limit stage
+func (pb *PipelineBuilder) Limit(n int64) *PipelineBuilder {
+	return pb.stage("$limit", n)
+}
+
+// Skip appends a $skip stage
+func (pb *PipelineBuilder) Skip(n int64) *PipelineBuilder {
+	return pb.stage("$skip", n)
+}
+
+// Unwind appends an $unwind stage for the array at path. The path must be prefixed with '$'.
+func (pb *PipelineBuilder) Unwind(path string) *PipelineBuilder {
+	return pb.stage("$unwind", path)
+}
+
+// Lookup appends a $lookup stage that performs an equality match between localField and the
+// foreignField of the documents in the from collection, storing the matches in the as field.
+func (pb *PipelineBuilder) Lookup(from, localField, foreignField, as string) *PipelineBuilder {
+	return pb.stage("$lookup", bson.D{
+		{Key: "from", Value: from},
+		{Key: "localField", Value: localField},
+		{Key: "foreignField", Value: foreignField},
+		{Key: "as", Value: as},
+	})
+}
+
+// Stage appends an arbitrary stage, for stages that do not have a helper
+func (pb *PipelineBuilder) Stage(name string, value interface{}) *PipelineBuilder {
+	return pb.stage(name, value)
+}
+
+// Build returns the constructed pipeline. Later calls on the builder do not modify the returned
+// pipeline.
+func (pb *PipelineBuilder) Build() Pipeline {
+	pipeline := make(Pipeline, len(pb.pipeline))
+	copy(pipeline, pb.pipeline)
+	return pipeline
+}
@@ -0,0 +1,108 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineBuilder(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		builder *PipelineBuilder
+		want    Pipeline
+	}{
+		{"empty", NewPipelineBuilder(), Pipeline{}},
+		{
+			"match",
+			NewPipelineBuilder().Match(bson.D{{"status", "A"}}),
+			Pipeline{{{"$match", bson.D{{"status", "A"}}}}},
+		},
+		{
+			"group",
+			NewPipelineBuilder().Group("$cust_id", bson.D{
+				{"total", bson.D{{"$sum", "$amount"}}},
+				{"count", bson.D{{"$sum", int32(1)}}},
+			}),
+			Pipeline{{{"$group", bson.D{
+				{"_id", "$cust_id"},
+				{"total", bson.D{{"$sum", "$amount"}}},
+				{"count", bson.D{{"$sum", int32(1)}}},
+			}}}},
+		},
+		{
+			"group without accumulators",
+			NewPipelineBuilder().Group(nil, nil),
+			Pipeline{{{"$group", bson.D{{"_id", primitive.Null{}}}}}},
+		},
+		{
+			"lookup",
+			NewPipelineBuilder().Lookup("inventory", "item", "sku", "inventory_docs"),
+			Pipeline{{{"$lookup", bson.D{
+				{"from", "inventory"},
+				{"localField", "item"},
+				{"foreignField", "sku"},
+				{"as", "inventory_docs"},
+			}}}},
+		},
+		{
+			"all stages",
+			NewPipelineBuilder().
+				Match(bson.D{{"status", "A"}}).
+				Unwind("$items").
+				Project(bson.D{{"items", 1}, {"_id", 0}}).
+				Sort(bson.D{{"items.price", -1}}).
+				Skip(5).
+				Limit(10).
+				Stage("$count", "total"),
+			Pipeline{
+				{{"$match", bson.D{{"status", "A"}}}},
+				{{"$unwind", "$items"}},
+				{{"$project", bson.D{{"items", 1}, {"_id", 0}}}},
+				{{"$sort", bson.D{{"items.price", -1}}}},
+				{{"$skip", int64(5)}},
+				{{"$limit", int64(10)}},
+				{{"$count", "total"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.builder.Build()
+			require.Equal(t, len(tc.want), len(got))
+			for i := range tc.want {
+				wantBytes, err := bson.Marshal(tc.want[i])
+				require.NoError(t, err)
+				gotBytes, err := bson.Marshal(got[i])
+				require.NoError(t, err)
+				require.True(t, bytes.Equal(wantBytes, gotBytes), "stage %d does not match", i)
+			}
+
+			want, err := transformAggregatePipeline(nil, tc.want)
+			require.NoError(t, err)
+			arr, err := transformAggregatePipeline(nil, got)
+			require.NoError(t, err)
+			require.True(t, arr.Equal(want))
+		})
+	}
+
+	t.Run("Build copies the pipeline", func(t *testing.T) {
+		pb := NewPipelineBuilder().Match(bson.D{{"a", int32(1)}})
+		first := pb.Build()
+		pb.Limit(1)
+		require.Len(t, first, 1)
+		require.Len(t, pb.Build(), 2)
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// ProjectionBuilder constructs a projection document for the Projection option of find operations.
+//
+// Example usage:
+//
+//	projection, err := mongo.NewProjectionBuilder().
+//		Include("name", "address").
+//		Exclude("_id").
+//		Slice("comments", 5).
+//		Build()
+type ProjectionBuilder struct {
+	projection bson.D
+}
+
+// NewProjectionBuilder creates a new instance of ProjectionBuilder
+func NewProjectionBuilder() *ProjectionBuilder {
+	var b ProjectionBuilder
+	b.projection = bson.D{}
+	return &b
+}
+
+// field sets the projection of the given field, replacing any earlier projection of the same field.
+func (pb *ProjectionBuilder) field(name string, value interface{}) *ProjectionBuilder {
+	for i, elem := range pb.projection {
+		if elem.Key == name {
+			pb.projection[i].Value = value
+			return pb
+		}
+	}
+	pb.projection = append(pb.projection, bson.E{Key: name, Value: value})
+	return pb
+}
+
+// Include includes the given fields in the returned documents
+func (pb *ProjectionBuilder) Include(fields ...string) *ProjectionBuilder {
+	for _, f := range fields {
+		pb.field(f, int32(1))
+	}
+	return pb
+}
+
+// Exclude excludes the given fields from the returned documents
+func (pb *ProjectionBuilder) Exclude(fields ...string) *ProjectionBuilder {
+	for _, f := range fields {
+		pb.field(f, int32(0))
+	}
+	return pb
+}
+
+// Slice limits the array field to its first n elements, or to its last -n elements if n is negative
+func (pb *ProjectionBuilder) Slice(field string, n int) *ProjectionBuilder {
+	return pb.field(field, bson.D{{Key: "$slice", Value: int32(n)}})
+}
+
+// ElemMatch limits the array field to the first element that matches filter
+func (pb *ProjectionBuilder) ElemMatch(field string, filter interface{}) *ProjectionBuilder {
+	return pb.field(field, bson.D{{Key: "$elemMatch", Value: filter}})
+}
+
+// Meta sets field to the metadata of the given type, such as "textScore"
+func (pb *ProjectionBuilder) Meta(field, metaType string) *ProjectionBuilder {
+	return pb.field(field, bson.D{{Key: "$meta", Value: metaType}})
+}
+
+// Build returns the constructed projection. It returns ErrMixedProjection if fields other than _id
+// are both included and excluded. Later calls on the builder do not modify the returned projection.
+func (pb *ProjectionBuilder) Build() (bson.D, error) {
+	var included, excluded bool
+	for _, elem := range pb.projection {
+		if elem.Key == "_id" {
+			continue
+		}
+		switch elem.Value {
+		case int32(1):
+			included = true
+		case int32(0):
+			excluded = true
+		}
+	}
+	if included && excluded {
+		return nil, ErrMixedProjection
+	}
+
+	projection := make(bson.D, len(pb.projection))
+	copy(projection, pb.projection)
+	return projection, nil
+}
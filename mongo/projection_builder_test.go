@@ -0,0 +1,104 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectionBuilder(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		builder *ProjectionBuilder
+		want    bson.D
+		err     error
+	}{
+		{"empty", NewProjectionBuilder(), bson.D{}, nil},
+		{
+			"include",
+			NewProjectionBuilder().Include("name", "address"),
+			bson.D{{"name", int32(1)}, {"address", int32(1)}},
+			nil,
+		},
+		{
+			"exclude",
+			NewProjectionBuilder().Exclude("history", "comments"),
+			bson.D{{"history", int32(0)}, {"comments", int32(0)}},
+			nil,
+		},
+		{
+			"include with _id excluded",
+			NewProjectionBuilder().Include("name").Exclude("_id"),
+			bson.D{{"name", int32(1)}, {"_id", int32(0)}},
+			nil,
+		},
+		{
+			"exclude with _id included",
+			NewProjectionBuilder().Exclude("history").Include("_id"),
+			bson.D{{"history", int32(0)}, {"_id", int32(1)}},
+			nil,
+		},
+		{
+			"slice",
+			NewProjectionBuilder().Slice("comments", -5),
+			bson.D{{"comments", bson.D{{"$slice", int32(-5)}}}},
+			nil,
+		},
+		{
+			"elemMatch",
+			NewProjectionBuilder().Include("name").ElemMatch("students", bson.D{{"school", 102}}),
+			bson.D{{"name", int32(1)}, {"students", bson.D{{"$elemMatch", bson.D{{"school", 102}}}}}},
+			nil,
+		},
+		{
+			"meta",
+			NewProjectionBuilder().Meta("score", "textScore"),
+			bson.D{{"score", bson.D{{"$meta", "textScore"}}}},
+			nil,
+		},
+		{
+			"operators with exclusion",
+			NewProjectionBuilder().Exclude("history").Slice("comments", 3),
+			bson.D{{"history", int32(0)}, {"comments", bson.D{{"$slice", int32(3)}}}},
+			nil,
+		},
+		{
+			"repeated field",
+			NewProjectionBuilder().Include("name").Slice("name", 2),
+			bson.D{{"name", bson.D{{"$slice", int32(2)}}}},
+			nil,
+		},
+		{
+			"mixed",
+			NewProjectionBuilder().Include("name").Exclude("history"),
+			nil,
+			ErrMixedProjection,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.Build()
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("build copies", func(t *testing.T) {
+		pb := NewProjectionBuilder().Include("name")
+		projection, err := pb.Build()
+		require.NoError(t, err)
+
+		pb.Include("address")
+		require.Equal(t, bson.D{{"name", int32(1)}}, projection)
+	})
+}
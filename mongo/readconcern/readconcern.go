@@ -7,12 +7,20 @@
 package readconcern
 
 import (
+	"errors"
+
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/x/bsonx"
 )
 
+// ErrAtClusterTimeWithoutSnapshot indicates that an atClusterTime was specified for a read concern whose level is
+// not snapshot.
+var ErrAtClusterTimeWithoutSnapshot = errors.New("atClusterTime can only be used with read concern level snapshot")
+
 // ReadConcern for replica sets and replica set shards determines which data to return from a query.
 type ReadConcern struct {
-	level string
+	level         string
+	atClusterTime *primitive.Timestamp
 }
 
 // Option is an option to provide when creating a ReadConcern.
@@ -25,6 +33,14 @@ func Level(level string) Option {
 	}
 }
 
+// AtClusterTime creates an option that reads data as of the given cluster time. It can only be used with
+// a snapshot read concern.
+func AtClusterTime(ts primitive.Timestamp) Option {
+	return func(concern *ReadConcern) {
+		concern.atClusterTime = &ts
+	}
+}
+
 // Local specifies that the query should return the instance’s most recent data.
 func Local() *ReadConcern {
 	return New(Level("local"))
@@ -64,6 +80,22 @@ func New(options ...Option) *ReadConcern {
 	return concern
 }
 
+// GetLevel returns the read concern level. An empty string is returned if no level was set or rc is nil.
+func (rc *ReadConcern) GetLevel() string {
+	if rc == nil {
+		return ""
+	}
+	return rc.level
+}
+
+// GetAtClusterTime returns the cluster time the read concern reads at, or nil if none was set.
+func (rc *ReadConcern) GetAtClusterTime() *primitive.Timestamp {
+	if rc == nil {
+		return nil
+	}
+	return rc.atClusterTime
+}
+
 // MarshalBSONElement implements the bsonx.ElementMarshaler interface.
 func (rc *ReadConcern) MarshalBSONElement() (bsonx.Elem, error) {
 	doc := bsonx.Doc{}
@@ -72,5 +104,12 @@ func (rc *ReadConcern) MarshalBSONElement() (bsonx.Elem, error) {
 		doc = doc.Append("level", bsonx.String(rc.level))
 	}
 
+	if rc.atClusterTime != nil {
+		if rc.level != "snapshot" {
+			return bsonx.Elem{}, ErrAtClusterTimeWithoutSnapshot
+		}
+		doc = doc.Append("atClusterTime", bsonx.Timestamp(rc.atClusterTime.T, rc.atClusterTime.I))
+	}
+
 	return bsonx.Elem{"readConcern", bsonx.Document(doc)}, nil
 }
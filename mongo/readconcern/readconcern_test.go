@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package readconcern_test
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	. "github.com/mongodb/mongo-go-driver/mongo/readconcern"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConcernLevels(t *testing.T) {
+	testCases := []struct {
+		rc    *ReadConcern
+		level string
+	}{
+		{Local(), "local"},
+		{Majority(), "majority"},
+		{Linearizable(), "linearizable"},
+		{Available(), "available"},
+		{Snapshot(), "snapshot"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.level, func(t *testing.T) {
+			require.Equal(t, tc.level, tc.rc.GetLevel())
+
+			elem, err := tc.rc.MarshalBSONElement()
+			require.NoError(t, err)
+			require.Equal(t, "readConcern", elem.Key)
+			require.True(t, bsonx.Doc{{"level", bsonx.String(tc.level)}}.Equal(elem.Value.Document()))
+		})
+	}
+}
+
+func TestReadConcern_NoLevel(t *testing.T) {
+	require.Equal(t, "", New().GetLevel())
+	require.Equal(t, "", (*ReadConcern)(nil).GetLevel())
+
+	elem, err := New().MarshalBSONElement()
+	require.NoError(t, err)
+	require.Len(t, elem.Value.Document(), 0)
+}
+
+func TestAtClusterTime(t *testing.T) {
+	ts := primitive.Timestamp{T: 12, I: 4}
+
+	t.Run("snapshot", func(t *testing.T) {
+		rc := New(Level("snapshot"), AtClusterTime(ts))
+		require.Equal(t, &ts, rc.GetAtClusterTime())
+
+		elem, err := rc.MarshalBSONElement()
+		require.NoError(t, err)
+		expected := bsonx.Doc{{"level", bsonx.String("snapshot")}, {"atClusterTime", bsonx.Timestamp(12, 4)}}
+		require.True(t, expected.Equal(elem.Value.Document()))
+	})
+	t.Run("other levels", func(t *testing.T) {
+		for _, level := range []string{"", "local", "majority", "linearizable", "available"} {
+			_, err := New(Level(level), AtClusterTime(ts)).MarshalBSONElement()
+			require.Equal(t, ErrAtClusterTimeWithoutSnapshot, err, "level %q", level)
+		}
+	})
+	t.Run("not set", func(t *testing.T) {
+		require.Nil(t, Snapshot().GetAtClusterTime())
+		require.Nil(t, (*ReadConcern)(nil).GetAtClusterTime())
+	})
+}
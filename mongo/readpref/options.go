@@ -16,6 +16,9 @@ import (
 // ErrInvalidTagSet indicates that an invalid set of tags was specified.
 var ErrInvalidTagSet = errors.New("an even number of tags must be specified")
 
+// ErrInvalidParallelHedge indicates that a parallel hedge of less than one server was specified.
+var ErrInvalidParallelHedge = errors.New("a parallel hedge must use at least one server")
+
 // Option configures a read preference
 type Option func(*ReadPref) error
 
@@ -58,3 +61,17 @@ func WithTagSets(tagSets ...tag.Set) Option {
 		return nil
 	}
 }
+
+// WithParallelHedge races reads against the n lowest-latency eligible
+// servers and uses the first successful reply, cancelling the others. It only
+// applies to the nearest mode and to reads that can safely be retried, and a
+// value of 1 disables hedging.
+func WithParallelHedge(n int) Option {
+	return func(rp *ReadPref) error {
+		if n < 1 {
+			return ErrInvalidParallelHedge
+		}
+		rp.parallelHedge = n
+		return nil
+	}
+}
@@ -78,6 +78,7 @@ type ReadPref struct {
 	maxStalenessSet bool
 	mode            Mode
 	tagSets         []tag.Set
+	parallelHedge   int
 }
 
 // MaxStaleness is the maximum amount of time to allow
@@ -97,3 +98,9 @@ func (r *ReadPref) Mode() Mode {
 func (r *ReadPref) TagSets() []tag.Set {
 	return r.tagSets
 }
+
+// ParallelHedge is the number of servers a read is raced
+// against. Values less than 2 mean the read is not hedged.
+func (r *ReadPref) ParallelHedge() int {
+	return r.parallelHedge
+}
@@ -120,3 +120,15 @@ func TestNearest_with_options(t *testing.T) {
 	require.Equal(time.Duration(10), ms)
 	require.Equal([]tag.Set{{tag.Tag{Name: "a", Value: "1"}, tag.Tag{Name: "b", Value: "2"}}}, subject.TagSets())
 }
+
+func TestNearest_with_parallel_hedge(t *testing.T) {
+	require := require.New(t)
+	subject := Nearest(WithParallelHedge(2))
+
+	require.Equal(NearestMode, subject.Mode())
+	require.Equal(2, subject.ParallelHedge())
+	require.Equal(0, Nearest().ParallelHedge())
+
+	_, err := New(NearestMode, WithParallelHedge(0))
+	require.Equal(ErrInvalidParallelHedge, err)
+}
@@ -33,7 +33,8 @@ type InsertOneResult struct {
 
 // InsertManyResult is a result of an InsertMany operation.
 type InsertManyResult struct {
-	// Maps the indexes of inserted documents to their _id fields.
+	// Maps the indexes of inserted documents to their _id fields. The entries of documents
+	// that were not inserted are nil.
 	InsertedIDs []interface{}
 }
 
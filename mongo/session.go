@@ -9,6 +9,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/core/command"
@@ -24,6 +25,15 @@ import (
 // the method call is using.
 var ErrWrongClient = errors.New("session was not created by this client")
 
+// withTransactionTimeout is the amount of time WithTransaction will keep retrying a transaction.
+var withTransactionTimeout = 120 * time.Second
+
+// commitRetryBackoff is the delay before WithTransaction first retries a commit whose result is unknown.
+// The delay doubles with each retry, up to maxCommitRetryBackoff.
+var commitRetryBackoff = 10 * time.Millisecond
+
+const maxCommitRetryBackoff = time.Second
+
 // SessionContext is a hybrid interface. It combines a context.Context with
 // a mongo.Session. This type can be used as a regular context.Context or
 // Session type.
@@ -48,6 +58,8 @@ type Session interface {
 	StartTransaction(...*options.TransactionOptions) error
 	AbortTransaction(context.Context) error
 	CommitTransaction(context.Context) error
+	WithTransaction(ctx context.Context, fn func(sessCtx SessionContext) (interface{}, error),
+		opts ...*options.TransactionOptions) (interface{}, error)
 	ClusterTime() bsonx.Doc
 	AdvanceClusterTime(bsonx.Doc) error
 	OperationTime() *primitive.Timestamp
@@ -102,7 +114,7 @@ func (s *sessionImpl) AbortTransaction(ctx context.Context) error {
 	}
 
 	s.Aborting = true
-	_, err = dispatch.AbortTransaction(ctx, cmd, s.topo, description.WriteSelector())
+	_, err = dispatch.AbortTransaction(ctx, cmd, s.topo, makePinnedSelector(s.Client, description.WriteSelector()))
 
 	_ = s.Client.AbortTransaction()
 	return err
@@ -136,13 +148,107 @@ func (s *sessionImpl) CommitTransaction(ctx context.Context) error {
 			s.Committing = false
 		}()
 	}
-	_, err = dispatch.CommitTransaction(ctx, cmd, s.topo, description.WriteSelector())
+	_, err = dispatch.CommitTransaction(ctx, cmd, s.topo, makePinnedSelector(s.Client, description.WriteSelector()))
 	if err == nil {
 		return s.Client.CommitTransaction()
 	}
 	return err
 }
 
+// WithTransaction starts a transaction, runs fn within it, and commits the transaction if fn succeeds. If fn
+// returns an error, the transaction is aborted. The whole transaction is retried if fn or the commit fails with
+// a TransientTransactionError label, and the commit alone is retried if it fails with an
+// UnknownTransactionCommitResult label, until 120 seconds have elapsed. fn may be called more than once and
+// must run all of its operations with the SessionContext it is given.
+func (s *sessionImpl) WithTransaction(ctx context.Context, fn func(sessCtx SessionContext) (interface{}, error),
+	opts ...*options.TransactionOptions) (interface{}, error) {
+	start := time.Now()
+	timedOut := func() bool {
+		return time.Since(start) >= withTransactionTimeout
+	}
+
+	for {
+		err := s.StartTransaction(opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := fn(contextWithSession(ctx, s))
+		if err != nil {
+			if s.TransactionRunning() {
+				_ = s.AbortTransaction(ctx)
+			}
+
+			if hasErrorLabel(err, command.TransientTransactionError) && !timedOut() && ctx.Err() == nil {
+				continue
+			}
+			return res, err
+		}
+
+		// fn may have committed or aborted the transaction itself
+		if !s.TransactionRunning() {
+			return res, nil
+		}
+
+		backoff := commitRetryBackoff
+	commitLoop:
+		for {
+			err = s.CommitTransaction(ctx)
+			if err == nil {
+				return res, nil
+			}
+			if timedOut() {
+				return res, err
+			}
+
+			switch {
+			case hasErrorLabel(err, command.UnknownTransactionCommitResult):
+				// The commit error is more useful to the caller than the context error.
+				if waitToRetry(ctx, backoff) != nil {
+					return res, err
+				}
+				backoff *= 2
+				if backoff > maxCommitRetryBackoff {
+					backoff = maxCommitRetryBackoff
+				}
+				continue
+			case hasErrorLabel(err, command.TransientTransactionError):
+				// the server has already aborted the transaction, so only the local state needs resetting
+				_ = s.Client.AbortTransaction()
+				if ctx.Err() != nil {
+					return res, err
+				}
+				break commitLoop
+			default:
+				return res, err
+			}
+		}
+	}
+}
+
+// waitToRetry waits for d to elapse before an operation is retried. It returns the context's error if ctx is
+// done first, in which case the operation must not be retried.
+func waitToRetry(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func hasErrorLabel(err error, label string) bool {
+	cerr, ok := err.(command.Error)
+	return ok && cerr.HasErrorLabel(label)
+}
+
 func (s *sessionImpl) ClusterTime() bsonx.Doc {
 	return s.Client.ClusterTime
 }
@@ -173,6 +279,29 @@ func sessionFromContext(ctx context.Context) *session.Client {
 	return nil
 }
 
+// makePinnedSelector returns a selector that only selects the server a session's sharded transaction is
+// pinned to, falling back to defaultSelector if the session is not pinned or the pinned server is no longer
+// available. The pin is read once, when the selector is made, and the selector never modifies the session;
+// the session is pinned to the replacement server when the operation's command runs on it.
+func makePinnedSelector(sess *session.Client, defaultSelector description.ServerSelector) description.ServerSelectorFunc {
+	var pinned *description.Server
+	if sess != nil {
+		pinned = sess.PinnedServer
+	}
+
+	return func(t description.Topology, svrs []description.Server) ([]description.Server, error) {
+		if pinned != nil {
+			for _, s := range svrs {
+				if s.Addr == pinned.Addr {
+					return []description.Server{s}, nil
+				}
+			}
+		}
+
+		return defaultSelector.SelectServer(t, svrs)
+	}
+}
+
 func contextWithSession(ctx context.Context, sess Session) SessionContext {
 	return &sessionContext{
 		Context: context.WithValue(ctx, sessionKey{}, sess),
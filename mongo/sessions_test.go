@@ -8,16 +8,19 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
 	"fmt"
+	"math/rand"
 	"os"
 	"time"
 
 	"bytes"
 	"strings"
 
+	"github.com/mongodb/mongo-go-driver/core/address"
 	"github.com/mongodb/mongo-go-driver/core/command"
 	"github.com/mongodb/mongo-go-driver/core/connection"
 	"github.com/mongodb/mongo-go-driver/core/connstring"
@@ -25,6 +28,7 @@ import (
 	"github.com/mongodb/mongo-go-driver/core/event"
 	"github.com/mongodb/mongo-go-driver/core/session"
 	"github.com/mongodb/mongo-go-driver/core/topology"
+	"github.com/mongodb/mongo-go-driver/core/uuid"
 	"github.com/mongodb/mongo-go-driver/internal/testutil"
 	"github.com/mongodb/mongo-go-driver/internal/testutil/helpers"
 	"github.com/mongodb/mongo-go-driver/mongo/readconcern"
@@ -327,6 +331,71 @@ func testCheckedOut(t *testing.T, client *Client, expected int) {
 	}
 }
 
+func TestMakePinnedSelector(t *testing.T) {
+	mongoses := []description.Server{
+		{Addr: address.Address("a:27017"), Kind: description.Mongos},
+		{Addr: address.Address("b:27017"), Kind: description.Mongos},
+		{Addr: address.Address("c:27017"), Kind: description.Mongos},
+	}
+	topo := description.Topology{Kind: description.Sharded, Servers: mongoses}
+
+	id, err := uuid.New()
+	require.NoError(t, err)
+	sess, err := session.NewClientSession(session.NewPool(nil), id, session.Explicit)
+	require.NoError(t, err)
+	// each operation makes its own selector, which reads the pin when it is made
+	selectServer := func(svrs []description.Server) ([]description.Server, error) {
+		return makePinnedSelector(sess, description.WriteSelector()).SelectServer(topo, svrs)
+	}
+
+	t.Run("all transaction statements target one mongos", func(t *testing.T) {
+		require.NoError(t, sess.StartTransaction(nil))
+
+		var addrs []address.Address
+		for i := 0; i < 10; i++ {
+			selected, err := selectServer(topo.Servers)
+			require.NoError(t, err)
+			// the topology picks randomly among the selected servers
+			chosen := selected[rand.Intn(len(selected))]
+			sess.ApplyCommand(chosen)
+			addrs = append(addrs, chosen.Addr)
+		}
+		for _, addr := range addrs {
+			require.Equal(t, addrs[0], addr)
+		}
+
+		require.NoError(t, sess.CommitTransaction())
+		selected, err := selectServer(topo.Servers)
+		require.NoError(t, err)
+		require.Len(t, selected, len(mongoses))
+	})
+	t.Run("unavailable pinned mongos is replaced", func(t *testing.T) {
+		require.NoError(t, sess.StartTransaction(nil))
+		sess.ApplyCommand(mongoses[0])
+		require.Equal(t, mongoses[0].Addr, sess.PinnedServer.Addr)
+
+		selected, err := selectServer(mongoses[1:])
+		require.NoError(t, err)
+		require.Len(t, selected, 2)
+		// the selector does not modify the session
+		require.Equal(t, mongoses[0].Addr, sess.PinnedServer.Addr)
+
+		sess.ApplyCommand(selected[0])
+		require.Equal(t, selected[0].Addr, sess.PinnedServer.Addr)
+		require.NoError(t, sess.AbortTransaction())
+	})
+}
+
+func TestWaitToRetry(t *testing.T) {
+	require.NoError(t, waitToRetry(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	require.Equal(t, context.Canceled, waitToRetry(ctx, time.Minute))
+	require.True(t, time.Since(start) < time.Second)
+}
+
 func TestSessions(t *testing.T) {
 	t.Run("TestPoolLifo", func(t *testing.T) {
 		skipIfBelow36(t) // otherwise no session timeout is given and sessions auto expire
@@ -545,6 +614,48 @@ func TestSessions(t *testing.T) {
 		}
 	})
 
+	t.Run("TestUseSession", func(t *testing.T) {
+		// test to make sure commands run with the SessionContext use the session and that the session is ended afterwards
+
+		skipInvalidTopology(t)
+		skipIfBelow36(t)
+
+		client := createSessionsMonitoredClient(t, sessionsMonitor)
+		defer verifySessionsReturned(t, client)
+
+		db := client.Database("UseSessionDB")
+		err := db.Drop(ctx)
+		testhelpers.RequireNil(t, err, "error dropping database: %s", err)
+		coll := db.Collection("UseSessionColl")
+
+		var sessID bsonx.Doc
+		err = client.UseSession(ctx, func(sctx SessionContext) error {
+			sessID = sessionFromContext(sctx).SessionID
+
+			_, err := coll.InsertOne(sctx, bsonx.Doc{{"x", bsonx.Int32(1)}})
+			return err
+		})
+		testhelpers.RequireNil(t, err, "error running UseSession: %s", err)
+
+		testhelpers.RequireNotNil(t, sessionStarted, "started command was nil")
+		if sessionStarted.CommandName != "insert" {
+			t.Fatalf("expected insert command; got %s", sessionStarted.CommandName)
+		}
+		lsid, err := sessionStarted.Command.LookupErr("lsid")
+		testhelpers.RequireNil(t, err, "key lsid not found in command: %s", err)
+		if !sessionIDsEqual(t, sessID, lsid.Document()) {
+			t.Errorf("lsid mismatch; got %v; want %v", lsid.Document(), sessID)
+		}
+
+		stop := errors.New("stop")
+		err = client.UseSessionWithOptions(ctx, options.Session().SetCausalConsistency(true), func(SessionContext) error {
+			return stop
+		})
+		if err != stop {
+			t.Errorf("expected the callback error to be returned; got %v", err)
+		}
+	})
+
 	t.Run("TestImplicitSessionReturned", func(t *testing.T) {
 		// test to make sure implicit sessions are returned to the server session pool
 
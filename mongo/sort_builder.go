@@ -0,0 +1,67 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"github.com/mongodb/mongo-go-driver/bson"
+)
+
+// SortBuilder constructs a sort document for the Sort option of find operations and $sort stages.
+// Keys are kept in the order they are added, unlike sort documents built from maps.
+//
+// Example usage:
+//
+//	sort := mongo.NewSortBuilder().
+//		Asc("lastName", "firstName").
+//		Desc("age").
+//		Build()
+type SortBuilder struct {
+	sort bson.D
+}
+
+// NewSortBuilder creates a new instance of SortBuilder
+func NewSortBuilder() *SortBuilder {
+	var b SortBuilder
+	b.sort = bson.D{}
+	return &b
+}
+
+// key sets the sort order of the given field. A field that was already added keeps its position.
+func (sb *SortBuilder) key(name string, order int32) *SortBuilder {
+	for i, elem := range sb.sort {
+		if elem.Key == name {
+			sb.sort[i].Value = order
+			return sb
+		}
+	}
+	sb.sort = append(sb.sort, bson.E{Key: name, Value: order})
+	return sb
+}
+
+// Asc sorts by the given fields in ascending order
+func (sb *SortBuilder) Asc(fields ...string) *SortBuilder {
+	for _, f := range fields {
+		sb.key(f, 1)
+	}
+	return sb
+}
+
+// Desc sorts by the given fields in descending order
+func (sb *SortBuilder) Desc(fields ...string) *SortBuilder {
+	for _, f := range fields {
+		sb.key(f, -1)
+	}
+	return sb
+}
+
+// Build returns the constructed sort document. Later calls on the builder do not modify the
+// returned document.
+func (sb *SortBuilder) Build() bson.D {
+	sort := make(bson.D, len(sb.sort))
+	copy(sort, sb.sort)
+	return sort
+}
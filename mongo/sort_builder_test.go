@@ -0,0 +1,67 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortBuilder(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		builder *SortBuilder
+		want    bson.D
+	}{
+		{"empty", NewSortBuilder(), bson.D{}},
+		{"asc", NewSortBuilder().Asc("a"), bson.D{{"a", int32(1)}}},
+		{"desc", NewSortBuilder().Desc("a"), bson.D{{"a", int32(-1)}}},
+		{"asc then desc", NewSortBuilder().Asc("a").Desc("b"), bson.D{{"a", int32(1)}, {"b", int32(-1)}}},
+		{"desc then asc", NewSortBuilder().Desc("b").Asc("a"), bson.D{{"b", int32(-1)}, {"a", int32(1)}}},
+		{
+			"multiple fields per call",
+			NewSortBuilder().Asc("z", "y").Desc("x", "w").Asc("v"),
+			bson.D{{"z", int32(1)}, {"y", int32(1)}, {"x", int32(-1)}, {"w", int32(-1)}, {"v", int32(1)}},
+		},
+		{
+			"repeated field keeps its position",
+			NewSortBuilder().Asc("a", "b").Desc("a"),
+			bson.D{{"a", int32(-1)}, {"b", int32(1)}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.builder.Build())
+		})
+	}
+
+	t.Run("marshaled order", func(t *testing.T) {
+		b, err := bson.Marshal(NewSortBuilder().Desc("c").Asc("a").Desc("b").Build())
+		require.NoError(t, err)
+
+		elems, err := bson.Raw(b).Elements()
+		require.NoError(t, err)
+		keys := make([]string, 0, len(elems))
+		for _, elem := range elems {
+			keys = append(keys, elem.Key())
+		}
+		require.Equal(t, []string{"c", "a", "b"}, keys)
+	})
+
+	t.Run("build copies", func(t *testing.T) {
+		sb := NewSortBuilder().Asc("a")
+		sort := sb.Build()
+
+		sb.Desc("a", "b")
+		require.Equal(t, bson.D{{"a", int32(1)}}, sort)
+	})
+}
@@ -8,6 +8,7 @@ package mongo
 
 import (
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"testing"
 
@@ -717,3 +718,82 @@ func shouldSkipTransactionsTest(t *testing.T, serverVersion string) bool {
 	return compareVersions(t, serverVersion, "4.0") < 0 ||
 		os.Getenv("TOPOLOGY") != "replica_set"
 }
+
+func TestWithTransaction(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbName := "admin"
+	dbAdmin := createTestDatabase(t, &dbName)
+	version, err := getServerVersion(dbAdmin)
+	require.NoError(t, err)
+	if shouldSkipTransactionsTest(t, version) {
+		t.Skip()
+	}
+
+	client := createTestClient(t)
+	coll := client.Database("WithTransactionDB").Collection("WithTransactionColl")
+	require.NoError(t, coll.Drop(ctx))
+	// collections cannot be created inside a transaction on 4.0
+	_, err = coll.InsertOne(ctx, bsonx.Doc{{"_id", bsonx.Int32(0)}})
+	require.NoError(t, err)
+
+	insertTwo := func(first, second int32) func(SessionContext) (interface{}, error) {
+		return func(sessCtx SessionContext) (interface{}, error) {
+			if _, err := coll.InsertOne(sessCtx, bsonx.Doc{{"_id", bsonx.Int32(first)}}); err != nil {
+				return nil, err
+			}
+			return coll.InsertOne(sessCtx, bsonx.Doc{{"_id", bsonx.Int32(second)}})
+		}
+	}
+	countIDs := func(ids ...int32) int64 {
+		arr := bsonx.Arr{}
+		for _, id := range ids {
+			arr = append(arr, bsonx.Int32(id))
+		}
+		count, err := coll.CountDocuments(ctx, bsonx.Doc{{"_id", bsonx.Document(bsonx.Doc{{"$in", bsonx.Array(arr)}})}})
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		sess, err := client.StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(ctx)
+
+		res, err := sess.WithTransaction(ctx, insertTwo(1, 2))
+		require.NoError(t, err)
+		require.IsType(t, &InsertOneResult{}, res)
+		require.Equal(t, int64(2), countIDs(1, 2))
+	})
+	t.Run("callback error aborts", func(t *testing.T) {
+		sess, err := client.StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(ctx)
+
+		cbErr := errors.New("callback error")
+		_, err = sess.WithTransaction(ctx, func(sessCtx SessionContext) (interface{}, error) {
+			if _, err := insertTwo(3, 4)(sessCtx); err != nil {
+				return nil, err
+			}
+			return nil, cbErr
+		})
+		require.Equal(t, cbErr, err)
+		require.Equal(t, int64(0), countIDs(3, 4))
+	})
+	t.Run("abort rolls back", func(t *testing.T) {
+		sess, err := client.StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(ctx)
+
+		require.NoError(t, sess.StartTransaction())
+		err = WithSession(ctx, sess, func(sessCtx SessionContext) error {
+			_, err := insertTwo(5, 6)(sessCtx)
+			return err
+		})
+		require.NoError(t, err)
+		require.NoError(t, sess.AbortTransaction(ctx))
+		require.Equal(t, int64(0), countIDs(5, 6))
+	})
+}
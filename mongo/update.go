@@ -0,0 +1,167 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"strings"
+
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+)
+
+// UpdateOperator is a single update operator, such as $set or $inc, together with the fields it
+// applies to. Fields can be any value that can be transformed into a document.
+type UpdateOperator struct {
+	Operator string
+	Fields   interface{}
+}
+
+// Set creates a $set update operator for the given fields.
+func Set(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$set", Fields: fields}
+}
+
+// SetOnInsert creates a $setOnInsert update operator for the given fields. The fields are only
+// set when an upsert inserts a new document.
+func SetOnInsert(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$setOnInsert", Fields: fields}
+}
+
+// Inc creates an $inc update operator for the given fields.
+func Inc(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$inc", Fields: fields}
+}
+
+// Unset creates an $unset update operator that removes the given fields.
+func Unset(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$unset", Fields: fields}
+}
+
+// Mul creates a $mul update operator that multiplies the given fields.
+func Mul(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$mul", Fields: fields}
+}
+
+// Push creates a $push update operator that appends values to the given array fields.
+func Push(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$push", Fields: fields}
+}
+
+// Pull creates a $pull update operator that removes matching values from the given array fields.
+func Pull(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$pull", Fields: fields}
+}
+
+// AddToSet creates an $addToSet update operator that adds values to the given array fields unless
+// they're already present.
+func AddToSet(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$addToSet", Fields: fields}
+}
+
+// Pop creates a $pop update operator that removes the first (-1) or last (1) element of the given
+// array fields.
+func Pop(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$pop", Fields: fields}
+}
+
+// Rename creates a $rename update operator. The fields map each current field name to its new
+// name.
+func Rename(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$rename", Fields: fields}
+}
+
+// CurrentDate creates a $currentDate update operator that sets the given fields to the current
+// date.
+func CurrentDate(fields interface{}) UpdateOperator {
+	return UpdateOperator{Operator: "$currentDate", Fields: fields}
+}
+
+// UpdateDocument is an update document built from a list of update operators. It can be passed
+// anywhere an update document is accepted.
+type UpdateDocument []UpdateOperator
+
+// Update combines the given update operators into a single update document. Operators of the
+// same kind are merged into one key, with later fields replacing earlier fields of the same name.
+func Update(ops ...UpdateOperator) UpdateDocument {
+	return UpdateDocument(ops)
+}
+
+// Document returns the combined update document. It returns an UpdateConflictError if the same
+// field is targeted by different operators, or if a field and one of its subfields are both targeted.
+func (ud UpdateDocument) Document() (bsonx.Doc, error) {
+	doc := bsonx.Doc{}
+
+	for _, op := range ud {
+		fields, err := transformDocument(nil, op.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := doc.LookupErr(op.Operator)
+		if err != nil {
+			doc = doc.Append(op.Operator, bsonx.Document(fields))
+			continue
+		}
+
+		merged := existing.Document().Copy()
+		for _, elem := range fields {
+			merged = merged.Set(elem.Key, elem.Value)
+		}
+		doc = doc.Set(op.Operator, bsonx.Document(merged))
+	}
+
+	if err := checkUpdatePaths(doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Build returns the combined update document. It's equivalent to Document.
+func (ud UpdateDocument) Build() (bsonx.Doc, error) {
+	return ud.Document()
+}
+
+// updatePath is a field targeted by an update operator.
+type updatePath struct {
+	path     string
+	operator string
+}
+
+// checkUpdatePaths returns an UpdateConflictError if the paths targeted by the operators of doc
+// conflict with each other.
+func checkUpdatePaths(doc bsonx.Doc) error {
+	var paths []updatePath
+	for _, op := range doc {
+		for _, field := range op.Value.Document() {
+			paths = append(paths, updatePath{path: field.Key, operator: op.Key})
+			// a renamed field targets its new name as well
+			if to, ok := field.Value.StringValueOK(); ok && op.Key == "$rename" {
+				paths = append(paths, updatePath{path: to, operator: op.Key})
+			}
+		}
+	}
+
+	for i, p := range paths {
+		for _, other := range paths[i+1:] {
+			if p.path == other.path && p.operator != other.operator ||
+				strings.HasPrefix(other.path, p.path+".") || strings.HasPrefix(p.path, other.path+".") {
+				return UpdateConflictError{Path: p.path, ConflictingPath: other.path}
+			}
+		}
+	}
+
+	return nil
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (ud UpdateDocument) MarshalBSON() ([]byte, error) {
+	doc, err := ud.Document()
+	if err != nil {
+		return nil, err
+	}
+	return doc.MarshalBSON()
+}
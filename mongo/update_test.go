@@ -0,0 +1,139 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Combines operators", func(t *testing.T) {
+		update := Update(
+			SetOnInsert(bson.D{{"createdBy", "alice"}}),
+			Inc(bson.D{{"count", int32(1)}}),
+		)
+
+		doc, err := transformDocument(nil, update)
+		require.NoError(t, err)
+		require.True(t, doc.Equal(bsonx.Doc{
+			{"$setOnInsert", bsonx.Document(bsonx.Doc{{"createdBy", bsonx.String("alice")}})},
+			{"$inc", bsonx.Document(bsonx.Doc{{"count", bsonx.Int32(1)}})},
+		}))
+		require.NoError(t, ensureDollarKey(doc))
+	})
+
+	t.Run("Merges operators of the same kind", func(t *testing.T) {
+		first := bsonx.Doc{{"a", bsonx.Int32(1)}, {"b", bsonx.Int32(2)}}
+		update := Update(
+			Set(first),
+			Inc(bson.D{{"count", int32(1)}}),
+			Set(bson.D{{"b", int32(3)}, {"c", int32(4)}}),
+		)
+
+		doc, err := update.Document()
+		require.NoError(t, err)
+		require.True(t, doc.Equal(bsonx.Doc{
+			{"$set", bsonx.Document(bsonx.Doc{{"a", bsonx.Int32(1)}, {"b", bsonx.Int32(3)}, {"c", bsonx.Int32(4)}})},
+			{"$inc", bsonx.Document(bsonx.Doc{{"count", bsonx.Int32(1)}})},
+		}))
+
+		// the documents passed in are not modified
+		require.True(t, first.Equal(bsonx.Doc{{"a", bsonx.Int32(1)}, {"b", bsonx.Int32(2)}}))
+	})
+
+	t.Run("Returns marshal errors", func(t *testing.T) {
+		_, err := transformDocument(nil, Update(Set([]string{"a"})))
+		require.Error(t, err)
+	})
+}
+
+func TestUpdateDocumentOperators(t *testing.T) {
+	t.Parallel()
+
+	update := Update(
+		Set(bson.D{{"a", int32(1)}}),
+		Unset(bson.D{{"b", ""}}),
+		Inc(bson.D{{"c", int32(1)}}),
+		Mul(bson.D{{"d", int32(2)}}),
+		Push(bson.D{{"e", "x"}}),
+		Pull(bson.D{{"f", "y"}}),
+		AddToSet(bson.D{{"g", "z"}}),
+		Pop(bson.D{{"h", int32(-1)}}),
+		Rename(bson.D{{"i", "j"}}),
+		CurrentDate(bson.D{{"k", true}}),
+		Set(bson.D{{"l", int32(2)}}),
+	)
+
+	doc, err := update.Build()
+	require.NoError(t, err)
+	require.True(t, doc.Equal(bsonx.Doc{
+		{"$set", bsonx.Document(bsonx.Doc{{"a", bsonx.Int32(1)}, {"l", bsonx.Int32(2)}})},
+		{"$unset", bsonx.Document(bsonx.Doc{{"b", bsonx.String("")}})},
+		{"$inc", bsonx.Document(bsonx.Doc{{"c", bsonx.Int32(1)}})},
+		{"$mul", bsonx.Document(bsonx.Doc{{"d", bsonx.Int32(2)}})},
+		{"$push", bsonx.Document(bsonx.Doc{{"e", bsonx.String("x")}})},
+		{"$pull", bsonx.Document(bsonx.Doc{{"f", bsonx.String("y")}})},
+		{"$addToSet", bsonx.Document(bsonx.Doc{{"g", bsonx.String("z")}})},
+		{"$pop", bsonx.Document(bsonx.Doc{{"h", bsonx.Int32(-1)}})},
+		{"$rename", bsonx.Document(bsonx.Doc{{"i", bsonx.String("j")}})},
+		{"$currentDate", bsonx.Document(bsonx.Doc{{"k", bsonx.Boolean(true)}})},
+	}), "got %v", doc)
+}
+
+func TestUpdateDocumentConflicts(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		update UpdateDocument
+		err    error
+	}{
+		{
+			"same operator",
+			Update(Set(bson.D{{"a", int32(1)}}), Set(bson.D{{"a", int32(2)}})),
+			nil,
+		},
+		{
+			"sibling paths",
+			Update(Set(bson.D{{"a.b", int32(1)}}), Inc(bson.D{{"a.c", int32(1)}, {"ab", int32(1)}})),
+			nil,
+		},
+		{
+			"different operators",
+			Update(Set(bson.D{{"a", int32(1)}}), Inc(bson.D{{"a", int32(1)}})),
+			UpdateConflictError{Path: "a", ConflictingPath: "a"},
+		},
+		{
+			"subfield",
+			Update(Set(bson.D{{"a", int32(1)}}), Unset(bson.D{{"a.b", ""}})),
+			UpdateConflictError{Path: "a", ConflictingPath: "a.b"},
+		},
+		{
+			"subfield with the same operator",
+			Update(Set(bson.D{{"a.b", int32(1)}}), Set(bson.D{{"a", int32(1)}})),
+			UpdateConflictError{Path: "a.b", ConflictingPath: "a"},
+		},
+		{
+			"rename target",
+			Update(Rename(bson.D{{"a", "b"}}), Set(bson.D{{"b", int32(1)}})),
+			UpdateConflictError{Path: "b", ConflictingPath: "b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.update.Build()
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
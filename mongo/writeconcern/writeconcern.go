@@ -155,7 +155,8 @@ func AcknowledgedElementRaw(rawv bson.RawValue) bool {
 	return i32 != 0
 }
 
-// Acknowledged indicates whether or not a write with the given write concern will be acknowledged.
+// Acknowledged indicates whether or not a write with the given write concern will be acknowledged. Requesting
+// journal acknowledgement with j=true makes a write acknowledged regardless of w.
 func (wc *WriteConcern) Acknowledged() bool {
 	if wc == nil || wc.j {
 		return true
@@ -171,9 +172,10 @@ func (wc *WriteConcern) Acknowledged() bool {
 	return true
 }
 
-// IsValid checks whether the write concern is invalid.
+// IsValid checks whether the write concern is valid. A write concern is invalid if it requests no acknowledgement
+// (w=0) while also requesting journal acknowledgement (j=true). A nil write concern is valid.
 func (wc *WriteConcern) IsValid() bool {
-	if !wc.j {
+	if wc == nil || !wc.j {
 		return true
 	}
 
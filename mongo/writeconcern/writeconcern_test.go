@@ -0,0 +1,71 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package writeconcern_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/mongodb/mongo-go-driver/mongo/writeconcern"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConcern_IsValid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		wc    *WriteConcern
+		valid bool
+		ack   bool
+	}{
+		{"nil", nil, true, true},
+		{"empty", New(), true, true},
+		{"w:0", New(W(0)), true, false},
+		{"w:1", New(W(1)), true, true},
+		{"w:majority", New(WMajority()), true, true},
+		{"w:tag", New(WTagSet("dc1")), true, true},
+		{"j:true", New(J(true)), true, true},
+		{"w:1 j:true", New(W(1), J(true)), true, true},
+		{"w:0 j:false", New(W(0), J(false)), true, false},
+		{"w:majority j:true", New(WMajority(), J(true)), true, true},
+		{"w:0 j:true", New(W(0), J(true)), false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.valid, tc.wc.IsValid())
+			require.Equal(t, tc.ack, tc.wc.Acknowledged())
+			require.Equal(t, tc.ack, AckWrite(tc.wc))
+		})
+	}
+}
+
+func TestWriteConcern_MarshalBSONElement(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		elem, err := New(W(2), J(true), WTimeout(5*time.Second)).MarshalBSONElement()
+		require.NoError(t, err)
+
+		expected := bsonx.Elem{"writeConcern", bsonx.Document(bsonx.Doc{
+			{"w", bsonx.Int32(2)},
+			{"j", bsonx.Boolean(true)},
+			{"wtimeout", bsonx.Int64(5000)},
+		})}
+		require.True(t, expected.Equal(elem))
+	})
+	t.Run("w:0 j:true", func(t *testing.T) {
+		_, err := New(W(0), J(true)).MarshalBSONElement()
+		require.Equal(t, ErrInconsistent, err)
+	})
+	t.Run("negative w", func(t *testing.T) {
+		_, err := New(W(-1)).MarshalBSONElement()
+		require.Equal(t, ErrNegativeW, err)
+	})
+	t.Run("negative wtimeout", func(t *testing.T) {
+		_, err := New(WTimeout(-time.Second)).MarshalBSONElement()
+		require.Equal(t, ErrNegativeWTimeout, err)
+	})
+}
@@ -11,13 +11,18 @@ import "time"
 // AggregateOptions represents all possible options to the aggregate() function
 type AggregateOptions struct {
 	AllowDiskUse             *bool          // Enables writing to temporary files. When set to true, aggregation stages can write data to the _tmp subdirectory in the dbPath directory
+	AutoCloseOnError         *bool          // If true, the cursor is closed as soon as Next returns false because of an error
 	BatchSize                *int32         // The number of documents to return per batch
 	BypassDocumentValidation *bool          // If true, allows the write to opt-out of document level validation. This only applies when the $out stage is specified
 	Collation                *Collation     // Specifies a collation
 	MaxTime                  *time.Duration // The maximum amount of time to allow the query to run
 	MaxAwaitTime             *time.Duration // The maximum amount of time for the server to wait on new documents to satisfy a tailable cursor query
-	Comment                  *string        // Enables users to specify an arbitrary string to help trace the operation through the database profiler, currentOp and logs.
+	Comment                  interface{}    // Enables users to specify an arbitrary value to help trace the operation through the database profiler, currentOp and logs.
 	Hint                     interface{}    // The index to use for the aggregation. The hint does not apply to $lookup and $graphLookup stages
+	KillCursorOnCancel       *bool          // If true, the server cursor is killed when the context is cancelled during iteration
+	Let                      interface{}    // Specifies variables that can be accessed in the pipeline as $$var
+	Search                   *bool          // If set, overrides the detection of Atlas Search pipelines, which are sent without a read concern
+	Timeout                  *time.Duration // The time budget for the operation, including iterating the cursor
 }
 
 // Aggregate returns a pointer to a new AggregateOptions
@@ -33,6 +38,13 @@ func (ao *AggregateOptions) SetAllowDiskUse(b bool) *AggregateOptions {
 	return ao
 }
 
+// SetAutoCloseOnError specifies whether the cursor should be closed as soon
+// as Next returns false because of an error
+func (ao *AggregateOptions) SetAutoCloseOnError(b bool) *AggregateOptions {
+	ao.AutoCloseOnError = &b
+	return ao
+}
+
 // SetBatchSize specifies the number of documents to return per batch
 func (ao *AggregateOptions) SetBatchSize(i int32) *AggregateOptions {
 	ao.BatchSize = &i
@@ -67,10 +79,11 @@ func (ao *AggregateOptions) SetMaxAwaitTime(d time.Duration) *AggregateOptions {
 	return ao
 }
 
-// SetComment enables users to specify an arbitrary string to help trace the
-// operation through the database profiler, currentOp and logs.
-func (ao *AggregateOptions) SetComment(s string) *AggregateOptions {
-	ao.Comment = &s
+// SetComment enables users to specify an arbitrary value to help trace the
+// operation through the database profiler, currentOp and logs. Values other
+// than strings are only supported by server versions >= 4.4
+func (ao *AggregateOptions) SetComment(comment interface{}) *AggregateOptions {
+	ao.Comment = comment
 	return ao
 }
 
@@ -81,6 +94,37 @@ func (ao *AggregateOptions) SetHint(h interface{}) *AggregateOptions {
 	return ao
 }
 
+// SetKillCursorOnCancel specifies whether the server cursor should be killed
+// if the context passed to Next is cancelled
+func (ao *AggregateOptions) SetKillCursorOnCancel(b bool) *AggregateOptions {
+	ao.KillCursorOnCancel = &b
+	return ao
+}
+
+// SetLet specifies a document of variables that can be accessed in the
+// pipeline as $$var. Valid for server versions >= 5.0
+func (ao *AggregateOptions) SetLet(let interface{}) *AggregateOptions {
+	ao.Let = let
+	return ao
+}
+
+// SetSearch marks the pipeline as an Atlas Search pipeline, which is sent without a read concern.
+// Like any other read, it's sent to the server selected by the read preference, unless the
+// pipeline ends with $out. Pipelines whose first stage is $search or $searchMeta are detected
+// automatically; setting this to false disables the detection.
+func (ao *AggregateOptions) SetSearch(b bool) *AggregateOptions {
+	ao.Search = &b
+	return ao
+}
+
+// SetTimeout specifies the time budget for the operation, including any
+// getMore commands sent while iterating the returned cursor. This overrides
+// the client's timeout
+func (ao *AggregateOptions) SetTimeout(d time.Duration) *AggregateOptions {
+	ao.Timeout = &d
+	return ao
+}
+
 // MergeAggregateOptions combines the argued AggregateOptions into a single AggregateOptions in a last-one-wins fashion
 func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 	aggOpts := Aggregate()
@@ -91,6 +135,9 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.AllowDiskUse != nil {
 			aggOpts.AllowDiskUse = ao.AllowDiskUse
 		}
+		if ao.AutoCloseOnError != nil {
+			aggOpts.AutoCloseOnError = ao.AutoCloseOnError
+		}
 		if ao.BatchSize != nil {
 			aggOpts.BatchSize = ao.BatchSize
 		}
@@ -112,6 +159,18 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.Hint != nil {
 			aggOpts.Hint = ao.Hint
 		}
+		if ao.KillCursorOnCancel != nil {
+			aggOpts.KillCursorOnCancel = ao.KillCursorOnCancel
+		}
+		if ao.Let != nil {
+			aggOpts.Let = ao.Let
+		}
+		if ao.Search != nil {
+			aggOpts.Search = ao.Search
+		}
+		if ao.Timeout != nil {
+			aggOpts.Timeout = ao.Timeout
+		}
 	}
 
 	return aggOpts
@@ -19,6 +19,7 @@ type ChangeStreamOptions struct {
 	FullDocument *FullDocument  // When set to ‘updateLookup’, the change notification for partial updates will include both a delta describing the changes to the document, as well as a copy of the entire document that was changed from some time after the change occurred.
 	MaxAwaitTime *time.Duration // The maximum amount of time for the server to wait on new documents to satisfy a change stream query
 	ResumeAfter  bsonx.Doc      // Specifies the logical starting point for the new change stream
+	StartAfter   bsonx.Doc      // Specifies the logical starting point for the new change stream. Unlike ResumeAfter, it can be the resume token of an invalidate event.
 }
 
 // ChangeStream returns a pointer to a new ChangeStreamOptions
@@ -60,6 +61,14 @@ func (cso *ChangeStreamOptions) SetResumeAfter(d bsonx.Doc) *ChangeStreamOptions
 	return cso
 }
 
+// SetStartAfter specifies the logical starting point for the new change stream. Unlike
+// SetResumeAfter, the token can be the resume token of an invalidate event, which allows a new
+// change stream to be started after the collection was dropped or renamed. Requires MongoDB 4.2+.
+func (cso *ChangeStreamOptions) SetStartAfter(d bsonx.Doc) *ChangeStreamOptions {
+	cso.StartAfter = d
+	return cso
+}
+
 // MergeChangeStreamOptions combines the argued ChangeStreamOptions into a single ChangeStreamOptions in a last-one-wins fashion
 func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions {
 	csOpts := ChangeStream()
@@ -82,6 +91,9 @@ func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions
 		if cso.ResumeAfter != nil {
 			csOpts.ResumeAfter = cso.ResumeAfter
 		}
+		if cso.StartAfter != nil {
+			csOpts.StartAfter = cso.StartAfter
+		}
 	}
 
 	return csOpts
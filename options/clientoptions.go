@@ -8,6 +8,7 @@ package options
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"time"
 
@@ -72,11 +73,13 @@ type Credential struct {
 type ClientOptions struct {
 	TopologyOptions []topology.Option
 	ConnString      connstring.ConnString
+	RetryReads      *bool
 	RetryWrites     *bool
 	ReadPreference  *readpref.ReadPref
 	ReadConcern     *readconcern.ReadConcern
 	WriteConcern    *writeconcern.WriteConcern
 	Registry        *bsoncodec.Registry
+	Timeout         *time.Duration
 }
 
 // Client creates a new ClientOptions instance.
@@ -160,6 +163,38 @@ func (c *ClientOptions) SetMonitor(m *event.CommandMonitor) *ClientOptions {
 	return c
 }
 
+// SetPoolMonitor specifies a monitor used to see the connection pool events of a client.
+func (c *ClientOptions) SetPoolMonitor(m *event.PoolMonitor) *ClientOptions {
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+			return append(
+				opts,
+				topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+					return append(
+						opts,
+						connection.WithPoolMonitor(func(*event.PoolMonitor) *event.PoolMonitor {
+							return m
+						}),
+					)
+				}),
+			)
+		}),
+	)
+
+	return c
+}
+
+// SetDirect specifies whether the client connects only to the single host it is given, without
+// discovering the other members of its replica set. It cannot be combined with multiple hosts or
+// a replica set name.
+func (c *ClientOptions) SetDirect(b bool) *ClientOptions {
+	c.ConnString.DirectConnection = b
+	c.ConnString.DirectConnectionSet = true
+
+	return c
+}
+
 // SetHeartbeatInterval specifies the interval to wait between server monitoring checks.
 func (c *ClientOptions) SetHeartbeatInterval(d time.Duration) *ClientOptions {
 	c.ConnString.HeartbeatInterval = d
@@ -175,9 +210,31 @@ func (c *ClientOptions) SetHosts(s []string) *ClientOptions {
 	return c
 }
 
+// SetKeepAlive specifies the TCP keepalive period for connections made by the client.
+func (c *ClientOptions) SetKeepAlive(d time.Duration) *ClientOptions {
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+			return append(
+				opts,
+				topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+					return append(
+						opts,
+						connection.WithKeepAlive(func(time.Duration) time.Duration {
+							return d
+						}),
+					)
+				}),
+			)
+		}),
+	)
+
+	return c
+}
+
 // SetLocalThreshold specifies how far to distribute queries, beyond the server with the fastest
 // round-trip time. If a server's roundtrip time is more than LocalThreshold slower than the
-// the fastest, the driver will not send queries to that server.
+// the fastest, the driver will not send queries to that server. The default is 15 milliseconds.
 func (c *ClientOptions) SetLocalThreshold(d time.Duration) *ClientOptions {
 	c.ConnString.LocalThreshold = d
 	c.ConnString.LocalThresholdSet = true
@@ -194,6 +251,30 @@ func (c *ClientOptions) SetMaxConnIdleTime(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetMaxConnecting specifies the maximum number of connections a server's connection pool establishes
+// concurrently. Checkouts that need a new connection wait while this many are being established.
+// The default is 2.
+func (c *ClientOptions) SetMaxConnecting(u uint64) *ClientOptions {
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+			return append(
+				opts,
+				topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+					return append(
+						opts,
+						connection.WithMaxConnecting(func(uint64) uint64 {
+							return u
+						}),
+					)
+				}),
+			)
+		}),
+	)
+
+	return c
+}
+
 // SetMaxConnsPerHost specifies the max size of a server's connection pool.
 func (c *ClientOptions) SetMaxConnsPerHost(u uint16) *ClientOptions {
 	c.ConnString.MaxConnsPerHost = u
@@ -211,6 +292,26 @@ func (c *ClientOptions) SetMaxIdleConnsPerHost(u uint16) *ClientOptions {
 	return c
 }
 
+// SetMaxPoolSize specifies the max size of a server's connection pool. Checking out a connection
+// blocks while all of them are in use.
+func (c *ClientOptions) SetMaxPoolSize(u uint16) *ClientOptions {
+	c.ConnString.MaxConnsPerHost = u
+	c.ConnString.MaxConnsPerHostSet = true
+	c.ConnString.MaxIdleConnsPerHost = u
+	c.ConnString.MaxIdleConnsPerHostSet = true
+
+	return c
+}
+
+// SetMinPoolSize specifies the minimum number of connections a server's connection pool keeps open.
+// Creating a client fails if it is larger than a non-zero max pool size.
+func (c *ClientOptions) SetMinPoolSize(u uint16) *ClientOptions {
+	c.ConnString.MinPoolSize = u
+	c.ConnString.MinPoolSizeSet = true
+
+	return c
+}
+
 // SetReadConcern specifies the read concern.
 func (c *ClientOptions) SetReadConcern(rc *readconcern.ReadConcern) *ClientOptions {
 	c.ReadConcern = rc
@@ -239,6 +340,14 @@ func (c *ClientOptions) SetReplicaSet(s string) *ClientOptions {
 	return c
 }
 
+// SetRetryReads specifies whether the client has retryable reads enabled. Retryable reads are
+// enabled by default.
+func (c *ClientOptions) SetRetryReads(b bool) *ClientOptions {
+	c.RetryReads = &b
+
+	return c
+}
+
 // SetRetryWrites specifies whether the client has retryable writes enabled.
 func (c *ClientOptions) SetRetryWrites(b bool) *ClientOptions {
 	c.RetryWrites = &b
@@ -246,6 +355,30 @@ func (c *ClientOptions) SetRetryWrites(b bool) *ClientOptions {
 	return c
 }
 
+// SetServerAPIOptions specifies the server API version declared by the client. The apiVersion,
+// apiStrict, and apiDeprecationErrors fields are attached to the connection handshake and to every
+// subsequent command. Requires MongoDB 5.0+.
+func (c *ClientOptions) SetServerAPIOptions(opts *ServerAPIOptions) *ClientOptions {
+	if opts == nil {
+		return c
+	}
+
+	serverAPI := &connection.ServerAPI{
+		Version:           opts.Version,
+		Strict:            opts.Strict,
+		DeprecationErrors: opts.DeprecationErrors,
+	}
+
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithServerAPI(func(*connection.ServerAPI) *connection.ServerAPI {
+			return serverAPI
+		}),
+	)
+
+	return c
+}
+
 // SetServerSelectionTimeout specifies a timeout in milliseconds to block for server selection.
 func (c *ClientOptions) SetServerSelectionTimeout(d time.Duration) *ClientOptions {
 	c.ConnString.ServerSelectionTimeout = d
@@ -276,6 +409,17 @@ func (c *ClientOptions) SetSocketTimeout(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetTimeout specifies the time budget for every operation run by the client. Find and Aggregate
+// can override it with their own Timeout option. When set, a deadline is derived for the whole
+// operation, including iterating any cursor it returns, and each command it sends carries a maxTimeMS
+// computed from the time remaining. getMore commands only carry one for tailable await cursors.
+// Operations that run out of time return a command.TimeoutError.
+func (c *ClientOptions) SetTimeout(d time.Duration) *ClientOptions {
+	c.Timeout = &d
+
+	return c
+}
+
 // SetSSL sets SSL options.
 func (c *ClientOptions) SetSSL(ssl *SSLOpt) *ClientOptions {
 	c.ConnString.SSL = ssl.Enabled
@@ -296,6 +440,53 @@ func (c *ClientOptions) SetSSL(ssl *SSLOpt) *ClientOptions {
 	return c
 }
 
+// SetTLSConfig sets the TLS configuration used for connections, taking the place of any SSL options
+// given in the connection string or through SetSSL. The server's hostname is used for verification
+// unless cfg.ServerName is set. A nil cfg leaves the options unchanged.
+func (c *ClientOptions) SetTLSConfig(cfg *tls.Config) *ClientOptions {
+	if cfg == nil {
+		return c
+	}
+
+	c.ConnString.SSL = false
+	c.ConnString.SSLSet = true
+
+	tlsConfig := &connection.TLSConfig{Config: cfg}
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithTLSConfig(func(*connection.TLSConfig) *connection.TLSConfig {
+			return tlsConfig
+		}),
+	)
+
+	return c
+}
+
+// SetWaitQueueTimeout specifies the maximum amount of time a checkout waits for a connection when a
+// server's connection pool has none available. When it is shorter than the time remaining before
+// the operation's context expires, the operation fails with a connection.WaitQueueTimeoutError
+// once it has waited this long. The default of 0 bounds the wait only by the context.
+func (c *ClientOptions) SetWaitQueueTimeout(d time.Duration) *ClientOptions {
+	c.TopologyOptions = append(
+		c.TopologyOptions,
+		topology.WithServerOptions(func(opts ...topology.ServerOption) []topology.ServerOption {
+			return append(
+				opts,
+				topology.WithConnectionOptions(func(opts ...connection.Option) []connection.Option {
+					return append(
+						opts,
+						connection.WithWaitQueueTimeout(func(time.Duration) time.Duration {
+							return d
+						}),
+					)
+				}),
+			)
+		}),
+	)
+
+	return c
+}
+
 // SetWriteConcern sets the write concern.
 func (c *ClientOptions) SetWriteConcern(wc *writeconcern.WriteConcern) *ClientOptions {
 	c.WriteConcern = wc
@@ -338,6 +529,10 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 			c.ConnString.ConnectTimeoutSet = true
 			c.ConnString.ConnectTimeout = opt.ConnString.ConnectTimeout
 		}
+		if opt.ConnString.DirectConnectionSet {
+			c.ConnString.DirectConnectionSet = true
+			c.ConnString.DirectConnection = opt.ConnString.DirectConnection
+		}
 		if opt.ConnString.HeartbeatIntervalSet {
 			c.ConnString.HeartbeatIntervalSet = true
 			c.ConnString.HeartbeatInterval = opt.ConnString.HeartbeatInterval
@@ -361,6 +556,10 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 			c.ConnString.MaxIdleConnsPerHostSet = true
 			c.ConnString.MaxIdleConnsPerHost = opt.ConnString.MaxIdleConnsPerHost
 		}
+		if opt.ConnString.MinPoolSizeSet {
+			c.ConnString.MinPoolSizeSet = true
+			c.ConnString.MinPoolSize = opt.ConnString.MinPoolSize
+		}
 		if opt.ReadConcern != nil {
 			c.ReadConcern = opt.ReadConcern
 		}
@@ -373,6 +572,9 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 		if rs := opt.ConnString.ReplicaSet; rs != "" {
 			c.ConnString.ReplicaSet = rs
 		}
+		if opt.RetryReads != nil {
+			c.RetryReads = opt.RetryReads
+		}
 		if opt.RetryWrites != nil {
 			c.RetryWrites = opt.RetryWrites
 		}
@@ -408,6 +610,9 @@ func MergeClientOptions(cs connstring.ConnString, opts ...*ClientOptions) *Clien
 			c.ConnString.SSLCaFileSet = true
 			c.ConnString.SSLCaFile = opt.ConnString.SSLCaFile
 		}
+		if opt.Timeout != nil {
+			c.Timeout = opt.Timeout
+		}
 		if opt.WriteConcern != nil {
 			c.WriteConcern = opt.WriteConcern
 		}
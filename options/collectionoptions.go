@@ -19,6 +19,7 @@ type CollectionOptions struct {
 	WriteConcern   *writeconcern.WriteConcern // The write concern for operations in the collection.
 	ReadPreference *readpref.ReadPref         // The read preference for operations in the collection.
 	Registry       *bsoncodec.Registry        // The registry to be used to construct BSON encoders and decoders for the collection.
+	Collation      *Collation                 // The default collation for operations in the collection.
 }
 
 // Collection creates a new CollectionOptions instance
@@ -50,6 +51,13 @@ func (c *CollectionOptions) SetRegistry(r *bsoncodec.Registry) *CollectionOption
 	return c
 }
 
+// SetCollation sets the default collation for the collection. It is applied to reads and writes
+// that don't specify a collation of their own.
+func (c *CollectionOptions) SetCollation(collation *Collation) *CollectionOptions {
+	c.Collation = collation
+	return c
+}
+
 // MergeCollectionOptions combines the *CollectionOptions arguments into a single *CollectionOptions in a last one wins
 // fashion.
 func MergeCollectionOptions(opts ...*CollectionOptions) *CollectionOptions {
@@ -71,6 +79,9 @@ func MergeCollectionOptions(opts ...*CollectionOptions) *CollectionOptions {
 		if opt.Registry != nil {
 			c.Registry = opt.Registry
 		}
+		if opt.Collation != nil {
+			c.Collation = opt.Collation
+		}
 	}
 
 	return c
@@ -9,6 +9,7 @@ package options
 // CountOptions represents all possible options to the count() function
 type CountOptions struct {
 	Collation *Collation  // Specifies a collation
+	Comment   interface{} // A value to help trace the operation through the database profiler, currentOp and logs
 	Hint      interface{} // The index to use
 	Limit     *int64      // The maximum number of documents to count
 	MaxTime   *int64      // The maximum amount of time to allow the operation to run
@@ -27,6 +28,14 @@ func (co *CountOptions) SetCollation(c *Collation) *CountOptions {
 	return co
 }
 
+// SetComment specifies a value to help trace the operation through the
+// database profiler, currentOp and logs. Values other than strings are only
+// supported by server versions >= 4.4
+func (co *CountOptions) SetComment(comment interface{}) *CountOptions {
+	co.Comment = comment
+	return co
+}
+
 // SetHint specifies the index to use
 func (co *CountOptions) SetHint(h interface{}) *CountOptions {
 	co.Hint = h
@@ -61,6 +70,9 @@ func MergeCountOptions(opts ...*CountOptions) *CountOptions {
 		if co.Collation != nil {
 			countOpts.Collation = co.Collation
 		}
+		if co.Comment != nil {
+			countOpts.Comment = co.Comment
+		}
 		if co.Hint != nil {
 			countOpts.Hint = co.Hint
 		}
@@ -13,11 +13,15 @@ import (
 // FindOptions represent all possible options to the find() function.
 type FindOptions struct {
 	AllowPartialResults *bool          // If true, allows partial results to be returned if some shards are down.
+	AutoCloseOnError    *bool          // If true, the cursor is closed as soon as Next returns false because of an error.
 	BatchSize           *int32         // Specifies the number of documents to return in every batch.
 	Collation           *Collation     // Specifies a collation to be used
 	Comment             *string        // Specifies a string to help trace the operation through the database.
 	CursorType          *CursorType    // Specifies the type of cursor to use
+	FilterAppender      BSONAppender   // Serializes the filter instead of the collection's registry.
 	Hint                interface{}    // Specifies the index to use.
+	KillCursorOnCancel  *bool          // If true, the server cursor is killed when the context is cancelled during iteration.
+	Let                 interface{}    // Specifies variables that can be accessed in the filter as $$var.
 	Limit               *int64         // Sets a limit on the number of results to return.
 	Max                 interface{}    // Sets an exclusive upper bound for a specific index
 	MaxAwaitTime        *time.Duration // Specifies the maximum amount of time for the server to wait on new documents.
@@ -31,6 +35,7 @@ type FindOptions struct {
 	Skip                *int64         // Specifies the number of documents to skip before returning
 	Snapshot            *bool          // If true, prevents the cursor from returning a document more than once because of an intervening write operation.
 	Sort                interface{}    // Specifies the order in which to return results.
+	Timeout             *time.Duration // Specifies the time budget for the operation, including iterating the cursor.
 }
 
 // Find creates a new FindOptions instance.
@@ -45,6 +50,13 @@ func (f *FindOptions) SetAllowPartialResults(b bool) *FindOptions {
 	return f
 }
 
+// SetAutoCloseOnError sets whether the cursor should be closed as soon as Next returns false because of an
+// error. This releases the server-side cursor without requiring a call to Close on error paths.
+func (f *FindOptions) SetAutoCloseOnError(b bool) *FindOptions {
+	f.AutoCloseOnError = &b
+	return f
+}
+
 // SetBatchSize sets the number of documents to return in each batch.
 func (f *FindOptions) SetBatchSize(i int32) *FindOptions {
 	f.BatchSize = &i
@@ -76,6 +88,30 @@ func (f *FindOptions) SetHint(hint interface{}) *FindOptions {
 	return f
 }
 
+// SetKillCursorOnCancel sets whether the server cursor should be killed if the context passed to Next is
+// cancelled. The killCursors command is sent on a pooled connection. Checking out the connection and
+// running the command share a short deadline of their own, so a busy pool cannot block Next.
+func (f *FindOptions) SetKillCursorOnCancel(b bool) *FindOptions {
+	f.KillCursorOnCancel = &b
+	return f
+}
+
+// SetLet specifies a document of variables that can be accessed in the filter as $$var.
+// Valid for server versions >= 5.0.
+func (f *FindOptions) SetLet(let interface{}) *FindOptions {
+	f.Let = let
+	return f
+}
+
+// SetFilterAppender specifies a BSONAppender that serializes the filter in place of the collection's
+// registry. The filter is passed to it unchanged, so it can, for example, append bytes cached from
+// an earlier call to avoid marshaling the same filter repeatedly. The appended bytes are validated
+// as a BSON document before the command is sent.
+func (f *FindOptions) SetFilterAppender(a BSONAppender) *FindOptions {
+	f.FilterAppender = a
+	return f
+}
+
 // SetLimit specifies a limit on the number of results.
 // A negative limit implies that only 1 batch should be returned.
 func (f *FindOptions) SetLimit(i int64) *FindOptions {
@@ -161,6 +197,14 @@ func (f *FindOptions) SetSort(sort interface{}) *FindOptions {
 	return f
 }
 
+// SetTimeout specifies the time budget for the operation, including any getMore commands sent while
+// iterating the returned cursor. The maxTimeMS of the find command, and of each getMore of a tailable
+// await cursor, is computed from the time remaining. This overrides the client's timeout.
+func (f *FindOptions) SetTimeout(d time.Duration) *FindOptions {
+	f.Timeout = &d
+	return f
+}
+
 // MergeFindOptions combines the argued FindOptions into a single FindOptions in a last-one-wins fashion
 func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 	fo := Find()
@@ -171,6 +215,9 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.AllowPartialResults != nil {
 			fo.AllowPartialResults = opt.AllowPartialResults
 		}
+		if opt.AutoCloseOnError != nil {
+			fo.AutoCloseOnError = opt.AutoCloseOnError
+		}
 		if opt.BatchSize != nil {
 			fo.BatchSize = opt.BatchSize
 		}
@@ -183,9 +230,18 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.CursorType != nil {
 			fo.CursorType = opt.CursorType
 		}
+		if opt.FilterAppender != nil {
+			fo.FilterAppender = opt.FilterAppender
+		}
 		if opt.Hint != nil {
 			fo.Hint = opt.Hint
 		}
+		if opt.KillCursorOnCancel != nil {
+			fo.KillCursorOnCancel = opt.KillCursorOnCancel
+		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.Limit != nil {
 			fo.Limit = opt.Limit
 		}
@@ -225,6 +281,9 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.Sort != nil {
 			fo.Sort = opt.Sort
 		}
+		if opt.Timeout != nil {
+			fo.Timeout = opt.Timeout
+		}
 	}
 
 	return fo
@@ -238,6 +297,7 @@ type FindOneOptions struct {
 	Comment             *string        // Specifies a string to help trace the operation through the database.
 	CursorType          *CursorType    // Specifies the type of cursor to use
 	Hint                interface{}    // Specifies the index to use.
+	Let                 interface{}    // Specifies variables that can be accessed in the filter as $$var.
 	Max                 interface{}    // Sets an exclusive upper bound for a specific index
 	MaxAwaitTime        *time.Duration // Specifies the maximum amount of time for the server to wait on new documents.
 	MaxTime             *time.Duration // Specifies the maximum amount of time to allow the query to run.
@@ -293,6 +353,13 @@ func (f *FindOneOptions) SetHint(hint interface{}) *FindOneOptions {
 	return f
 }
 
+// SetLet specifies a document of variables that can be accessed in the filter as $$var.
+// Valid for server versions >= 5.0.
+func (f *FindOneOptions) SetLet(let interface{}) *FindOneOptions {
+	f.Let = let
+	return f
+}
+
 // SetMax specifies an exclusive upper bound for a specific index.
 func (f *FindOneOptions) SetMax(max interface{}) *FindOneOptions {
 	f.Max = max
@@ -391,6 +458,9 @@ func MergeFindOneOptions(opts ...*FindOneOptions) *FindOneOptions {
 		if opt.Hint != nil {
 			fo.Hint = opt.Hint
 		}
+		if opt.Let != nil {
+			fo.Let = opt.Let
+		}
 		if opt.Max != nil {
 			fo.Max = opt.Max
 		}
@@ -0,0 +1,153 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// MapReduceOutAction specifies how the results of a map-reduce are written to an existing output collection
+type MapReduceOutAction string
+
+// These constants are the valid output actions for a map-reduce.
+const (
+	// MapReduceReplace replaces the contents of the output collection with the results
+	MapReduceReplace MapReduceOutAction = "replace"
+	// MapReduceMerge overwrites documents in the output collection that share a key with the results
+	MapReduceMerge MapReduceOutAction = "merge"
+	// MapReduceReduce reduces results that share a key with documents in the output collection
+	MapReduceReduce MapReduceOutAction = "reduce"
+)
+
+// MapReduceOptions represents all possible options to the mapReduce() function
+type MapReduceOptions struct {
+	BypassDocumentValidation *bool               // If true, allows the write to opt-out of document level validation. This only applies when Out is specified
+	Collation                *Collation          // Specifies a collation
+	Finalize                 *string             // A JavaScript function that modifies the output of the reduce function
+	Limit                    *int64              // The maximum number of documents to input into the map function
+	MaxTime                  *time.Duration      // The maximum amount of time to allow the query to run
+	Out                      *string             // The collection to write the results to. If not specified, the results are returned inline
+	OutAction                *MapReduceOutAction // How the results are written to the Out collection. Defaults to MapReduceReplace
+	Query                    interface{}         // A filter that selects the documents to input into the map function
+	Scope                    interface{}         // Global variables that are accessible in the map, reduce, and finalize functions
+	Sort                     interface{}         // The order in which documents are input into the map function
+}
+
+// MapReduce returns a pointer to a new MapReduceOptions
+func MapReduce() *MapReduceOptions {
+	return &MapReduceOptions{}
+}
+
+// SetBypassDocumentValidation allows the write to opt-out of document level
+// validation. This only applies when an output collection is specified
+// Valid for server versions >= 3.2. For servers < 3.2, this option is ignored.
+func (mro *MapReduceOptions) SetBypassDocumentValidation(b bool) *MapReduceOptions {
+	mro.BypassDocumentValidation = &b
+	return mro
+}
+
+// SetCollation specifies a collation.
+// Valid for server versions >= 3.4
+func (mro *MapReduceOptions) SetCollation(c *Collation) *MapReduceOptions {
+	mro.Collation = c
+	return mro
+}
+
+// SetFinalize specifies a JavaScript function that modifies the output of the
+// reduce function
+func (mro *MapReduceOptions) SetFinalize(f string) *MapReduceOptions {
+	mro.Finalize = &f
+	return mro
+}
+
+// SetLimit specifies the maximum number of documents to input into the map
+// function
+func (mro *MapReduceOptions) SetLimit(i int64) *MapReduceOptions {
+	mro.Limit = &i
+	return mro
+}
+
+// SetMaxTime specifies the maximum amount of time to allow the query to run
+func (mro *MapReduceOptions) SetMaxTime(d time.Duration) *MapReduceOptions {
+	mro.MaxTime = &d
+	return mro
+}
+
+// SetOut specifies the collection, in the same database, to write the results
+// to instead of returning them inline
+func (mro *MapReduceOptions) SetOut(coll string) *MapReduceOptions {
+	mro.Out = &coll
+	return mro
+}
+
+// SetOutAction specifies how the results are written to the output collection
+// if it already exists
+func (mro *MapReduceOptions) SetOutAction(action MapReduceOutAction) *MapReduceOptions {
+	mro.OutAction = &action
+	return mro
+}
+
+// SetQuery specifies a filter that selects the documents to input into the
+// map function
+func (mro *MapReduceOptions) SetQuery(query interface{}) *MapReduceOptions {
+	mro.Query = query
+	return mro
+}
+
+// SetScope specifies global variables that are accessible in the map, reduce,
+// and finalize functions
+func (mro *MapReduceOptions) SetScope(scope interface{}) *MapReduceOptions {
+	mro.Scope = scope
+	return mro
+}
+
+// SetSort specifies the order in which documents are input into the map
+// function
+func (mro *MapReduceOptions) SetSort(sort interface{}) *MapReduceOptions {
+	mro.Sort = sort
+	return mro
+}
+
+// MergeMapReduceOptions combines the argued MapReduceOptions into a single MapReduceOptions in a last-one-wins fashion
+func MergeMapReduceOptions(opts ...*MapReduceOptions) *MapReduceOptions {
+	mrOpts := MapReduce()
+	for _, mro := range opts {
+		if mro == nil {
+			continue
+		}
+		if mro.BypassDocumentValidation != nil {
+			mrOpts.BypassDocumentValidation = mro.BypassDocumentValidation
+		}
+		if mro.Collation != nil {
+			mrOpts.Collation = mro.Collation
+		}
+		if mro.Finalize != nil {
+			mrOpts.Finalize = mro.Finalize
+		}
+		if mro.Limit != nil {
+			mrOpts.Limit = mro.Limit
+		}
+		if mro.MaxTime != nil {
+			mrOpts.MaxTime = mro.MaxTime
+		}
+		if mro.Out != nil {
+			mrOpts.Out = mro.Out
+		}
+		if mro.OutAction != nil {
+			mrOpts.OutAction = mro.OutAction
+		}
+		if mro.Query != nil {
+			mrOpts.Query = mro.Query
+		}
+		if mro.Scope != nil {
+			mrOpts.Scope = mro.Scope
+		}
+		if mro.Sort != nil {
+			mrOpts.Sort = mro.Sort
+		}
+	}
+
+	return mrOpts
+}
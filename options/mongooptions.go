@@ -95,6 +95,26 @@ const (
 	UpdateLookup FullDocument = "updateLookup"
 )
 
+// ExplainVerbosity specifies how much information an explain command should return about the
+// explained operation.
+type ExplainVerbosity string
+
+const (
+	// QueryPlanner includes the plan selected by the query optimizer.
+	QueryPlanner ExplainVerbosity = "queryPlanner"
+	// ExecutionStats also runs the selected plan and includes statistics describing its execution.
+	ExecutionStats ExplainVerbosity = "executionStats"
+	// AllPlansExecution also includes execution statistics for the plans that were considered
+	// during plan selection.
+	AllPlansExecution ExplainVerbosity = "allPlansExecution"
+)
+
+// BSONAppender is implemented by types that append the BSON encoding of a value to a []byte. The
+// mongo package refers to it as mongo.BSONAppender.
+type BSONAppender interface {
+	AppendBSON([]byte, interface{}) ([]byte, error)
+}
+
 // ArrayFilters is used to hold filters for the array filters CRUD option. If a registry is nil, bson.DefaultRegistry
 // will be used when converting the filter interfaces to BSON.
 type ArrayFilters struct {
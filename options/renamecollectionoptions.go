@@ -0,0 +1,40 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// RenameCollectionOptions represents all possible options to the renameCollection() function
+type RenameCollectionOptions struct {
+	DropTarget *bool // If true, an existing collection with the new name is dropped before the rename
+}
+
+// RenameCollection returns a pointer to a new RenameCollectionOptions
+func RenameCollection() *RenameCollectionOptions {
+	return &RenameCollectionOptions{}
+}
+
+// SetDropTarget specifies whether an existing collection with the new name
+// should be dropped before the rename. If false, the rename fails when the
+// target collection exists
+func (rco *RenameCollectionOptions) SetDropTarget(b bool) *RenameCollectionOptions {
+	rco.DropTarget = &b
+	return rco
+}
+
+// MergeRenameCollectionOptions combines the argued RenameCollectionOptions into a single RenameCollectionOptions in a last-one-wins fashion
+func MergeRenameCollectionOptions(opts ...*RenameCollectionOptions) *RenameCollectionOptions {
+	rcOpts := RenameCollection()
+	for _, rco := range opts {
+		if rco == nil {
+			continue
+		}
+		if rco.DropTarget != nil {
+			rcOpts.DropTarget = rco.DropTarget
+		}
+	}
+
+	return rcOpts
+}
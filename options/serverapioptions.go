@@ -0,0 +1,35 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ServerAPIVersion1 is the first version of the MongoDB Stable API.
+const ServerAPIVersion1 = "1"
+
+// ServerAPIOptions represents the server API version declared by a client. Requires MongoDB 5.0+.
+type ServerAPIOptions struct {
+	Version           string // The declared API version.
+	Strict            *bool  // If true, the server rejects commands that are not part of the declared API version.
+	DeprecationErrors *bool  // If true, the server rejects commands that are deprecated in the declared API version.
+}
+
+// ServerAPI creates a new ServerAPIOptions instance declaring the given API version.
+func ServerAPI(version string) *ServerAPIOptions {
+	return &ServerAPIOptions{Version: version}
+}
+
+// SetStrict specifies whether the server rejects commands that are not part of the declared API version.
+func (s *ServerAPIOptions) SetStrict(b bool) *ServerAPIOptions {
+	s.Strict = &b
+	return s
+}
+
+// SetDeprecationErrors specifies whether the server rejects commands that are deprecated in the
+// declared API version.
+func (s *ServerAPIOptions) SetDeprecationErrors(b bool) *ServerAPIOptions {
+	s.DeprecationErrors = &b
+	return s
+}
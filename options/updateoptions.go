@@ -11,6 +11,7 @@ type UpdateOptions struct {
 	ArrayFilters             *ArrayFilters // A set of filters specifying to which array elements an update should apply
 	BypassDocumentValidation *bool         // If true, allows the write to opt-out of document level validation
 	Collation                *Collation    // Specifies a collation
+	Let                      interface{}   // Specifies variables that can be accessed in the filter and update as $$var
 	Upsert                   *bool         // When true, creates a new document if no document matches the query
 }
 
@@ -40,6 +41,13 @@ func (uo *UpdateOptions) SetCollation(c *Collation) *UpdateOptions {
 	return uo
 }
 
+// SetLet specifies a document of variables that can be accessed in the filter and update as $$var.
+// Valid for server versions >= 5.0.
+func (uo *UpdateOptions) SetLet(let interface{}) *UpdateOptions {
+	uo.Let = let
+	return uo
+}
+
 // SetUpsert allows the creation of a new document if not document matches the query
 func (uo *UpdateOptions) SetUpsert(b bool) *UpdateOptions {
 	uo.Upsert = &b
@@ -62,6 +70,9 @@ func MergeUpdateOptions(opts ...*UpdateOptions) *UpdateOptions {
 		if uo.Collation != nil {
 			uOpts.Collation = uo.Collation
 		}
+		if uo.Let != nil {
+			uOpts.Let = uo.Let
+		}
 		if uo.Upsert != nil {
 			uOpts.Upsert = uo.Upsert
 		}
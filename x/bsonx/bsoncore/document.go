@@ -330,6 +330,34 @@ func (d Document) Elements() ([]Element, error) {
 	return elems, nil
 }
 
+// Keys returns the keys of the top-level elements of this document, in order. The values of the
+// elements are skipped without being validated or decoded. If the document is not valid, the keys
+// up to the invalid point will be returned along with an error.
+func (d Document) Keys() ([]string, error) {
+	length, rem, ok := ReadLength(d)
+	if !ok {
+		return nil, NewInsufficientBytesError(d, rem)
+	}
+
+	length -= 4
+
+	var elem Element
+	var keys []string
+	for length > 1 {
+		elem, rem, ok = ReadElement(rem)
+		length -= int32(len(elem))
+		if !ok {
+			return keys, NewInsufficientBytesError(d, rem)
+		}
+		key, err := elem.KeyErr()
+		if err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 // Values returns this document as a slice of values. The returned slice will contain valid values.
 // If the document is not valid, the values up to the invalid point will be returned along with an
 // error.
@@ -409,4 +409,41 @@ func TestDocument(t *testing.T) {
 			})
 		}
 	})
+	t.Run("Keys", func(t *testing.T) {
+		nested := BuildDocument(nil,
+			AppendDocumentElement(
+				AppendDoubleElement(nil, "pi", 3.14159),
+				"nested", BuildDocument(nil, AppendStringElement(nil, "inner", "value")),
+			),
+		)
+		invalidSecondElem := BuildDocument(nil,
+			AppendHeader(
+				AppendDoubleElement(nil, "pi", 3.14159),
+				bsontype.Double, "foo",
+			),
+		)
+		testCases := []struct {
+			name string
+			doc  Document
+			keys []string
+			err  error
+		}{
+			{"Insufficient Bytes Length", Document{0x03, 0x00, 0x00}, nil, NewInsufficientBytesError(nil, nil)},
+			{"Insufficient Bytes Second Element", invalidSecondElem, []string{"pi"}, NewInsufficientBytesError(nil, nil)},
+			{"Empty", BuildDocument(nil, nil), nil, nil},
+			{"Nested Document", nested, []string{"pi", "nested"}, nil},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				keys, err := tc.doc.Keys()
+				if !compareErrors(err, tc.err) {
+					t.Errorf("errors do not match. got %v; want %v", err, tc.err)
+				}
+				if !cmp.Equal(keys, tc.keys) {
+					t.Errorf("keys do not match. got %v; want %v", keys, tc.keys)
+				}
+			})
+		}
+	})
 }
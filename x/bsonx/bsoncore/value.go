@@ -198,7 +198,7 @@ func (v Value) String() string {
 		if !ok {
 			return ""
 		}
-		return fmt.Sprintf(`{"$code":%s,"$scope":%s}`, code, scope)
+		return fmt.Sprintf(`{"$code":%s,"$scope":%s}`, escapeString(code), scope)
 	case bsontype.Int32:
 		i32, ok := v.Int32OK()
 		if !ok {
@@ -259,7 +259,7 @@ func (v Value) DebugString() string {
 		if !ok {
 			return ""
 		}
-		return fmt.Sprintf(`{"$code":%s,"$scope":%s}`, code, scope.DebugString())
+		return fmt.Sprintf(`{"$code":%s,"$scope":%s}`, escapeString(code), scope.DebugString())
 	default:
 		str := v.String()
 		if str == "" {
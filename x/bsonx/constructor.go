@@ -9,6 +9,7 @@ package bsonx
 import (
 	"encoding/binary"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
@@ -17,8 +18,8 @@ import (
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 )
 
-// IDoc is the interface implemented by Doc and MDoc. It allows either of these types to be provided
-// to the Document function to create a Value.
+// IDoc is the interface implemented by Doc, MDoc, and RawDoc. It allows any of these types to be
+// provided to the Document function to create a Value.
 type IDoc interface {
 	idoc()
 }
@@ -52,6 +53,13 @@ func Document(doc IDoc) Val {
 		}
 		v.t = bsontype.EmbeddedDocument
 		v.primitive = tt
+	case RawDoc:
+		if tt == nil {
+			v.t = bsontype.Null
+			break
+		}
+		v.t = bsontype.EmbeddedDocument
+		v.primitive = tt
 	default:
 		v.t = bsontype.Null
 	}
@@ -101,12 +109,19 @@ func Time(t time.Time) Val {
 // Null constructs a BSON binary Value.
 func Null() Val { return Val{t: bsontype.Null} }
 
-// Regex constructs a BSON regex Value.
+// Regex constructs a BSON regex Value. The options are sorted alphabetically.
 func Regex(pattern, options string) Val {
-	regex := primitive.Regex{Pattern: pattern, Options: options}
+	regex := primitive.Regex{Pattern: pattern, Options: sortRegexOptions(options)}
 	return Val{t: bsontype.Regex, primitive: regex}
 }
 
+// sortRegexOptions sorts the options of a regex alphabetically, which is the order MongoDB requires.
+func sortRegexOptions(options string) string {
+	opts := []rune(options)
+	sort.Slice(opts, func(i, j int) bool { return opts[i] < opts[j] })
+	return string(opts)
+}
+
 // DBPointer constructs a BSON dbpointer Value.
 func DBPointer(ns string, ptr objectid.ObjectID) Val {
 	dbptr := primitive.DBPointer{DB: ns, Pointer: ptr}
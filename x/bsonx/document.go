@@ -7,9 +7,10 @@
 package bsonx
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
 	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
@@ -107,6 +108,18 @@ func (d Doc) Delete(key string) Doc {
 	return append(d[:idx], d[idx+1:]...)
 }
 
+// Filter returns a new document containing only the elements for which keep returns true, in their
+// original order. The receiver is not modified.
+func (d Doc) Filter(keep func(Elem) bool) Doc {
+	d2 := make(Doc, 0, len(d))
+	for _, elem := range d {
+		if keep(elem) {
+			d2 = append(d2, elem)
+		}
+	}
+	return d2
+}
+
 // Lookup searches the document and potentially subdocuments or arrays for the
 // provided key. Each key provided to this method represents a layer of depth.
 //
@@ -164,6 +177,11 @@ func (d Doc) LookupElementErr(key ...string) (Elem, error) {
 			elem, err = tt.LookupElementErr(key[1:]...)
 		case MDoc:
 			elem, err = tt.LookupElementErr(key[1:]...)
+		case RawDoc:
+			var doc Doc
+			if doc, err = elem.Value.asDoc(); err == nil {
+				elem, err = doc.LookupElementErr(key[1:]...)
+			}
 		}
 	default:
 		return Elem{}, KeyNotFound{Type: elem.Value.Type()}
@@ -180,6 +198,85 @@ func (d Doc) LookupElementErr(key ...string) (Elem, error) {
 	}
 }
 
+// SetPath sets the value of the element at the provided dotted path, such as "a.b.c", and returns
+// the updated Doc. Missing intermediate subdocuments are created. When a component of the path
+// refers to an element of an array, it must be an index into the array or equal to the length of
+// the array, in which case the value is appended.
+func (d Doc) SetPath(path string, val Val) (Doc, error) {
+	if path == "" {
+		return d, errors.New("path cannot be empty")
+	}
+
+	return d.setPath(strings.Split(path, "."), val)
+}
+
+func (d Doc) setPath(keys []string, val Val) (Doc, error) {
+	if len(keys) == 1 {
+		return d.Set(keys[0], val), nil
+	}
+
+	idx := d.indexOf(keys[0])
+	if idx == -1 {
+		sub, err := Doc{}.setPath(keys[1:], val)
+		if err != nil {
+			return d, err
+		}
+		return append(d, Elem{Key: keys[0], Value: Document(sub)}), nil
+	}
+
+	v, err := setValuePath(d[idx].Value, keys[1:], val)
+	if err != nil {
+		return d, err
+	}
+	d[idx].Value = v
+	return d, nil
+}
+
+// setValuePath sets val at the path described by keys within v, which must be a document or an
+// array, and returns the updated value.
+func setValuePath(v Val, keys []string, val Val) (Val, error) {
+	switch v.Type() {
+	case bsontype.EmbeddedDocument:
+		doc, err := v.Document().setPath(keys, val)
+		if err != nil {
+			return v, err
+		}
+		return Document(doc), nil
+	case bsontype.Array:
+		arr := v.Array()
+		idx, err := strconv.Atoi(keys[0])
+		if err != nil || idx < 0 {
+			return v, fmt.Errorf(`key "%s" is not a valid array index`, keys[0])
+		}
+		if idx > len(arr) {
+			return v, fmt.Errorf("array index %d is out of range for array of length %d", idx, len(arr))
+		}
+
+		elem := val
+		if len(keys) > 1 {
+			if idx < len(arr) {
+				elem, err = setValuePath(arr[idx], keys[1:], val)
+			} else {
+				var sub Doc
+				sub, err = Doc{}.setPath(keys[1:], val)
+				elem = Document(sub)
+			}
+			if err != nil {
+				return v, err
+			}
+		}
+
+		if idx == len(arr) {
+			arr = append(arr, elem)
+		} else {
+			arr[idx] = elem
+		}
+		return Array(arr), nil
+	default:
+		return v, fmt.Errorf(`key "%s" cannot be set within BSON type %s`, keys[0], v.Type())
+	}
+}
+
 // MarshalBSONValue implements the bsoncodec.ValueMarshaler interface.
 //
 // This method will never return an error.
@@ -268,6 +365,9 @@ func (d Doc) Equal(id IDoc) bool {
 		if len(unique) != len(tt) {
 			return false
 		}
+	case RawDoc:
+		doc, err := ReadDoc(tt)
+		return err == nil && d.Equal(doc)
 	case nil:
 		return d == nil
 	default:
@@ -277,19 +377,12 @@ func (d Doc) Equal(id IDoc) bool {
 	return true
 }
 
-// String implements the fmt.Stringer interface.
+// String implements the fmt.Stringer interface. It returns the same representation as StringIndent
+// on a single line.
 func (d Doc) String() string {
-	var buf bytes.Buffer
-	buf.Write([]byte("bson.Document{"))
-	for idx, elem := range d {
-		if idx > 0 {
-			buf.Write([]byte(", "))
-		}
-		fmt.Fprintf(&buf, "%v", elem)
-	}
-	buf.WriteByte('}')
-
-	return buf.String()
+	var p printer
+	p.doc(d)
+	return p.buf.String()
 }
 
 func (Doc) idoc() {}
@@ -8,11 +8,15 @@ package bsonx
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+	"github.com/mongodb/mongo-go-driver/bson/objectid"
 	"github.com/mongodb/mongo-go-driver/x/bsonx/bsoncore"
 )
 
@@ -108,6 +112,113 @@ func TestDocument(t *testing.T) {
 			})
 		}
 	})
+	t.Run("Filter", func(t *testing.T) {
+		t.Parallel()
+		start := Doc{
+			{"x_foo", Int32(1)},
+			{"bar", String("baz")},
+			{"x_qux", String("quux")},
+			{"corge", Int64(2)},
+		}
+		testCases := []struct {
+			name string
+			keep func(Elem) bool
+			want Doc
+		}{
+			{
+				"key prefix",
+				func(e Elem) bool { return strings.HasPrefix(e.Key, "x_") },
+				Doc{{"x_foo", Int32(1)}, {"x_qux", String("quux")}},
+			},
+			{
+				"value type",
+				func(e Elem) bool { return e.Value.Type() == bsontype.String },
+				Doc{{"bar", String("baz")}, {"x_qux", String("quux")}},
+			},
+			{"none", func(Elem) bool { return false }, Doc{}},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				got := start.Filter(tc.keep)
+				if !cmp.Equal(got, tc.want) {
+					t.Errorf("Filtered documents do not match. got %v; want %v", got, tc.want)
+				}
+				if len(start) != 4 {
+					t.Errorf("Filter should not modify the original document. got %v", start)
+				}
+			})
+		}
+	})
+	t.Run("SetPath", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			name  string
+			start Doc
+			path  string
+			val   Val
+			want  Doc
+			err   bool
+		}{
+			{"empty path", Doc{}, "", Null(), Doc{}, true},
+			{"top level", Doc{{"foo", Null()}}, "foo", Int32(1), Doc{{"foo", Int32(1)}}, false},
+			{
+				"existing subdocument",
+				Doc{{"foo", Document(Doc{{"bar", Null()}, {"baz", Null()}})}}, "foo.baz", Int32(1),
+				Doc{{"foo", Document(Doc{{"bar", Null()}, {"baz", Int32(1)}})}}, false,
+			},
+			{
+				"creates subdocuments",
+				Doc{{"foo", Null()}}, "bar.baz.qux", Int32(1),
+				Doc{{"foo", Null()}, {"bar", Document(Doc{{"baz", Document(Doc{{"qux", Int32(1)}})}})}}, false,
+			},
+			{
+				"array index",
+				Doc{{"foo", Array(Arr{Int32(1), Int32(2)})}}, "foo.1", Int32(3),
+				Doc{{"foo", Array(Arr{Int32(1), Int32(3)})}}, false,
+			},
+			{
+				"array append",
+				Doc{{"foo", Array(Arr{Int32(1), Int32(2)})}}, "foo.2", Int32(3),
+				Doc{{"foo", Array(Arr{Int32(1), Int32(2), Int32(3)})}}, false,
+			},
+			{
+				"document in array",
+				Doc{{"foo", Array(Arr{Document(Doc{{"bar", Null()}})})}}, "foo.0.bar", Int32(1),
+				Doc{{"foo", Array(Arr{Document(Doc{{"bar", Int32(1)}})})}}, false,
+			},
+			{
+				"append document to array",
+				Doc{{"foo", Array(Arr{})}}, "foo.0.bar", Int32(1),
+				Doc{{"foo", Array(Arr{Document(Doc{{"bar", Int32(1)}})})}}, false,
+			},
+			{"array index out of range", Doc{{"foo", Array(Arr{Int32(1)})}}, "foo.2", Int32(3), nil, true},
+			{"invalid array index", Doc{{"foo", Array(Arr{Int32(1)})}}, "foo.bar", Int32(3), nil, true},
+			{"cannot traverse", Doc{{"foo", Double(3.14159)}}, "foo.bar", Int32(3), nil, true},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				got, err := tc.start.SetPath(tc.path, tc.val)
+				if tc.err {
+					if err == nil {
+						t.Errorf("Expected an error but got nil")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if !got.Equal(tc.want) {
+					t.Errorf("Documents do not match. got %v; want %v", got, tc.want)
+				}
+			})
+		}
+	})
 	testCases := []struct {
 		name   string
 		fn     interface{}   // method to call
@@ -285,3 +396,114 @@ func TestDocument(t *testing.T) {
 		})
 	}
 }
+
+func TestDocumentStringIndent(t *testing.T) {
+	oid := objectid.ObjectID{0x5c, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b}
+	doc := Doc{
+		{"name", String("foo")},
+		{"nested", Document(Doc{
+			{"n", Int32(1)},
+			{"empty", Document(Doc{})},
+		})},
+		{"arr", Array(Arr{Int64(2), Document(Doc{{"ok", Boolean(true)}}), Array(Arr{})})},
+		{"bin", Binary(0x00, []byte{0xde, 0xad, 0xbe, 0xef})},
+		{"oid", ObjectID(oid)},
+		{"date", Time(time.Date(2018, time.November, 5, 12, 30, 0, 0, time.UTC))},
+		{"null", Null()},
+	}
+
+	t.Run("StringIndent", func(t *testing.T) {
+		want := `{
+>  "name": string("foo"),
+>  "nested": {
+>    "n": int32(1),
+>    "empty": {}
+>  },
+>  "arr": [
+>    int64(2),
+>    {
+>      "ok": bool(true)
+>    },
+>    []
+>  ],
+>  "bin": binary(0x00, deadbeef),
+>  "oid": objectID(5c0102030405060708090a0b),
+>  "date": dateTime(2018-11-05T12:30:00Z),
+>  "null": null
+>}`
+		got := doc.StringIndent(">", "  ")
+		if got != want {
+			t.Errorf("StringIndent output does not match.\ngot:\n%s\nwant:\n%s", got, want)
+		}
+	})
+	t.Run("String", func(t *testing.T) {
+		want := `{"name": string("foo"), "nested": {"n": int32(1), "empty": {}}, ` +
+			`"arr": [int64(2), {"ok": bool(true)}, []], "bin": binary(0x00, deadbeef), ` +
+			`"oid": objectID(5c0102030405060708090a0b), "date": dateTime(2018-11-05T12:30:00Z), "null": null}`
+		got := doc.String()
+		if got != want {
+			t.Errorf("String output does not match.\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		if got := (Doc{}).StringIndent("", "\t"); got != "{}" {
+			t.Errorf("StringIndent output does not match. got %q; want %q", got, "{}")
+		}
+	})
+}
+
+func TestEqual(t *testing.T) {
+	nested := func(innermost Val) Doc {
+		return Doc{
+			{"a", Int32(1)},
+			{"b", Document(Doc{
+				{"c", Array(Arr{String("x"), Document(Doc{{"d", innermost}})})},
+			})},
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		d1    Doc
+		d2    Doc
+		equal bool
+	}{
+		{"empty", Doc{}, Doc{}, true},
+		{"nested", nested(Int64(2)), nested(Int64(2)), true},
+		{"nested mismatch", nested(Int64(2)), nested(Int64(3)), false},
+		{"nested type mismatch", nested(Int64(2)), nested(Int32(2)), false},
+		{"order sensitive", Doc{{"a", Null()}, {"b", Null()}}, Doc{{"b", Null()}, {"a", Null()}}, false},
+		{"key mismatch", Doc{{"a", Null()}}, Doc{{"b", Null()}}, false},
+		{"length mismatch", Doc{{"a", Null()}}, Doc{{"a", Null()}, {"b", Null()}}, false},
+		{"array length mismatch", Doc{{"a", Array(Arr{Int32(1)})}}, Doc{{"a", Array(Arr{Int32(1), Int32(1)})}}, false},
+		{"NaN", Doc{{"a", Double(math.NaN())}}, Doc{{"a", Double(math.NaN())}}, true},
+		{"NaN payloads", Doc{{"a", Double(math.Float64frombits(0x7FF8000000000001))}}, Doc{{"a", Double(math.NaN())}}, true},
+		{"NaN and number", Doc{{"a", Double(math.NaN())}}, Doc{{"a", Double(1)}}, false},
+		{"signed zeros", Doc{{"a", Double(0)}}, Doc{{"a", Double(math.Copysign(0, -1))}}, false},
+		{"binary", Doc{{"a", Binary(0x80, []byte{0x01, 0x02})}}, Doc{{"a", Binary(0x80, []byte{0x01, 0x02})}}, true},
+		{"binary data mismatch", Doc{{"a", Binary(0x80, []byte{0x01, 0x02})}}, Doc{{"a", Binary(0x80, []byte{0x01, 0x03})}}, false},
+		{"binary subtype mismatch", Doc{{"a", Binary(0x80, []byte{0x01})}}, Doc{{"a", Binary(0x00, []byte{0x01})}}, false},
+	}
+
+	t.Run("nil and empty", func(t *testing.T) {
+		if !Doc(nil).Equal(Doc{}) {
+			t.Error("Expected a nil document to equal an empty document")
+		}
+	})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.d1.Equal(tc.d2); got != tc.equal {
+				t.Errorf("Doc.Equal returned %v; want %v", got, tc.equal)
+			}
+			if got := tc.d2.Equal(tc.d1); got != tc.equal {
+				t.Errorf("Doc.Equal is not symmetric. got %v; want %v", got, tc.equal)
+			}
+			if got := Document(tc.d1).Equal(Document(tc.d2)); got != tc.equal {
+				t.Errorf("Val.Equal returned %v; want %v", got, tc.equal)
+			}
+			if got := (Arr{Document(tc.d1)}).Equal(Arr{Document(tc.d2)}); got != tc.equal {
+				t.Errorf("Arr.Equal returned %v; want %v", got, tc.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/bsontype"
+)
+
+// StringIndent returns a human-readable, type-annotated representation of the document. Each
+// element of a document or array begins on a new line that starts with prefix followed by one copy
+// of indent per level of nesting. Like json.MarshalIndent, the output does not begin with prefix.
+//
+// Binary values are rendered as hex, ObjectIDs as their hex string, and datetimes in RFC 3339.
+func (d Doc) StringIndent(prefix, indent string) string {
+	p := printer{prefix: prefix, indent: indent, multiline: true}
+	p.doc(d)
+	return p.buf.String()
+}
+
+// printer renders documents for String and StringIndent.
+type printer struct {
+	buf       bytes.Buffer
+	prefix    string
+	indent    string
+	multiline bool
+	depth     int
+}
+
+// separator writes what comes before the idx-th element of a document or array.
+func (p *printer) separator(idx int) {
+	if idx > 0 {
+		p.buf.WriteByte(',')
+		if !p.multiline {
+			p.buf.WriteByte(' ')
+		}
+	}
+	p.newline()
+}
+
+func (p *printer) newline() {
+	if !p.multiline {
+		return
+	}
+	p.buf.WriteByte('\n')
+	p.buf.WriteString(p.prefix)
+	for i := 0; i < p.depth; i++ {
+		p.buf.WriteString(p.indent)
+	}
+}
+
+func (p *printer) doc(d Doc) {
+	if len(d) == 0 {
+		p.buf.WriteString("{}")
+		return
+	}
+
+	p.buf.WriteByte('{')
+	p.depth++
+	for idx, elem := range d {
+		p.separator(idx)
+		p.buf.WriteString(strconv.Quote(elem.Key))
+		p.buf.WriteString(": ")
+		p.value(elem.Value)
+	}
+	p.depth--
+	p.newline()
+	p.buf.WriteByte('}')
+}
+
+func (p *printer) array(a Arr) {
+	if len(a) == 0 {
+		p.buf.WriteString("[]")
+		return
+	}
+
+	p.buf.WriteByte('[')
+	p.depth++
+	for idx, val := range a {
+		p.separator(idx)
+		p.value(val)
+	}
+	p.depth--
+	p.newline()
+	p.buf.WriteByte(']')
+}
+
+func (p *printer) value(v Val) {
+	switch v.Type() {
+	case bsontype.Double:
+		fmt.Fprintf(&p.buf, "double(%v)", v.Double())
+	case bsontype.String:
+		fmt.Fprintf(&p.buf, "string(%q)", v.StringValue())
+	case bsontype.EmbeddedDocument:
+		p.doc(v.Document())
+	case bsontype.Array:
+		p.array(v.Array())
+	case bsontype.Binary:
+		subtype, data := v.Binary()
+		fmt.Fprintf(&p.buf, "binary(0x%02x, %s)", subtype, hex.EncodeToString(data))
+	case bsontype.Undefined:
+		p.buf.WriteString("undefined")
+	case bsontype.ObjectID:
+		fmt.Fprintf(&p.buf, "objectID(%s)", v.ObjectID().Hex())
+	case bsontype.Boolean:
+		fmt.Fprintf(&p.buf, "bool(%t)", v.Boolean())
+	case bsontype.DateTime:
+		fmt.Fprintf(&p.buf, "dateTime(%s)", v.Time().UTC().Format(time.RFC3339Nano))
+	case bsontype.Null:
+		p.buf.WriteString("null")
+	case bsontype.Regex:
+		pattern, options := v.Regex()
+		fmt.Fprintf(&p.buf, "regex(/%s/%s)", pattern, options)
+	case bsontype.DBPointer:
+		ns, oid := v.DBPointer()
+		fmt.Fprintf(&p.buf, "dbPointer(%q, %s)", ns, oid.Hex())
+	case bsontype.JavaScript:
+		fmt.Fprintf(&p.buf, "javascript(%q)", v.JavaScript())
+	case bsontype.Symbol:
+		fmt.Fprintf(&p.buf, "symbol(%q)", v.Symbol())
+	case bsontype.CodeWithScope:
+		code, scope := v.CodeWithScope()
+		fmt.Fprintf(&p.buf, "codeWithScope(%q, ", code)
+		p.doc(scope)
+		p.buf.WriteByte(')')
+	case bsontype.Int32:
+		fmt.Fprintf(&p.buf, "int32(%d)", v.Int32())
+	case bsontype.Timestamp:
+		t, i := v.Timestamp()
+		fmt.Fprintf(&p.buf, "timestamp(%d, %d)", t, i)
+	case bsontype.Int64:
+		fmt.Fprintf(&p.buf, "int64(%d)", v.Int64())
+	case bsontype.Decimal128:
+		fmt.Fprintf(&p.buf, "decimal128(%s)", v.Decimal128())
+	case bsontype.MinKey:
+		p.buf.WriteString("minKey")
+	case bsontype.MaxKey:
+		p.buf.WriteString("maxKey")
+	default:
+		p.buf.WriteString("null")
+	}
+}
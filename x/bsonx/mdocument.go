@@ -94,6 +94,11 @@ func (d MDoc) LookupElementErr(key ...string) (Elem, error) {
 			elem, err = tt.LookupElementErr(key[1:]...)
 		case MDoc:
 			elem, err = tt.LookupElementErr(key[1:]...)
+		case RawDoc:
+			var doc Doc
+			if doc, err = val.asDoc(); err == nil {
+				elem, err = doc.LookupElementErr(key[1:]...)
+			}
 		}
 	default:
 		return Elem{}, KeyNotFound{Type: val.Type()}
@@ -202,6 +207,9 @@ func (d MDoc) Equal(id IDoc) bool {
 		if len(unique) != len(d) {
 			return false
 		}
+	case RawDoc:
+		doc, err := ReadDoc(tt)
+		return err == nil && d.Equal(doc)
 	case nil:
 		return d == nil
 	default:
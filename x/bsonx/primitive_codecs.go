@@ -438,6 +438,10 @@ func (pc PrimitiveCodecs) encodeValue(ec bsoncodec.EncodeContext, vw bsonrw.Valu
 	case bsontype.String:
 		err = vw.WriteString(val.StringValue())
 	case bsontype.EmbeddedDocument:
+		if raw, ok := val.primitive.(RawDoc); ok {
+			err = bsonrw.Copier{}.CopyDocumentFromBytes(vw, raw)
+			break
+		}
 		var encoder bsoncodec.ValueEncoder
 		encoder, err = ec.LookupEncoder(tDocument)
 		if err != nil {
@@ -474,7 +478,11 @@ func (pc PrimitiveCodecs) encodeValue(ec bsoncodec.EncodeContext, vw bsonrw.Valu
 	case bsontype.Symbol:
 		err = vw.WriteSymbol(val.Symbol())
 	case bsontype.CodeWithScope:
-		code, scope := val.CodeWithScope()
+		code, scope, ok := val.CodeWithScopeOK()
+		if !ok {
+			err = errors.New("code with scope value has an invalid scope")
+			break
+		}
 
 		var cwsw bsonrw.DocumentWriter
 		cwsw, err = vw.WriteCodeWithScope(code)
@@ -0,0 +1,15 @@
+// Copyright (C) MongoDB, Inc. 2017-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package bsonx
+
+// RawDoc is a BSON document that is kept in its serialized form. A Val constructed from a RawDoc
+// marshals to exactly these bytes, so a pre-serialized document can be embedded in another without
+// being read and written again. The bytes must be a valid BSON document; they are only read into a
+// Doc when the value is inspected.
+type RawDoc []byte
+
+func (RawDoc) idoc() {}
@@ -115,6 +115,12 @@ func (v Val) Interface() interface{} {
 			return v.primitive.(Doc)
 		case MDoc:
 			return v.primitive.(MDoc)
+		case RawDoc:
+			// A RawDoc that cannot be read is returned as is.
+			if doc, err := v.asDoc(); err == nil {
+				return doc
+			}
+			return v.primitive.(RawDoc)
 		default:
 			return primitive.Null{}
 		}
@@ -175,6 +181,8 @@ func (v Val) MarshalBSONValue() (bsontype.Type, []byte, error) {
 			t, data, _ = v.primitive.(Doc).MarshalBSONValue() // Doc.MarshalBSONValue never returns an error.
 		case MDoc:
 			t, data, _ = v.primitive.(MDoc).MarshalBSONValue() // MDoc.MarshalBSONValue never returns an error.
+		case RawDoc:
+			data = v.primitive.(RawDoc)
 		}
 	case bsontype.Array:
 		t, data, _ = v.Array().MarshalBSONValue() // Arr.MarshalBSON never returns an error.
@@ -200,10 +208,16 @@ func (v Val) MarshalBSONValue() (bsontype.Type, []byte, error) {
 	case bsontype.Symbol:
 		data = bsoncore.AppendSymbol(data, string(v.Symbol()))
 	case bsontype.CodeWithScope:
-		code, doc := v.CodeWithScope()
-		var scope []byte
-		scope, _ = doc.MarshalBSON() // Doc.MarshalBSON never returns an error.
-		data = bsoncore.AppendCodeWithScope(data, code, scope)
+		cws := v.primitive.(primitive.CodeWithScope)
+		scope, ok := cws.Scope.(RawDoc)
+		if !ok {
+			doc, err := scopeAsDoc(cws.Scope)
+			if err != nil {
+				return bsontype.Type(0), nil, err
+			}
+			scope, _ = doc.MarshalBSON() // Doc.MarshalBSON never returns an error.
+		}
+		data = bsoncore.AppendCodeWithScope(data, string(cws.Code), scope)
 	case bsontype.Int32:
 		data = bsoncore.AppendInt32(data, v.Int32())
 	case bsontype.Timestamp:
@@ -255,7 +269,8 @@ func (v *Val) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
 		var subtype byte
 		var bindata []byte
 		subtype, bindata, rem, ok = bsoncore.ReadBinary(data)
-		*v = Binary(subtype, bindata)
+		// copy the data so that the value does not alias the buffer it was read from
+		*v = Binary(subtype, append([]byte(nil), bindata...))
 	case bsontype.Undefined:
 		*v = Undefined()
 	case bsontype.ObjectID:
@@ -383,37 +398,52 @@ func (v Val) StringValueOK() (string, bool) {
 	return v.string(), true
 }
 
-func (v Val) asDoc() Doc {
-	doc, ok := v.primitive.(Doc)
-	if ok {
-		return doc
+// asDoc returns the embedded document v holds as a Doc. An error is only returned if v holds a
+// RawDoc that is not a valid document.
+func (v Val) asDoc() (Doc, error) {
+	switch tt := v.primitive.(type) {
+	case Doc:
+		return tt, nil
+	case RawDoc:
+		return ReadDoc(tt)
 	}
+	var doc Doc
 	mdoc := v.primitive.(MDoc)
 	for k, v := range mdoc {
 		doc = append(doc, Elem{k, v})
 	}
-	return doc
+	return doc, nil
 }
 
-func (v Val) asMDoc() MDoc {
+// asMDoc returns the embedded document v holds as an MDoc. An error is only returned if v holds a
+// RawDoc that is not a valid document.
+func (v Val) asMDoc() (MDoc, error) {
 	mdoc, ok := v.primitive.(MDoc)
 	if ok {
-		return mdoc
+		return mdoc, nil
+	}
+	doc, err := v.asDoc()
+	if err != nil {
+		return nil, err
 	}
-	doc := v.primitive.(Doc)
+	mdoc = make(MDoc, len(doc))
 	for _, elem := range doc {
 		mdoc[elem.Key] = elem.Value
 	}
-	return mdoc
+	return mdoc, nil
 }
 
 // Document returns the BSON embedded document value the Value represents. It panics if the value
-// is a BSON type other than embedded document.
+// is a BSON type other than embedded document, or holds a RawDoc that is not a valid document.
 func (v Val) Document() Doc {
 	if v.t != bsontype.EmbeddedDocument {
 		panic(ElementTypeError{"bson.Value.Document", v.t})
 	}
-	return v.asDoc()
+	doc, err := v.asDoc()
+	if err != nil {
+		panic(err)
+	}
+	return doc
 }
 
 // DocumentOK is the same as Document, except it returns a boolean
@@ -422,16 +452,21 @@ func (v Val) DocumentOK() (Doc, bool) {
 	if v.t != bsontype.EmbeddedDocument {
 		return nil, false
 	}
-	return v.asDoc(), true
+	doc, err := v.asDoc()
+	return doc, err == nil
 }
 
 // MDocument returns the BSON embedded document value the Value represents. It panics if the value
-// is a BSON type other than embedded document.
+// is a BSON type other than embedded document, or holds a RawDoc that is not a valid document.
 func (v Val) MDocument() MDoc {
 	if v.t != bsontype.EmbeddedDocument {
 		panic(ElementTypeError{"bson.Value.MDocument", v.t})
 	}
-	return v.asMDoc()
+	mdoc, err := v.asMDoc()
+	if err != nil {
+		panic(err)
+	}
+	return mdoc
 }
 
 // MDocumentOK is the same as Document, except it returns a boolean
@@ -440,7 +475,8 @@ func (v Val) MDocumentOK() (MDoc, bool) {
 	if v.t != bsontype.EmbeddedDocument {
 		return nil, false
 	}
-	return v.asMDoc(), true
+	mdoc, err := v.asMDoc()
+	return mdoc, err == nil
 }
 
 // Array returns the BSON array value the Value represents. It panics if the value is a BSON type
@@ -672,13 +708,18 @@ func (v Val) SymbolOK() (string, bool) {
 }
 
 // CodeWithScope returns the BSON code with scope value the Value represents. It panics if the
-// value is a BSON type other than code with scope.
+// value is a BSON type other than code with scope, or its scope is a RawDoc that is not a valid
+// document.
 func (v Val) CodeWithScope() (string, Doc) {
 	if v.t != bsontype.CodeWithScope {
 		panic(ElementTypeError{"bson.Value.CodeWithScope", v.t})
 	}
 	cws := v.primitive.(primitive.CodeWithScope)
-	return string(cws.Code), cws.Scope.(Doc)
+	scope, err := scopeAsDoc(cws.Scope)
+	if err != nil {
+		panic(err)
+	}
+	return string(cws.Code), scope
 }
 
 // CodeWithScopeOK is the same as JavascriptWithScope,
@@ -688,7 +729,28 @@ func (v Val) CodeWithScopeOK() (string, Doc, bool) {
 		return "", nil, false
 	}
 	cws := v.primitive.(primitive.CodeWithScope)
-	return string(cws.Code), cws.Scope.(Doc), true
+	scope, err := scopeAsDoc(cws.Scope)
+	return string(cws.Code), scope, err == nil
+}
+
+// scopeAsDoc converts the scope of a code with scope value into a Doc. The scope may have been
+// constructed from a Doc, an MDoc, or a RawDoc. An error is only returned for a RawDoc that is not a
+// valid document.
+func scopeAsDoc(scope interface{}) (Doc, error) {
+	switch tt := scope.(type) {
+	case Doc:
+		return tt, nil
+	case MDoc:
+		doc := make(Doc, 0, len(tt))
+		for k, v := range tt {
+			doc = append(doc, Elem{k, v})
+		}
+		return doc, nil
+	case RawDoc:
+		return ReadDoc(tt)
+	default:
+		return nil, nil
+	}
 }
 
 // Int32 returns the BSON int32 the Value represents. It panics if the value is a BSON type
@@ -810,7 +872,8 @@ func (v Val) MaxKeyOK() bool {
 }
 
 // Equal compares v to v2 and returns true if they are equal. Unknown BSON types are
-// never equal. Two empty values are equal.
+// never equal. Two empty values are equal. Doubles are compared bitwise, except that
+// any two NaNs are equal.
 func (v Val) Equal(v2 Val) bool {
 	if v.Type() != v2.Type() {
 		return false
@@ -820,7 +883,12 @@ func (v Val) Equal(v2 Val) bool {
 	}
 
 	switch v.Type() {
-	case bsontype.Double, bsontype.DateTime, bsontype.Timestamp, bsontype.Int64:
+	case bsontype.Double:
+		if math.IsNaN(v.Double()) && math.IsNaN(v2.Double()) {
+			return true
+		}
+		return bytes.Equal(v.bootstrap[0:8], v2.bootstrap[0:8])
+	case bsontype.DateTime, bsontype.Timestamp, bsontype.Int64:
 		return bytes.Equal(v.bootstrap[0:8], v2.bootstrap[0:8])
 	case bsontype.String:
 		return v.string() == v2.string()
@@ -869,9 +937,13 @@ func (v Val) equalDocs(v2 Val) bool {
 	_, ok1 := v.primitive.(MDoc)
 	_, ok2 := v2.primitive.(MDoc)
 	if ok1 || ok2 {
-		return v.asMDoc().Equal(v2.asMDoc())
+		d1, err1 := v.asMDoc()
+		d2, err2 := v2.asMDoc()
+		return err1 == nil && err2 == nil && d1.Equal(d2)
 	}
-	return v.asDoc().Equal(v2.asDoc())
+	d1, err1 := v.asDoc()
+	d2, err2 := v2.asDoc()
+	return err1 == nil && err2 == nil && d1.Equal(d2)
 }
 
 func (Val) equalInterfaceDocs(i, i2 interface{}) bool {
@@ -888,6 +960,13 @@ func (Val) equalInterfaceDocs(i, i2 interface{}) bool {
 			return false
 		}
 		return d.Equal(d2)
+	case RawDoc:
+		d2, ok := i2.(IDoc)
+		if !ok {
+			return false
+		}
+		doc, err := ReadDoc(d)
+		return err == nil && doc.Equal(d2)
 	case nil:
 		return i2 == nil
 	default:
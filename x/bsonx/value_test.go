@@ -91,6 +91,8 @@ func TestValue(t *testing.T) {
 		{"MDocument->Document/success", Document(MDoc{}).Document, []interface{}{Doc{}}, nil},
 		{"Document->MDocumentOK/success", Document(Doc{}).MDocumentOK, []interface{}{MDoc{}, true}, nil},
 		{"MDocument->DocumentOK/success", Document(MDoc{}).DocumentOK, []interface{}{Doc{}, true}, nil},
+		{"Document->MDocumentOK/non-empty", Document(Doc{{"foo", Int32(1)}}).MDocumentOK, []interface{}{MDoc{"foo": Int32(1)}, true}, nil},
+		{"MDocument->DocumentOK/non-empty", Document(MDoc{"foo": Int32(1)}).DocumentOK, []interface{}{Doc{{"foo", Int32(1)}}, true}, nil},
 		{"Array/panic", Double(0).Array, nil, ElementTypeError{"bson.Value.Array", bsontype.Double}},
 		{"Array/success", Array(Arr{}).Array, []interface{}{Arr{}}, nil},
 		{"ArrayOK/error", Double(0).ArrayOK, []interface{}{(Arr)(nil), false}, nil},
@@ -149,6 +151,9 @@ func TestValue(t *testing.T) {
 		{"CodeWithScope/success", CodeWithScope(code, scope).CodeWithScope, []interface{}{code, scope}, nil},
 		{"CodeWithScopeOK/error", Double(0).CodeWithScopeOK, []interface{}{"", (Doc)(nil), false}, nil},
 		{"CodeWithScopeOK/success", CodeWithScope(code, scope).CodeWithScopeOK, []interface{}{code, scope, true}, nil},
+		{"CodeWithScope/MDocument scope", CodeWithScope(code, MDoc{"foo": Int32(1)}).CodeWithScope, []interface{}{code, Doc{{"foo", Int32(1)}}}, nil},
+		{"CodeWithScopeOK/MDocument scope", CodeWithScope(code, MDoc{"foo": Int32(1)}).CodeWithScopeOK, []interface{}{code, Doc{{"foo", Int32(1)}}, true}, nil},
+		{"CodeWithScopeOK/nil scope", CodeWithScope(code, nil).CodeWithScopeOK, []interface{}{code, (Doc)(nil), true}, nil},
 		{"Int32/panic", Double(0).Int32, nil, ElementTypeError{"bson.Value.Int32", bsontype.Double}},
 		{"Int32/success", Int32(12345).Int32, []interface{}{int32(12345)}, nil},
 		{"Int32OK/error", Double(0).Int32OK, []interface{}{int32(0), false}, nil},
@@ -238,6 +243,8 @@ func TestValue(t *testing.T) {
 			{"Null/Equal", Null(), Null(), true},
 			{"Regex/Equal", Regex(regex.Pattern, regex.Options), Regex(regex.Pattern, regex.Options), true},
 			{"Regex/Not Equal", Regex(regex.Pattern, regex.Options), Regex("", ""), false},
+			{"Regex/Not Equal (options)", Regex("abc", "i"), Regex("abc", "m"), false},
+			{"Regex/Equal (options order)", Regex("abc", "xmi"), Regex("abc", "imx"), true},
 			{"DBPointer/Equal", DBPointer(dbptr.DB, dbptr.Pointer), DBPointer(dbptr.DB, dbptr.Pointer), true},
 			{"DBPointer/Not Equal", DBPointer(dbptr.DB, dbptr.Pointer), DBPointer("", objectid.ObjectID{}), false},
 			{"JavaScript/Equal", JavaScript(js), JavaScript(js), true},
@@ -257,6 +264,9 @@ func TestValue(t *testing.T) {
 			{"Decimal128/Not Equal", Decimal128(d128), Decimal128(decimal.Decimal128{}), false},
 			{"MinKey/Equal", MinKey(), MinKey(), true},
 			{"MaxKey/Equal", MaxKey(), MaxKey(), true},
+			{"MinKey/Not Equal (MaxKey)", MinKey(), MaxKey(), false},
+			{"MinKey/Not Equal (Null)", MinKey(), Null(), false},
+			{"MaxKey/Not Equal (Null)", MaxKey(), Null(), false},
 		}
 
 		for _, tc := range testCases {
@@ -271,3 +281,71 @@ func TestValue(t *testing.T) {
 		}
 	})
 }
+
+func TestRawDocValue(t *testing.T) {
+	doc := Doc{{"a", Int32(1)}, {"b", Document(Doc{{"c", String("d")}})}}
+	raw, err := doc.MarshalBSON()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	val := Document(RawDoc(raw))
+	if val.Type() != bsontype.EmbeddedDocument {
+		t.Fatalf("Incorrect type. got %v; want %v", val.Type(), bsontype.EmbeddedDocument)
+	}
+	typ, data, err := val.MarshalBSONValue()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if typ != bsontype.EmbeddedDocument || !cmp.Equal(data, raw) {
+		t.Errorf("Raw document was not preserved. got %v %v; want %v %v", typ, data, bsontype.EmbeddedDocument, raw)
+	}
+
+	if !val.Equal(Document(doc)) || !Document(doc).Equal(val) {
+		t.Errorf("Raw document value should equal the document it was marshaled from")
+	}
+	if got := val.Document(); !got.Equal(doc) {
+		t.Errorf("Documents do not match. got %v; want %v", got, doc)
+	}
+	if got := val.MDocument(); got["a"].Int32() != 1 {
+		t.Errorf("Incorrect value for a. got %v; want %v", got["a"], Int32(1))
+	}
+
+	outer := Doc{{"filter", val}}
+	if got := outer.Lookup("filter", "b", "c"); got.StringValue() != "d" {
+		t.Errorf("Incorrect value for filter.b.c. got %v; want %v", got, String("d"))
+	}
+
+	if got := Document(RawDoc(nil)); got.Type() != bsontype.Null {
+		t.Errorf("A nil raw document should be null. got %v", got.Type())
+	}
+
+	if !doc.Equal(RawDoc(raw)) || !doc.Copy().Equal(RawDoc(raw)) {
+		t.Errorf("Doc should equal the raw document it was marshaled to")
+	}
+	if mdoc := val.MDocument(); !mdoc.Equal(RawDoc(raw)) {
+		t.Errorf("MDoc should equal the raw document it was read from")
+	}
+
+	cws := CodeWithScope("x", RawDoc(raw))
+	if _, scope := cws.CodeWithScope(); !scope.Equal(doc) {
+		t.Errorf("Scopes do not match. got %v; want %v", scope, doc)
+	}
+	if !cws.Equal(CodeWithScope("x", doc)) {
+		t.Errorf("A raw scope should equal the document it was marshaled from")
+	}
+
+	invalid := Document(RawDoc(raw[:len(raw)-1]))
+	if _, ok := invalid.DocumentOK(); ok {
+		t.Errorf("DocumentOK should fail for an invalid raw document")
+	}
+	if invalid.Equal(val) {
+		t.Errorf("An invalid raw document should not equal a valid one")
+	}
+	if _, err := (Doc{{"filter", invalid}}).LookupErr("filter", "a"); err == nil {
+		t.Errorf("Looking up a key in an invalid raw document should fail")
+	}
+	if _, _, ok := CodeWithScope("x", RawDoc(raw[:len(raw)-1])).CodeWithScopeOK(); ok {
+		t.Errorf("CodeWithScopeOK should fail for an invalid raw scope")
+	}
+}